@@ -0,0 +1,531 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package behaviortreebuilder provides a fluent API for constructing Behavior
+// Trees in Go.
+//
+// Nodes are created with constructors like [Sequence], [Retry] and [Task] and
+// customized with methods like [Node.Named]:
+//
+//	tree := behaviortreebuilder.NewTree("pick",
+//		behaviortreebuilder.Sequence(
+//			behaviortreebuilder.Task("ai.intrinsic.move_robot", moveParams).Named("approach"),
+//			behaviortreebuilder.Retry(3,
+//				behaviortreebuilder.Task("ai.intrinsic.grasp", graspParams),
+//			).Recovery(behaviortreebuilder.Task("ai.intrinsic.open_gripper", nil)),
+//		),
+//	)
+//	bt, err := tree.Build()
+//
+// Skills and parameterizable behavior trees (processes) are both called with
+// [Task]. Errors in the use of the builder are collected and returned by
+// [Tree.Build], which also assigns unique node ids and validates the tree.
+package behaviortreebuilder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"intrinsic/executive/go/behaviortree"
+	"intrinsic/executive/go/behaviortreelint"
+	"intrinsic/util/proto/descriptor"
+	"intrinsic/util/proto/registryutil"
+
+	awpb "intrinsic/executive/proto/any_with_assignments_go_proto"
+	bcpb "intrinsic/executive/proto/behavior_call_go_proto"
+	btpb "intrinsic/executive/proto/behavior_tree_go_proto"
+	cepb "intrinsic/executive/proto/code_execution_go_proto"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Node is a node of a Behavior Tree under construction.
+type Node struct {
+	node *btpb.BehaviorTree_Node
+	// files are the file descriptor sets of all messages packed into Any protos
+	// in this node and its descendants.
+	files []*descriptorpb.FileDescriptorSet
+	errs  []error
+}
+
+func newNode(node *btpb.BehaviorTree_Node, children ...*Node) *Node {
+	n := &Node{node: node}
+	for i, c := range children {
+		if c == nil {
+			n.errorf("child %d is nil", i)
+			continue
+		}
+		n.adopt(c)
+	}
+	return n
+}
+
+// adopt merges the file descriptor sets and errors of a child into n.
+func (n *Node) adopt(child *Node) {
+	n.files = append(n.files, child.files...)
+	n.errs = append(n.errs, child.errs...)
+}
+
+func (n *Node) errorf(format string, args ...any) {
+	n.errs = append(n.errs, fmt.Errorf("%s node: %s", behaviortree.NodeType(n.node), fmt.Sprintf(format, args...)))
+}
+
+func (n *Node) pack(m proto.Message) *anypb.Any {
+	a, err := anypb.New(m)
+	if err != nil {
+		n.errorf("cannot pack %s: %v", m.ProtoReflect().Descriptor().FullName(), err)
+		return nil
+	}
+	n.files = append(n.files, descriptor.FileDescriptorSetFrom(m))
+	return a
+}
+
+func protos(nodes []*Node) []*btpb.BehaviorTree_Node {
+	result := make([]*btpb.BehaviorTree_Node, 0, len(nodes))
+	for _, n := range nodes {
+		if n != nil {
+			result = append(result, n.node)
+		}
+	}
+	return result
+}
+
+// Sequence creates a node that executes its children one after another until
+// one fails.
+func Sequence(children ...*Node) *Node {
+	return newNode(&btpb.BehaviorTree_Node{NodeType: &btpb.BehaviorTree_Node_Sequence{
+		Sequence: &btpb.BehaviorTree_SequenceNode{Children: protos(children)},
+	}}, children...)
+}
+
+// Parallel creates a node that executes its children concurrently.
+func Parallel(children ...*Node) *Node {
+	return newNode(&btpb.BehaviorTree_Node{NodeType: &btpb.BehaviorTree_Node_Parallel{
+		Parallel: &btpb.BehaviorTree_ParallelNode{Children: protos(children)},
+	}}, children...)
+}
+
+// SelectorBranch is a branch of a selector node, see [Case].
+type SelectorBranch struct {
+	condition *btpb.BehaviorTree_Condition
+	node      *Node
+}
+
+// Case creates a branch of a selector node that executes the given node if
+// the condition is satisfied.
+func Case(condition *btpb.BehaviorTree_Condition, node *Node) SelectorBranch {
+	return SelectorBranch{condition: condition, node: node}
+}
+
+// Selector creates a node that executes the node of the first branch whose
+// condition is satisfied.
+func Selector(branches ...SelectorBranch) *Node {
+	selector := &btpb.BehaviorTree_SelectorNode{}
+	n := newNode(&btpb.BehaviorTree_Node{NodeType: &btpb.BehaviorTree_Node_Selector{Selector: selector}})
+	for i, b := range branches {
+		if b.node == nil {
+			n.errorf("branch %d has no node", i)
+			continue
+		}
+		if b.node.node.GetDecorators().GetCondition() != nil {
+			n.errorf("node of branch %d must not have a decorator condition", i)
+		}
+		if b.condition == nil {
+			n.errorf("branch %d has no condition", i)
+		}
+		n.adopt(b.node)
+		selector.Branches = append(selector.Branches, &btpb.BehaviorTree_SelectorNode_Branch{
+			Condition: b.condition,
+			Node:      b.node.node,
+		})
+	}
+	return n
+}
+
+// Fallback creates a node that executes its children one after another until
+// one succeeds.
+func Fallback(children ...*Node) *Node {
+	fallback := &btpb.BehaviorTree_FallbackNode{}
+	for _, c := range children {
+		if c != nil {
+			fallback.Tries = append(fallback.Tries, &btpb.BehaviorTree_FallbackNode_Try{Node: c.node})
+		}
+	}
+	return newNode(&btpb.BehaviorTree_Node{NodeType: &btpb.BehaviorTree_Node_Fallback{Fallback: fallback}}, children...)
+}
+
+// If creates a node that executes then if the condition is satisfied. Use
+// [Node.Else] to add a child that is executed otherwise.
+func If(condition *btpb.BehaviorTree_Condition, then *Node) *Node {
+	branch := &btpb.BehaviorTree_BranchNode{If: condition}
+	n := newNode(&btpb.BehaviorTree_Node{NodeType: &btpb.BehaviorTree_Node_Branch{Branch: branch}})
+	if condition == nil {
+		n.errorf("condition is nil")
+	}
+	if then != nil {
+		branch.Then = then.node
+		n.adopt(then)
+	}
+	return n
+}
+
+// Else sets the child of a node created with [If] that is executed if the
+// condition is not satisfied.
+func (n *Node) Else(node *Node) *Node {
+	branch := n.node.GetBranch()
+	switch {
+	case branch == nil:
+		n.errorf("Else() can only be used on branch nodes")
+	case node == nil:
+		n.errorf("else child is nil")
+	default:
+		branch.Else = node.node
+		n.adopt(node)
+	}
+	return n
+}
+
+// While creates a node that executes do as long as the condition is satisfied.
+func While(condition *btpb.BehaviorTree_Condition, do *Node) *Node {
+	n := newNode(&btpb.BehaviorTree_Node{NodeType: &btpb.BehaviorTree_Node_Loop{
+		Loop: &btpb.BehaviorTree_LoopNode{
+			LoopType: &btpb.BehaviorTree_LoopNode_While{While: condition},
+			Do:       do.orNil(),
+		},
+	}}, do)
+	if condition == nil {
+		n.errorf("condition is nil")
+	}
+	return n
+}
+
+// Repeat creates a node that executes do the given number of times.
+func Repeat(times uint32, do *Node) *Node {
+	return newNode(&btpb.BehaviorTree_Node{NodeType: &btpb.BehaviorTree_Node_Loop{
+		Loop: &btpb.BehaviorTree_LoopNode{
+			Do:       do.orNil(),
+			MaxTimes: proto.Uint32(times),
+		},
+	}}, do)
+}
+
+// Retry creates a node that executes child up to maxTries times until it
+// succeeds. Use [Node.Recovery] to add a child that is executed between tries.
+func Retry(maxTries uint32, child *Node) *Node {
+	return newNode(&btpb.BehaviorTree_Node{NodeType: &btpb.BehaviorTree_Node_Retry{
+		Retry: &btpb.BehaviorTree_RetryNode{
+			Child:    child.orNil(),
+			MaxTries: maxTries,
+		},
+	}}, child)
+}
+
+// Recovery sets the child of a node created with [Retry] that is executed
+// after a failed try.
+func (n *Node) Recovery(node *Node) *Node {
+	retry := n.node.GetRetry()
+	switch {
+	case retry == nil:
+		n.errorf("Recovery() can only be used on retry nodes")
+	case node == nil:
+		n.errorf("recovery child is nil")
+	default:
+		retry.Recovery = node.node
+		n.adopt(node)
+	}
+	return n
+}
+
+// Task creates a node that calls the skill or parameterizable behavior tree
+// with the given id. The parameters are packed into an Any proto and may be
+// nil.
+func Task(skillID string, params proto.Message) *Node {
+	call := &bcpb.BehaviorCall{SkillId: skillID}
+	n := newNode(&btpb.BehaviorTree_Node{NodeType: &btpb.BehaviorTree_Node_Task{
+		Task: &btpb.BehaviorTree_TaskNode{TaskType: &btpb.BehaviorTree_TaskNode_CallBehavior{CallBehavior: call}},
+	}})
+	if skillID == "" {
+		n.errorf("skill id is empty")
+	}
+	if params != nil {
+		call.Parameters = n.pack(params)
+	}
+	return n
+}
+
+// Script creates a node that executes the given Python function body (see
+// intrinsic_proto.executive.PythonCode). The parameters may be nil. The file
+// descriptor set of the parameters is added to the node.
+func Script(functionBody string, params proto.Message) *Node {
+	code := &cepb.CodeExecution{
+		Code: &cepb.CodeExecution_PythonCode{PythonCode: &cepb.PythonCode{FunctionBody: functionBody}},
+	}
+	n := newNode(&btpb.BehaviorTree_Node{NodeType: &btpb.BehaviorTree_Node_Task{
+		Task: &btpb.BehaviorTree_TaskNode{TaskType: &btpb.BehaviorTree_TaskNode_ExecuteCode{ExecuteCode: code}},
+	}})
+	if params != nil {
+		code.Parameters = &awpb.AnyWithAssignments{Proto: n.pack(params)}
+		code.ParameterMessageFullName = string(params.ProtoReflect().Descriptor().FullName())
+		code.FileDescriptorSet = descriptor.FileDescriptorSetFrom(params)
+	}
+	return n
+}
+
+// Assign assigns the result of a CEL expression to a parameter of a node
+// created with [Task] before it is executed.
+func (n *Node) Assign(parameterPath, celExpression string) *Node {
+	call := n.node.GetTask().GetCallBehavior()
+	if call == nil {
+		n.errorf("Assign() can only be used on nodes created with Task()")
+		return n
+	}
+	call.Assignments = append(call.Assignments, &bcpb.BehaviorCall_ParameterAssignment{
+		Target: &bcpb.BehaviorCall_ParameterAssignment_ParameterPath{ParameterPath: parameterPath},
+		Source: &bcpb.BehaviorCall_ParameterAssignment_CelExpression{CelExpression: celExpression},
+	})
+	return n
+}
+
+// ReturnValueTo stores the return value of a node created with [Task] or
+// [Script] on the blackboard under the given key.
+func (n *Node) ReturnValueTo(key string) *Node {
+	switch task := n.node.GetTask(); {
+	case task.GetCallBehavior() != nil:
+		task.GetCallBehavior().ReturnValueName = key
+	case task.GetExecuteCode() != nil:
+		task.GetExecuteCode().ReturnValueKey = key
+	default:
+		n.errorf("ReturnValueTo() can only be used on nodes created with Task() or Script()")
+	}
+	return n
+}
+
+// SubTree creates a node that executes the given root node as a tree with the
+// given name.
+func SubTree(name string, root *Node) *Node {
+	return newNode(&btpb.BehaviorTree_Node{NodeType: &btpb.BehaviorTree_Node_SubTree{
+		SubTree: &btpb.BehaviorTree_SubtreeNode{Tree: &btpb.BehaviorTree{Name: name, Root: root.orNil()}},
+	}}, root)
+}
+
+// Fail creates a node that always fails.
+func Fail() *Node {
+	return newNode(&btpb.BehaviorTree_Node{NodeType: &btpb.BehaviorTree_Node_Fail{
+		Fail: &btpb.BehaviorTree_FailNode{},
+	}})
+}
+
+// SetBlackboard creates a node that stores the given value on the blackboard.
+func SetBlackboard(key string, value proto.Message) *Node {
+	update := &btpb.BehaviorTree_DataNode_CreateOrUpdate{BlackboardKey: key}
+	n := newNode(&btpb.BehaviorTree_Node{NodeType: &btpb.BehaviorTree_Node_Data{
+		Data: &btpb.BehaviorTree_DataNode{OperationType: &btpb.BehaviorTree_DataNode_CreateOrUpdate_{CreateOrUpdate: update}},
+	}})
+	if value == nil {
+		n.errorf("value for blackboard key %q is nil", key)
+		return n
+	}
+	update.InputType = &btpb.BehaviorTree_DataNode_CreateOrUpdate_Proto{Proto: n.pack(value)}
+	return n
+}
+
+// RemoveBlackboard creates a node that removes the given key from the
+// blackboard.
+func RemoveBlackboard(key string) *Node {
+	return newNode(&btpb.BehaviorTree_Node{NodeType: &btpb.BehaviorTree_Node_Data{
+		Data: &btpb.BehaviorTree_DataNode{OperationType: &btpb.BehaviorTree_DataNode_Remove_{
+			Remove: &btpb.BehaviorTree_DataNode_Remove{BlackboardKey: key},
+		}},
+	}})
+}
+
+func (n *Node) orNil() *btpb.BehaviorTree_Node {
+	if n == nil {
+		return nil
+	}
+	return n.node
+}
+
+func (n *Node) decorators() *btpb.BehaviorTree_Node_Decorators {
+	if n.node.Decorators == nil {
+		n.node.Decorators = &btpb.BehaviorTree_Node_Decorators{}
+	}
+	return n.node.Decorators
+}
+
+// Named sets the name of the node.
+func (n *Node) Named(name string) *Node {
+	n.node.Name = proto.String(name)
+	return n
+}
+
+// WithCondition only executes the node if the given condition is satisfied.
+// The node fails otherwise.
+func (n *Node) WithCondition(condition *btpb.BehaviorTree_Condition) *Node {
+	n.decorators().Condition = condition
+	return n
+}
+
+// Disabled disables the node. Instead of executing the node, the executive
+// puts it in the given result state.
+func (n *Node) Disabled(result btpb.BehaviorTree_Node_ExecutionSettings_DisabledResultState) *Node {
+	n.decorators().ExecutionSettings = &btpb.BehaviorTree_Node_ExecutionSettings{
+		Mode:                btpb.BehaviorTree_Node_ExecutionSettings_DISABLED,
+		DisabledResultState: result.Enum(),
+	}
+	return n
+}
+
+// EmitFailureTo stores the extended status of the node on the blackboard
+// under the given key if the node fails.
+func (n *Node) EmitFailureTo(key string) *Node {
+	n.decorators().OnFailure = &btpb.BehaviorTree_Node_Decorators_FailureSettings{
+		EmitExtendedStatus: &btpb.BehaviorTree_Node_Decorators_FailureSettings_ExtendedStatusSettings{
+			ToBlackboardKey: key,
+		},
+	}
+	return n
+}
+
+// Blackboard creates a condition that is satisfied if the given CEL expression
+// evaluates to true on the blackboard.
+func Blackboard(celExpression string) *btpb.BehaviorTree_Condition {
+	return &btpb.BehaviorTree_Condition{ConditionType: &btpb.BehaviorTree_Condition_Blackboard{
+		Blackboard: &btpb.BehaviorTree_Condition_BlackboardExpression{
+			ExpressionType: &btpb.BehaviorTree_Condition_BlackboardExpression_CelExpression{CelExpression: celExpression},
+		},
+	}}
+}
+
+// AllOf creates a condition that is satisfied if all given conditions are
+// satisfied.
+func AllOf(conditions ...*btpb.BehaviorTree_Condition) *btpb.BehaviorTree_Condition {
+	return &btpb.BehaviorTree_Condition{ConditionType: &btpb.BehaviorTree_Condition_AllOf{
+		AllOf: &btpb.BehaviorTree_Condition_LogicalCompound{Conditions: conditions},
+	}}
+}
+
+// AnyOf creates a condition that is satisfied if any of the given conditions
+// is satisfied.
+func AnyOf(conditions ...*btpb.BehaviorTree_Condition) *btpb.BehaviorTree_Condition {
+	return &btpb.BehaviorTree_Condition{ConditionType: &btpb.BehaviorTree_Condition_AnyOf{
+		AnyOf: &btpb.BehaviorTree_Condition_LogicalCompound{Conditions: conditions},
+	}}
+}
+
+// Not creates a condition that is satisfied if the given condition is not.
+func Not(condition *btpb.BehaviorTree_Condition) *btpb.BehaviorTree_Condition {
+	return &btpb.BehaviorTree_Condition{ConditionType: &btpb.BehaviorTree_Condition_Not{Not: condition}}
+}
+
+// StatusMatch creates a condition that is satisfied if an extended status is
+// stored on the blackboard under the given key (see [Node.EmitFailureTo]).
+func StatusMatch(key string) *btpb.BehaviorTree_Condition {
+	return &btpb.BehaviorTree_Condition{ConditionType: &btpb.BehaviorTree_Condition_StatusMatch{
+		StatusMatch: &btpb.BehaviorTree_Condition_ExtendedStatusMatch{BlackboardKey: key},
+	}}
+}
+
+// Tree is a Behavior Tree under construction.
+type Tree struct {
+	name string
+	root *Node
+}
+
+// NewTree creates a tree with the given name and root node.
+func NewTree(name string, root *Node) *Tree {
+	return &Tree{name: name, root: root}
+}
+
+// idAssigner assigns consecutive ids to all nodes in preorder.
+type idAssigner struct {
+	next uint32
+}
+
+func (a *idAssigner) Visit(ctx context.Context, element behaviortree.VisitElement) error {
+	if node := element.Node(); node != nil {
+		a.next++
+		node.Id = proto.Uint32(a.next)
+	}
+	return nil
+}
+
+// Build returns the Behavior Tree proto. It returns all errors from the
+// construction of the nodes. Otherwise, it assigns unique ids to all nodes and
+// validates the tree.
+func (t *Tree) Build() (*btpb.BehaviorTree, error) {
+	if t.root == nil {
+		return nil, errors.New("tree has no root node")
+	}
+	if err := errors.Join(t.root.errs...); err != nil {
+		return nil, err
+	}
+
+	// Nodes may have been used more than once, so the tree is cloned to give
+	// every occurrence its own id.
+	bt := proto.Clone(&btpb.BehaviorTree{Name: t.name, Root: t.root.node}).(*btpb.BehaviorTree)
+	ctx := context.Background()
+	if err := behaviortree.Walk(ctx, bt, &idAssigner{}); err != nil {
+		return nil, err
+	}
+
+	findings, err := behaviortreelint.New(
+		behaviortreelint.DuplicateNodeIDRule(),
+		behaviortreelint.MissingChildRule(),
+	).Lint(ctx, bt)
+	if err != nil {
+		return nil, err
+	}
+	var errs []error
+	for _, f := range findings {
+		if f.Severity == behaviortreelint.SeverityError {
+			errs = append(errs, errors.New(f.String()))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("invalid behavior tree: %w", err)
+	}
+	return bt, nil
+}
+
+// FileDescriptorSet returns a file descriptor set that describes all messages
+// packed into Any protos in the tree.
+func (t *Tree) FileDescriptorSet() (*descriptorpb.FileDescriptorSet, error) {
+	if t.root == nil {
+		return &descriptorpb.FileDescriptorSet{}, nil
+	}
+	return descriptor.MergeFileDescriptorSets(t.root.files)
+}
+
+// MarshalText builds the tree and returns it in text format with expanded Any
+// protos, as accepted by `inctl process set --process_format textproto`.
+func (t *Tree) MarshalText() ([]byte, error) {
+	bt, err := t.Build()
+	if err != nil {
+		return nil, err
+	}
+	fds, err := t.FileDescriptorSet()
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge file descriptor sets: %w", err)
+	}
+	types, err := registryutil.NewTypesFromFileDescriptorSet(fds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create types from file descriptor set: %w", err)
+	}
+	return prototext.MarshalOptions{Resolver: types, Multiline: true, Indent: "  "}.Marshal(bt)
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package behaviortreebuilder_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	bb "intrinsic/executive/go/behaviortreebuilder"
+	btpb "intrinsic/executive/proto/behavior_tree_go_proto"
+)
+
+func TestBuild(t *testing.T) {
+	grasp := bb.Task("ai.intrinsic.grasp", wrapperspb.String("bolt")).Named("grasp")
+	tree := bb.NewTree("pick", bb.Sequence(
+		bb.Task("ai.intrinsic.move", durationpb.New(0)).Assign("seconds", "timeout").ReturnValueTo("move_result"),
+		bb.Retry(3, grasp).Recovery(bb.Task("ai.intrinsic.open_gripper", nil)),
+		bb.If(bb.Not(bb.Blackboard("done")), grasp).Else(bb.Fail().EmitFailureTo("error")),
+	))
+
+	got, err := tree.Build()
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+
+	want := &btpb.BehaviorTree{}
+	if err := prototext.Unmarshal([]byte(`
+		name: "pick"
+		root {
+			id: 1
+			sequence {
+				children {
+					id: 2
+					task { call_behavior {
+						skill_id: "ai.intrinsic.move"
+						parameters { [type.googleapis.com/google.protobuf.Duration] {} }
+						assignments { parameter_path: "seconds" cel_expression: "timeout" }
+						return_value_name: "move_result"
+					} }
+				}
+				children {
+					id: 3
+					retry {
+						max_tries: 3
+						child { id: 4 name: "grasp" task { call_behavior {
+							skill_id: "ai.intrinsic.grasp"
+							parameters { [type.googleapis.com/google.protobuf.StringValue] { value: "bolt" } }
+						} } }
+						recovery { id: 5 task { call_behavior { skill_id: "ai.intrinsic.open_gripper" } } }
+					}
+				}
+				children {
+					id: 6
+					branch {
+						if { not { blackboard { cel_expression: "done" } } }
+						then { id: 7 name: "grasp" task { call_behavior {
+							skill_id: "ai.intrinsic.grasp"
+							parameters { [type.googleapis.com/google.protobuf.StringValue] { value: "bolt" } }
+						} } }
+						else { id: 8 fail {} decorators { on_failure { emit_extended_status { to_blackboard_key: "error" } } } }
+					}
+				}
+			}
+		}`), want); err != nil {
+		t.Fatalf("prototext.Unmarshal() failed: %v", err)
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Build() returned unexpected tree (-want +got):\n%s", diff)
+	}
+}
+
+func TestMarshalTextRoundTrip(t *testing.T) {
+	tree := bb.NewTree("script", bb.Sequence(
+		bb.SetBlackboard("count", wrapperspb.Int64(3)),
+		bb.Script("  return None", wrapperspb.String("x")).ReturnValueTo("result"),
+		bb.RemoveBlackboard("count"),
+	))
+	want, err := tree.Build()
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+	text, err := tree.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText() failed: %v", err)
+	}
+
+	got := &btpb.BehaviorTree{}
+	if err := prototext.Unmarshal(text, got); err != nil {
+		t.Fatalf("prototext.Unmarshal(%s) failed: %v", text, err)
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("MarshalText() did not round-trip (-want +got):\n%s", diff)
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		tree *bb.Tree
+	}{
+		{name: "no root", tree: bb.NewTree("t", nil)},
+		{name: "nil child", tree: bb.NewTree("t", bb.Sequence(bb.Fail(), nil))},
+		{name: "empty skill id", tree: bb.NewTree("t", bb.Task("", nil))},
+		{name: "else on sequence", tree: bb.NewTree("t", bb.Sequence(bb.Fail()).Else(bb.Fail()))},
+		{name: "recovery on task", tree: bb.NewTree("t", bb.Task("a", nil).Recovery(bb.Fail()))},
+		{name: "missing retry child", tree: bb.NewTree("t", bb.Sequence(bb.Retry(2, nil)))},
+		{name: "selector branch with decorator condition", tree: bb.NewTree("t", bb.Selector(
+			bb.Case(bb.Blackboard("a"), bb.Fail().WithCondition(bb.Blackboard("b"))),
+		))},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.tree.Build(); err == nil {
+				t.Errorf("Build() succeeded, want error")
+			}
+		})
+	}
+}
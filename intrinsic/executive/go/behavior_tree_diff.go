@@ -0,0 +1,870 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package behaviortreediff computes structural differences between two
+// Behavior Trees.
+//
+// Nodes of the two trees are matched by their id. Nodes without an id (or
+// whose id is only set in one of the trees) are matched by their name and
+// finally by their position in the parent. Matched nodes are compared field by
+// field, where Any protos (e.g., skill parameters) are unpacked and compared
+// message-aware using the descriptors contained in the trees.
+package behaviortreediff
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"intrinsic/executive/go/behaviortree"
+	"intrinsic/util/proto/fieldbehavior"
+	"intrinsic/util/proto/registryutil"
+
+	btpb "intrinsic/executive/proto/behavior_tree_go_proto"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ChangeType is the kind of a [NodeChange].
+type ChangeType int
+
+const (
+	// Added marks a node that only exists in the new tree.
+	Added ChangeType = iota
+	// Removed marks a node that only exists in the old tree.
+	Removed
+	// Moved marks a node that has a different parent or position in the new
+	// tree.
+	Moved
+	// Modified marks a node whose fields (other than its children) differ.
+	Modified
+)
+
+// String returns the lower-case name of the change type.
+func (c ChangeType) String() string {
+	switch c {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Moved:
+		return "moved"
+	case Modified:
+		return "modified"
+	default:
+		return fmt.Sprintf("change(%d)", int(c))
+	}
+}
+
+// MarshalJSON encodes the change type by its name.
+func (c ChangeType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// FieldChange is a single changed field of a node.
+type FieldChange struct {
+	// Path is the path of the field relative to the node, e.g.,
+	// "task.call_behavior.parameters.velocity". Fields of Any protos are
+	// addressed as if they were fields of the Any itself.
+	Path string `json:"path"`
+	// Old is the formatted old value, empty if the field was not set.
+	Old string `json:"old,omitempty"`
+	// New is the formatted new value, empty if the field is not set anymore.
+	New string `json:"new,omitempty"`
+}
+
+// String returns the field change in a single-line format.
+func (f FieldChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", f.Path, orUnset(f.Old), orUnset(f.New))
+}
+
+func orUnset(s string) string {
+	if s == "" {
+		return "<unset>"
+	}
+	return s
+}
+
+// NodeChange is a single change between two trees.
+type NodeChange struct {
+	Type ChangeType `json:"type"`
+	// OldPath is the location of the node in the old tree. Empty for added
+	// nodes.
+	OldPath string `json:"old_path,omitempty"`
+	// NewPath is the location of the node in the new tree. Empty for removed
+	// nodes.
+	NewPath string `json:"new_path,omitempty"`
+	// Fields lists the changed fields of modified nodes.
+	Fields []FieldChange `json:"fields,omitempty"`
+}
+
+// String returns the change in a human-readable format. Modified nodes are
+// followed by one indented line per changed field.
+func (c NodeChange) String() string {
+	switch c.Type {
+	case Added:
+		return "+ " + c.NewPath
+	case Removed:
+		return "- " + c.OldPath
+	case Moved:
+		return fmt.Sprintf("> %s -> %s", c.OldPath, c.NewPath)
+	}
+	lines := []string{"~ " + c.NewPath}
+	for _, f := range c.Fields {
+		lines = append(lines, "    "+f.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+type options struct {
+	resolver protoregistry.MessageTypeResolver
+}
+
+// Option enables customizing the behavior of [Diff].
+type Option func(*options)
+
+// WithResolver is an option that makes [Diff] use the given resolver to unpack
+// Any protos whose types are not described in the trees themselves (e.g., a
+// resolver backed by the proto registry of a solution).
+func WithResolver(r protoregistry.MessageTypeResolver) Option {
+	return func(o *options) {
+		o.resolver = r
+	}
+}
+
+// nodeInfo is a node of one of the compared trees together with its location.
+type nodeInfo struct {
+	node   *btpb.BehaviorTree_Node
+	parent *nodeInfo
+	// scope is the sub_tree node enclosing this node, nil for nodes of the
+	// top-level tree. Node ids are only unique within a scope.
+	scope *nodeInfo
+	// field is the name of the field of the parent containing this node, e.g.,
+	// "children" or "then".
+	field    string
+	index    int
+	path     string
+	children []*nodeInfo
+	match    *nodeInfo
+	moved    bool
+}
+
+func (n *nodeInfo) nodeType() string {
+	return behaviortree.NodeType(n.node)
+}
+
+// hasID returns whether both nodes have an id.
+func hasID(a, b *nodeInfo) bool {
+	return a.node.Id != nil && b.node.Id != nil
+}
+
+func sameScope(a, b *nodeInfo) bool {
+	if a.scope == nil || b.scope == nil {
+		return a.scope == nil && b.scope == nil
+	}
+	return a.scope.match == b.scope
+}
+
+// childSlot is a child node of a node and the field containing it.
+type childSlot struct {
+	field string
+	index int
+	node  *btpb.BehaviorTree_Node
+}
+
+func childSlots(node *btpb.BehaviorTree_Node) []childSlot {
+	var slots []childSlot
+	addAll := func(field string, nodes []*btpb.BehaviorTree_Node) {
+		for i, n := range nodes {
+			slots = append(slots, childSlot{field: field, index: i, node: n})
+		}
+	}
+	add := func(field string, n *btpb.BehaviorTree_Node) {
+		if n != nil {
+			slots = append(slots, childSlot{field: field, node: n})
+		}
+	}
+	switch n := node.GetNodeType().(type) {
+	case *btpb.BehaviorTree_Node_Sequence:
+		addAll("children", n.Sequence.GetChildren())
+	case *btpb.BehaviorTree_Node_Parallel:
+		addAll("children", n.Parallel.GetChildren())
+	case *btpb.BehaviorTree_Node_Selector:
+		addAll("children", n.Selector.GetChildren())
+		for i, b := range n.Selector.GetBranches() {
+			if b.GetNode() != nil {
+				slots = append(slots, childSlot{field: "branches", index: i, node: b.GetNode()})
+			}
+		}
+	case *btpb.BehaviorTree_Node_Fallback:
+		addAll("children", n.Fallback.GetChildren())
+		for i, t := range n.Fallback.GetTries() {
+			if t.GetNode() != nil {
+				slots = append(slots, childSlot{field: "tries", index: i, node: t.GetNode()})
+			}
+		}
+	case *btpb.BehaviorTree_Node_Branch:
+		add("then", n.Branch.GetThen())
+		add("else", n.Branch.GetElse())
+	case *btpb.BehaviorTree_Node_Loop:
+		add("do", n.Loop.GetDo())
+	case *btpb.BehaviorTree_Node_Retry:
+		add("child", n.Retry.GetChild())
+		add("recovery", n.Retry.GetRecovery())
+	case *btpb.BehaviorTree_Node_SubTree:
+		add("tree", n.SubTree.GetTree().GetRoot())
+	}
+	return slots
+}
+
+// stripChildren returns a copy of the node without its child nodes, id and
+// output-only fields, i.e., only the fields compared for modifications.
+func stripChildren(node *btpb.BehaviorTree_Node) (*btpb.BehaviorTree_Node, error) {
+	node = proto.Clone(node).(*btpb.BehaviorTree_Node)
+	node.Id = nil
+	switch n := node.GetNodeType().(type) {
+	case *btpb.BehaviorTree_Node_Sequence:
+		n.Sequence.Children = nil
+	case *btpb.BehaviorTree_Node_Parallel:
+		n.Parallel.Children = nil
+	case *btpb.BehaviorTree_Node_Selector:
+		n.Selector.Children = nil
+		for _, b := range n.Selector.GetBranches() {
+			b.Node = nil
+		}
+	case *btpb.BehaviorTree_Node_Fallback:
+		n.Fallback.Children = nil
+		for _, t := range n.Fallback.GetTries() {
+			t.Node = nil
+		}
+	case *btpb.BehaviorTree_Node_Branch:
+		n.Branch.Then = nil
+		n.Branch.Else = nil
+	case *btpb.BehaviorTree_Node_Loop:
+		n.Loop.Do = nil
+	case *btpb.BehaviorTree_Node_Retry:
+		n.Retry.Child = nil
+		n.Retry.Recovery = nil
+	case *btpb.BehaviorTree_Node_SubTree:
+		if n.SubTree.GetTree() != nil {
+			n.SubTree.GetTree().Root = nil
+			n.SubTree.GetTree().TreeId = nil
+		}
+	}
+	if err := fieldbehavior.ClearOutputOnly(node); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func describeNode(node *btpb.BehaviorTree_Node) string {
+	s := behaviortree.NodeType(node)
+	if s == "" {
+		s = "node"
+	}
+	if node.Name != nil {
+		return fmt.Sprintf("%s %q", s, node.GetName())
+	}
+	if node.Id != nil {
+		return fmt.Sprintf("%s#%d", s, node.GetId())
+	}
+	return s
+}
+
+func describeTree(tree *btpb.BehaviorTree) string {
+	if name := tree.GetName(); name != "" {
+		return fmt.Sprintf("tree %q", name)
+	}
+	return "tree"
+}
+
+// newNodeInfos returns the root of the given tree and all nodes in preorder.
+func newNodeInfos(tree *btpb.BehaviorTree) (*nodeInfo, []*nodeInfo) {
+	if tree.GetRoot() == nil {
+		return nil, nil
+	}
+	var all []*nodeInfo
+	var build func(info *nodeInfo)
+	build = func(info *nodeInfo) {
+		all = append(all, info)
+		scope := info.scope
+		if info.node.GetSubTree() != nil {
+			scope = info
+		}
+		for _, slot := range childSlots(info.node) {
+			path := info.path + " > "
+			if info.node.GetSubTree() != nil {
+				path += describeTree(info.node.GetSubTree().GetTree()) + " > "
+			}
+			child := &nodeInfo{
+				node:   slot.node,
+				parent: info,
+				scope:  scope,
+				field:  slot.field,
+				index:  slot.index,
+				path:   path + describeNode(slot.node),
+			}
+			info.children = append(info.children, child)
+			build(child)
+		}
+	}
+	root := &nodeInfo{
+		node: tree.GetRoot(),
+		path: describeTree(tree) + " > " + describeNode(tree.GetRoot()),
+	}
+	build(root)
+	return root, all
+}
+
+func setMatch(a, b *nodeInfo) {
+	a.match = b
+	b.match = a
+}
+
+// matchChildren matches the children of two matched nodes and recurses into
+// all matched children.
+func matchChildren(a, b *nodeInfo) {
+	// Match by id.
+	for _, ca := range a.children {
+		for _, cb := range b.children {
+			if ca.match == nil && cb.match == nil && hasID(ca, cb) &&
+				ca.node.GetId() == cb.node.GetId() && ca.nodeType() == cb.nodeType() {
+				setMatch(ca, cb)
+				break
+			}
+		}
+	}
+	// Match by unique name.
+	for _, ca := range a.children {
+		if ca.match != nil || ca.node.Name == nil {
+			continue
+		}
+		if cb := uniqueByName(ca, a.children, b.children); cb != nil && !hasID(ca, cb) {
+			setMatch(ca, cb)
+		}
+	}
+	// Match by position.
+	for _, ca := range a.children {
+		if ca.match != nil {
+			continue
+		}
+		for _, cb := range b.children {
+			if cb.match == nil && !hasID(ca, cb) && ca.field == cb.field &&
+				ca.index == cb.index && ca.nodeType() == cb.nodeType() {
+				setMatch(ca, cb)
+				break
+			}
+		}
+	}
+
+	markReordered(a, b)
+	for _, ca := range a.children {
+		if ca.match != nil && ca.match.parent == b {
+			matchChildren(ca, ca.match)
+		}
+	}
+}
+
+// uniqueByName returns the only unmatched node in candidates with the same name
+// and type as n, if n's name is also unique among the unmatched nodes in
+// siblings.
+func uniqueByName(n *nodeInfo, siblings, candidates []*nodeInfo) *nodeInfo {
+	count := func(nodes []*nodeInfo) (*nodeInfo, int) {
+		var found *nodeInfo
+		num := 0
+		for _, c := range nodes {
+			if c.match == nil && c.node.Name != nil && c.node.GetName() == n.node.GetName() &&
+				c.nodeType() == n.nodeType() && sameScopeAs(c, n) {
+				found = c
+				num++
+			}
+		}
+		return found, num
+	}
+	if _, num := count(siblings); num != 1 {
+		return nil
+	}
+	found, num := count(candidates)
+	if num != 1 {
+		return nil
+	}
+	return found
+}
+
+// sameScopeAs returns whether c can be matched to n by name. c is either in
+// the same tree as n or in the other tree.
+func sameScopeAs(c, n *nodeInfo) bool {
+	return c.scope == n.scope || sameScope(n, c)
+}
+
+// markReordered marks the matched children of two matched nodes as moved if
+// they are in a different field or their relative order changed.
+func markReordered(a, b *nodeInfo) {
+	var pairs []*nodeInfo
+	for _, ca := range a.children {
+		if ca.match != nil && ca.match.parent == b {
+			if ca.field != ca.match.field {
+				ca.moved = true
+				continue
+			}
+			pairs = append(pairs, ca)
+		}
+	}
+	// The children in the longest increasing subsequence of new positions stay
+	// in place, all others were moved.
+	positions := make([]int, len(pairs))
+	for i, ca := range pairs {
+		positions[i] = slices.Index(b.children, ca.match)
+	}
+	inPlace := longestIncreasingSubsequence(positions)
+	for i, ca := range pairs {
+		if !inPlace[i] {
+			ca.moved = true
+		}
+	}
+}
+
+// longestIncreasingSubsequence returns which elements of values are part of a
+// longest strictly increasing subsequence.
+func longestIncreasingSubsequence(values []int) []bool {
+	lengths := make([]int, len(values))
+	prev := make([]int, len(values))
+	best := -1
+	for i := range values {
+		lengths[i] = 1
+		prev[i] = -1
+		for j := 0; j < i; j++ {
+			if values[j] < values[i] && lengths[j]+1 > lengths[i] {
+				lengths[i] = lengths[j] + 1
+				prev[i] = j
+			}
+		}
+		if best == -1 || lengths[i] > lengths[best] {
+			best = i
+		}
+	}
+	result := make([]bool, len(values))
+	for i := best; i >= 0; i = prev[i] {
+		result[i] = true
+	}
+	return result
+}
+
+// matchMoved matches nodes that were moved to a different parent by their id or
+// unique name.
+func matchMoved(allA, allB []*nodeInfo) {
+	for _, a := range allA {
+		if a.match != nil || a.parent == nil || a.parent.match == nil {
+			// Only consider the topmost unmatched nodes, their children are matched
+			// recursively.
+			continue
+		}
+		var found *nodeInfo
+		if a.node.Id != nil {
+			for _, b := range allB {
+				if b.match == nil && b.node.Id != nil && b.node.GetId() == a.node.GetId() &&
+					b.nodeType() == a.nodeType() && sameScope(a, b) {
+					found = b
+					break
+				}
+			}
+		}
+		if found == nil && a.node.Name != nil {
+			if b := uniqueByName(a, allA, allB); b != nil && !hasID(a, b) {
+				found = b
+			}
+		}
+		if found != nil {
+			setMatch(a, found)
+			a.moved = true
+			matchChildren(a, found)
+		}
+	}
+}
+
+// differ compares the fields of nodes.
+type differ struct {
+	resolvers []protoregistry.MessageTypeResolver
+}
+
+func (d *differ) FindMessageByName(name protoreflect.FullName) (protoreflect.MessageType, error) {
+	for _, r := range d.resolvers {
+		if mt, err := r.FindMessageByName(name); err == nil {
+			return mt, nil
+		}
+	}
+	return nil, protoregistry.NotFound
+}
+
+func (d *differ) FindMessageByURL(url string) (protoreflect.MessageType, error) {
+	for _, r := range d.resolvers {
+		if mt, err := r.FindMessageByURL(url); err == nil {
+			return mt, nil
+		}
+	}
+	return nil, protoregistry.NotFound
+}
+
+func (d *differ) FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	return protoregistry.GlobalTypes.FindExtensionByName(field)
+}
+
+func (d *differ) FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error) {
+	return protoregistry.GlobalTypes.FindExtensionByNumber(message, field)
+}
+
+// fileDescriptorSetCollector collects the file descriptor sets of script nodes
+// and of the parameters of (sub-)trees.
+type fileDescriptorSetCollector struct {
+	sets []*descriptorpb.FileDescriptorSet
+}
+
+func (c *fileDescriptorSetCollector) Visit(ctx context.Context, element behaviortree.VisitElement) error {
+	if tree := element.Tree(); tree != nil {
+		if set := tree.GetDescription().GetParameterDescription().GetParameterDescriptorFileset(); set != nil {
+			c.sets = append(c.sets, set)
+		}
+	}
+	if node := element.Node(); node != nil {
+		if set := node.GetTask().GetExecuteCode().GetFileDescriptorSet(); set != nil {
+			c.sets = append(c.sets, set)
+		}
+	}
+	return nil
+}
+
+// typesFromTrees returns all types described by file descriptor sets in the
+// given trees. Files contained in several sets are only added once.
+func typesFromTrees(ctx context.Context, trees ...*btpb.BehaviorTree) (*protoregistry.Types, error) {
+	collector := &fileDescriptorSetCollector{}
+	for _, tree := range trees {
+		if err := behaviortree.Walk(ctx, tree, collector); err != nil {
+			return nil, fmt.Errorf("failed to walk behavior tree: %w", err)
+		}
+	}
+	files := &protoregistry.Files{}
+	for _, set := range collector.sets {
+		setFiles, err := protodesc.NewFiles(set)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create files from file descriptor set: %w", err)
+		}
+		setFiles.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+			if _, findErr := files.FindFileByPath(file.Path()); findErr == nil {
+				return true
+			}
+			err = files.RegisterFile(file)
+			return err == nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to register file: %w", err)
+		}
+	}
+	types := &protoregistry.Types{}
+	if err := registryutil.PopulateTypesFromFiles(types, files); err != nil {
+		return nil, fmt.Errorf("failed to populate types from files: %w", err)
+	}
+	return types, nil
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+var fileDescriptorSetName = (&descriptorpb.FileDescriptorSet{}).ProtoReflect().Descriptor().FullName()
+
+// diffMessages appends the changes between two messages of the same type.
+func (d *differ) diffMessages(path string, a, b protoreflect.Message, changes []FieldChange) []FieldChange {
+	desc := a.Descriptor()
+	switch desc.FullName() {
+	case fileDescriptorSetName:
+		// File descriptor sets are only compared as a whole since their contents
+		// are not meaningful to a reviewer.
+		if !proto.Equal(a.Interface(), b.Interface()) {
+			changes = append(changes, FieldChange{Path: path, Old: "<file descriptor set>", New: "<changed file descriptor set>"})
+		}
+		return changes
+	case (&anypb.Any{}).ProtoReflect().Descriptor().FullName():
+		return d.diffAnys(path, a, b, changes)
+	}
+
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		fieldPath := joinPath(path, string(fd.Name()))
+		switch {
+		case fd.IsList():
+			changes = d.diffLists(fieldPath, fd, a.Get(fd).List(), b.Get(fd).List(), changes)
+		case fd.IsMap():
+			changes = d.diffMaps(fieldPath, fd, a.Get(fd).Map(), b.Get(fd).Map(), changes)
+		case fd.Message() != nil:
+			hasA, hasB := a.Has(fd), b.Has(fd)
+			switch {
+			case hasA && hasB:
+				changes = d.diffMessages(fieldPath, a.Get(fd).Message(), b.Get(fd).Message(), changes)
+			case hasA || hasB:
+				changes = append(changes, d.fieldChange(fieldPath, fd, a, b))
+			}
+		default:
+			if a.Has(fd) != b.Has(fd) || !a.Get(fd).Equal(b.Get(fd)) {
+				changes = append(changes, d.fieldChange(fieldPath, fd, a, b))
+			}
+		}
+	}
+	return changes
+}
+
+func (d *differ) fieldChange(path string, fd protoreflect.FieldDescriptor, a, b protoreflect.Message) FieldChange {
+	change := FieldChange{Path: path}
+	if a.Has(fd) {
+		change.Old = d.formatValue(fd, a.Get(fd))
+	}
+	if b.Has(fd) {
+		change.New = d.formatValue(fd, b.Get(fd))
+	}
+	return change
+}
+
+func (d *differ) diffLists(path string, fd protoreflect.FieldDescriptor, a, b protoreflect.List, changes []FieldChange) []FieldChange {
+	for i := 0; i < max(a.Len(), b.Len()); i++ {
+		elemPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= a.Len():
+			changes = append(changes, FieldChange{Path: elemPath, New: d.formatValue(fd, b.Get(i))})
+		case i >= b.Len():
+			changes = append(changes, FieldChange{Path: elemPath, Old: d.formatValue(fd, a.Get(i))})
+		case fd.Message() != nil:
+			changes = d.diffMessages(elemPath, a.Get(i).Message(), b.Get(i).Message(), changes)
+		case !a.Get(i).Equal(b.Get(i)):
+			changes = append(changes, FieldChange{Path: elemPath, Old: d.formatValue(fd, a.Get(i)), New: d.formatValue(fd, b.Get(i))})
+		}
+	}
+	return changes
+}
+
+func (d *differ) diffMaps(path string, fd protoreflect.FieldDescriptor, a, b protoreflect.Map, changes []FieldChange) []FieldChange {
+	var keys []protoreflect.MapKey
+	seen := map[string]bool{}
+	collect := func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+		if s := fmt.Sprint(k.Interface()); !seen[s] {
+			seen[s] = true
+			keys = append(keys, k)
+		}
+		return true
+	}
+	a.Range(collect)
+	b.Range(collect)
+	slices.SortFunc(keys, func(x, y protoreflect.MapKey) int {
+		return strings.Compare(fmt.Sprint(x.Interface()), fmt.Sprint(y.Interface()))
+	})
+
+	valueField := fd.MapValue()
+	for _, k := range keys {
+		entryPath := fmt.Sprintf("%s[%v]", path, k.Interface())
+		hasA, hasB := a.Has(k), b.Has(k)
+		switch {
+		case !hasA:
+			changes = append(changes, FieldChange{Path: entryPath, New: d.formatValue(valueField, b.Get(k))})
+		case !hasB:
+			changes = append(changes, FieldChange{Path: entryPath, Old: d.formatValue(valueField, a.Get(k))})
+		case valueField.Message() != nil:
+			changes = d.diffMessages(entryPath, a.Get(k).Message(), b.Get(k).Message(), changes)
+		case !a.Get(k).Equal(b.Get(k)):
+			changes = append(changes, FieldChange{Path: entryPath, Old: d.formatValue(valueField, a.Get(k)), New: d.formatValue(valueField, b.Get(k))})
+		}
+	}
+	return changes
+}
+
+// diffAnys compares two Any protos. If both have the same type and the type
+// can be resolved, their unpacked messages are compared field by field.
+// Otherwise the Any protos are compared as a whole.
+func (d *differ) diffAnys(path string, a, b protoreflect.Message, changes []FieldChange) []FieldChange {
+	anyA := a.Interface().(*anypb.Any)
+	anyB := b.Interface().(*anypb.Any)
+	if anyA.GetTypeUrl() == anyB.GetTypeUrl() {
+		if msgs, err := d.unpack(anyA, anyB); err == nil {
+			return d.diffMessages(path, msgs[0].ProtoReflect(), msgs[1].ProtoReflect(), changes)
+		}
+	}
+	if proto.Equal(anyA, anyB) {
+		return changes
+	}
+	return append(changes, FieldChange{Path: path, Old: d.formatAny(anyA), New: d.formatAny(anyB)})
+}
+
+func (d *differ) unpack(anys ...*anypb.Any) ([]proto.Message, error) {
+	var msgs []proto.Message
+	for _, a := range anys {
+		msg, err := a.UnmarshalNew(proto.UnmarshalOptions{Resolver: d})
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+func (d *differ) formatAny(a *anypb.Any) string {
+	msgs, err := d.unpack(a)
+	if err != nil {
+		return fmt.Sprintf("[%s] <%d bytes>", a.GetTypeUrl(), len(a.GetValue()))
+	}
+	return fmt.Sprintf("[%s] {%s}", a.GetTypeUrl(), d.formatMessage(msgs[0]))
+}
+
+func (d *differ) formatMessage(m proto.Message) string {
+	return prototext.MarshalOptions{Resolver: d}.Format(m)
+}
+
+func (d *differ) formatValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch {
+	case fd.Message() != nil:
+		if a, ok := v.Message().Interface().(*anypb.Any); ok {
+			return d.formatAny(a)
+		}
+		if fd.Message().FullName() == fileDescriptorSetName {
+			return "<file descriptor set>"
+		}
+		return "{" + d.formatMessage(v.Message().Interface()) + "}"
+	case fd.Enum() != nil:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return fmt.Sprint(v.Enum())
+	case fd.Kind() == protoreflect.StringKind:
+		return fmt.Sprintf("%q", v.String())
+	case fd.Kind() == protoreflect.BytesKind:
+		return fmt.Sprintf("<%d bytes>", len(v.Bytes()))
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+// Diff returns the changes from tree a to tree b.
+//
+// Changes of nodes of a are returned first in preorder (removed, moved and
+// modified nodes), followed by the nodes added in b in preorder. Changes of the
+// tree itself (e.g., its name or description) are reported as a modification
+// of the root node. Node ids, the tree id and output-only fields (e.g., node
+// states) are ignored.
+func Diff(ctx context.Context, a, b *btpb.BehaviorTree, opts ...Option) ([]NodeChange, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	types, err := typesFromTrees(ctx, a, b)
+	if err != nil {
+		return nil, err
+	}
+	d := &differ{resolvers: []protoregistry.MessageTypeResolver{types}}
+	if o.resolver != nil {
+		d.resolvers = append(d.resolvers, o.resolver)
+	}
+	d.resolvers = append(d.resolvers, protoregistry.GlobalTypes)
+
+	rootA, allA := newNodeInfos(a)
+	rootB, allB := newNodeInfos(b)
+	if rootA != nil && rootB != nil && rootA.nodeType() == rootB.nodeType() {
+		setMatch(rootA, rootB)
+		matchChildren(rootA, rootB)
+	}
+	matchMoved(allA, allB)
+
+	var changes []NodeChange
+	treeFields, err := d.diffTrees(a, b)
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range allA {
+		switch {
+		case info.match == nil:
+			if info.parent == nil || info.parent.match != nil {
+				changes = append(changes, NodeChange{Type: Removed, OldPath: info.path})
+			}
+			continue
+		case info.moved:
+			changes = append(changes, NodeChange{Type: Moved, OldPath: info.path, NewPath: info.match.path})
+		}
+
+		fields, err := d.diffNodes(info.node, info.match.node)
+		if err != nil {
+			return nil, err
+		}
+		if info.parent == nil {
+			fields = append(treeFields, fields...)
+			treeFields = nil
+		}
+		if len(fields) > 0 {
+			changes = append(changes, NodeChange{Type: Modified, OldPath: info.path, NewPath: info.match.path, Fields: fields})
+		}
+	}
+	if len(treeFields) > 0 {
+		changes = append(changes, NodeChange{Type: Modified, OldPath: describeTree(a), NewPath: describeTree(b), Fields: treeFields})
+	}
+	for _, info := range allB {
+		if info.match == nil && (info.parent == nil || info.parent.match != nil) {
+			changes = append(changes, NodeChange{Type: Added, NewPath: info.path})
+		}
+	}
+	return changes, nil
+}
+
+func (d *differ) diffNodes(a, b *btpb.BehaviorTree_Node) ([]FieldChange, error) {
+	strippedA, err := stripChildren(a)
+	if err != nil {
+		return nil, err
+	}
+	strippedB, err := stripChildren(b)
+	if err != nil {
+		return nil, err
+	}
+	return d.diffMessages("", strippedA.ProtoReflect(), strippedB.ProtoReflect(), nil), nil
+}
+
+// diffTrees compares the fields of the trees themselves (without their nodes).
+func (d *differ) diffTrees(a, b *btpb.BehaviorTree) ([]FieldChange, error) {
+	strip := func(tree *btpb.BehaviorTree) (*btpb.BehaviorTree, error) {
+		tree = proto.Clone(tree).(*btpb.BehaviorTree)
+		tree.Root = nil
+		tree.TreeId = nil
+		if err := fieldbehavior.ClearOutputOnly(tree); err != nil {
+			return nil, err
+		}
+		return tree, nil
+	}
+	strippedA, err := strip(a)
+	if err != nil {
+		return nil, err
+	}
+	strippedB, err := strip(b)
+	if err != nil {
+		return nil, err
+	}
+	changes := d.diffMessages("", strippedA.ProtoReflect(), strippedB.ProtoReflect(), nil)
+	for i := range changes {
+		changes[i].Path = "tree." + changes[i].Path
+	}
+	return changes, nil
+}
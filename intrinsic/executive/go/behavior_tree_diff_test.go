@@ -0,0 +1,138 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package behaviortreediff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/prototext"
+
+	btpb "intrinsic/executive/proto/behavior_tree_go_proto"
+)
+
+func mustParseTree(t *testing.T, text string) *btpb.BehaviorTree {
+	t.Helper()
+	tree := &btpb.BehaviorTree{}
+	if err := prototext.Unmarshal([]byte(text), tree); err != nil {
+		t.Fatalf("prototext.Unmarshal(%q) failed: %v", text, err)
+	}
+	return tree
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want []NodeChange
+	}{
+		{
+			name: "identical trees",
+			a:    `name: "main" root { id: 1 sequence { children { id: 2 fail {} } } }`,
+			b:    `name: "main" root { id: 1 sequence { children { id: 2 fail {} } } state: SUCCEEDED } tree_id: "abc"`,
+		},
+		{
+			name: "match by id",
+			a: `root { id: 1 sequence {
+				children { id: 2 name: "a" fail {} }
+				children { id: 3 name: "b" fail {} }
+			} }`,
+			b: `root { id: 1 sequence {
+				children { id: 3 name: "b" fail {} }
+				children { id: 2 name: "c" fail {} }
+			} }`,
+			want: []NodeChange{
+				{
+					Type:    Modified,
+					OldPath: `tree > sequence#1 > fail "a"`,
+					NewPath: `tree > sequence#1 > fail "c"`,
+					Fields:  []FieldChange{{Path: "name", Old: `"a"`, New: `"c"`}},
+				},
+				{
+					Type:    Moved,
+					OldPath: `tree > sequence#1 > fail "b"`,
+					NewPath: `tree > sequence#1 > fail "b"`,
+				},
+			},
+		},
+		{
+			name: "match by name and position",
+			a: `root { sequence {
+				children { name: "a" fail {} }
+				children { name: "b" fail {} }
+				children { fail {} }
+			} }`,
+			b: `root { sequence {
+				children { name: "a" fail {} }
+				children { name: "new" task { call_behavior { skill_id: "ai.intrinsic.move" } } }
+				children { name: "b" fail {} }
+			} }`,
+			want: []NodeChange{
+				{Type: Removed, OldPath: `tree > sequence > fail`},
+				{Type: Added, NewPath: `tree > sequence > task "new"`},
+			},
+		},
+		{
+			name: "moved to other parent",
+			a: `root { id: 1 sequence {
+				children { id: 2 name: "left" sequence { children { id: 4 name: "x" fail {} } } }
+				children { id: 3 name: "right" sequence {} }
+			} }`,
+			b: `root { id: 1 sequence {
+				children { id: 2 name: "left" sequence {} }
+				children { id: 3 name: "right" sequence { children { id: 4 name: "x" fail {} } } }
+			} }`,
+			want: []NodeChange{{
+				Type:    Moved,
+				OldPath: `tree > sequence#1 > sequence "left" > fail "x"`,
+				NewPath: `tree > sequence#1 > sequence "right" > fail "x"`,
+			}},
+		},
+		{
+			name: "any parameters",
+			a: `name: "main" root { name: "move" task { call_behavior {
+				skill_id: "ai.intrinsic.move"
+				parameters { [type.googleapis.com/intrinsic_proto.executive.BehaviorTree] { name: "x" } }
+			} } }`,
+			b: `name: "other" root { name: "move" task { call_behavior {
+				skill_id: "ai.intrinsic.move"
+				parameters { [type.googleapis.com/intrinsic_proto.executive.BehaviorTree] { name: "y" } }
+			} } }`,
+			want: []NodeChange{{
+				Type:    Modified,
+				OldPath: `tree "main" > task "move"`,
+				NewPath: `tree "other" > task "move"`,
+				Fields: []FieldChange{
+					{Path: "tree.name", Old: `"main"`, New: `"other"`},
+					{Path: "task.call_behavior.parameters.name", Old: `"x"`, New: `"y"`},
+				},
+			}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Diff(context.Background(), mustParseTree(t, tc.a), mustParseTree(t, tc.b))
+			if err != nil {
+				t.Fatalf("Diff() failed: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Diff() returned unexpected changes (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
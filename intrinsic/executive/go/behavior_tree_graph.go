@@ -0,0 +1,396 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package behaviortreegraph renders Behavior Trees as graphs in the Graphviz
+// DOT and Mermaid formats.
+//
+// Every tree (including sub-trees and trees of called processes), node and
+// condition becomes a vertex of the graph. Edges are labeled with the role of
+// the child (e.g., "then" or "recovery"). Edges that are only followed after a
+// failure (fallback tries and retry recovery) are styled distinctly.
+package behaviortreegraph
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"intrinsic/executive/go/behaviortree"
+
+	btpb "intrinsic/executive/proto/behavior_tree_go_proto"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// vertexKind determines how a vertex is drawn.
+type vertexKind int
+
+const (
+	kindNode vertexKind = iota
+	kindTree
+	kindCondition
+	kindFail
+	// kindDisabled is a node that is disabled by its execution settings.
+	kindDisabled
+)
+
+type vertex struct {
+	id    string
+	lines []string
+	kind  vertexKind
+}
+
+type edge struct {
+	from, to string
+	label    string
+	// onFailure marks edges that are only followed if a previous child failed.
+	onFailure bool
+	// decorator marks edges to decorator conditions.
+	decorator bool
+}
+
+// Graph is a Behavior Tree represented as vertices and edges.
+type Graph struct {
+	name     string
+	vertices []vertex
+	edges    []edge
+}
+
+type graphBuilder struct {
+	g   *Graph
+	ids map[proto.Message]string
+}
+
+func (b *graphBuilder) Visit(ctx context.Context, element behaviortree.VisitElement) error {
+	msg := elementMessage(element)
+	id := fmt.Sprintf("n%d", len(b.g.vertices))
+	b.ids[msg] = id
+	b.g.vertices = append(b.g.vertices, newVertex(id, element))
+
+	parent := element.Ancestor()
+	if parent == nil || !parent.IsValid() {
+		return nil
+	}
+	e := edge{from: b.ids[elementMessage(*parent)], to: id}
+	describeEdge(&e, *parent, element)
+	b.g.edges = append(b.g.edges, e)
+	return nil
+}
+
+func elementMessage(element behaviortree.VisitElement) proto.Message {
+	switch {
+	case element.Tree() != nil:
+		return element.Tree()
+	case element.Node() != nil:
+		return element.Node()
+	default:
+		return element.Condition()
+	}
+}
+
+func newVertex(id string, element behaviortree.VisitElement) vertex {
+	switch {
+	case element.Tree() != nil:
+		tree := element.Tree()
+		lines := []string{"tree"}
+		if tree.GetName() != "" {
+			lines = []string{tree.GetName(), "tree"}
+		}
+		return vertex{id: id, lines: lines, kind: kindTree}
+	case element.Node() != nil:
+		return nodeVertex(id, element.Node())
+	default:
+		return vertex{id: id, lines: conditionLines(element.Condition()), kind: kindCondition}
+	}
+}
+
+func nodeVertex(id string, node *btpb.BehaviorTree_Node) vertex {
+	v := vertex{id: id, kind: kindNode}
+	typeLine := behaviortree.NodeType(node)
+	if typeLine == "" {
+		typeLine = "node"
+	}
+	if node.Id != nil {
+		typeLine += fmt.Sprintf(" #%d", node.GetId())
+	}
+	if node.Name != nil {
+		v.lines = append(v.lines, node.GetName())
+	}
+	v.lines = append(v.lines, typeLine)
+
+	switch n := node.GetNodeType().(type) {
+	case *btpb.BehaviorTree_Node_Task:
+		if skillID := n.Task.GetCallBehavior().GetSkillId(); skillID != "" {
+			v.lines = append(v.lines, skillID)
+		} else if n.Task.GetExecuteCode() != nil {
+			v.lines = append(v.lines, "script")
+		}
+	case *btpb.BehaviorTree_Node_Retry:
+		if n.Retry.GetMaxTries() > 0 {
+			v.lines = append(v.lines, fmt.Sprintf("max tries: %d", n.Retry.GetMaxTries()))
+		}
+	case *btpb.BehaviorTree_Node_Loop:
+		if n.Loop.MaxTimes != nil {
+			v.lines = append(v.lines, fmt.Sprintf("max times: %d", n.Loop.GetMaxTimes()))
+		}
+	case *btpb.BehaviorTree_Node_Data:
+		if key := n.Data.GetCreateOrUpdate().GetBlackboardKey(); key != "" {
+			v.lines = append(v.lines, "set "+key)
+		} else if key := n.Data.GetRemove().GetBlackboardKey(); key != "" {
+			v.lines = append(v.lines, "remove "+key)
+		}
+	case *btpb.BehaviorTree_Node_Fail:
+		v.kind = kindFail
+	}
+
+	decorators := node.GetDecorators()
+	if key := decorators.GetOnFailure().GetEmitExtendedStatus().GetToBlackboardKey(); key != "" {
+		v.lines = append(v.lines, "on failure: emit to "+key)
+	}
+	if decorators.GetExecutionSettings().GetMode() == btpb.BehaviorTree_Node_ExecutionSettings_DISABLED {
+		line := "disabled"
+		if state := decorators.GetExecutionSettings().GetDisabledResultState(); state != btpb.BehaviorTree_Node_ExecutionSettings_DISABLED_RESULT_STATE_UNSPECIFIED {
+			line += ": " + strings.ToLower(state.String())
+		}
+		v.lines = append(v.lines, line)
+		v.kind = kindDisabled
+	}
+	if decorators.Breakpoint != nil {
+		v.lines = append(v.lines, "breakpoint: "+strings.ToLower(decorators.GetBreakpoint().String()))
+	}
+	return v
+}
+
+func conditionLines(cond *btpb.BehaviorTree_Condition) []string {
+	switch c := cond.GetConditionType().(type) {
+	case *btpb.BehaviorTree_Condition_BehaviorTree:
+		return []string{"tree condition"}
+	case *btpb.BehaviorTree_Condition_Blackboard:
+		if expr := c.Blackboard.GetCelExpression(); expr != "" {
+			return []string{expr}
+		}
+		return []string{"blackboard expression"}
+	case *btpb.BehaviorTree_Condition_AllOf:
+		return []string{"all of"}
+	case *btpb.BehaviorTree_Condition_AnyOf:
+		return []string{"any of"}
+	case *btpb.BehaviorTree_Condition_Not:
+		return []string{"not"}
+	case *btpb.BehaviorTree_Condition_StatusMatch:
+		return []string{"status match", c.StatusMatch.GetBlackboardKey()}
+	default:
+		return []string{"condition"}
+	}
+}
+
+// describeEdge sets the label and style of the edge from parent to child.
+func describeEdge(e *edge, parent, child behaviortree.VisitElement) {
+	node := parent.Node()
+	if node == nil {
+		return
+	}
+	if cond := child.Condition(); cond != nil && cond == node.GetDecorators().GetCondition() {
+		e.label = "condition"
+		e.decorator = true
+		return
+	}
+	childMsg := elementMessage(child)
+	switch n := node.GetNodeType().(type) {
+	case *btpb.BehaviorTree_Node_Selector:
+		for i, b := range n.Selector.GetBranches() {
+			switch childMsg {
+			case proto.Message(b.GetCondition()):
+				e.label = fmt.Sprintf("branch %d if", i+1)
+			case proto.Message(b.GetNode()):
+				e.label = fmt.Sprintf("branch %d", i+1)
+			}
+		}
+	case *btpb.BehaviorTree_Node_Fallback:
+		if i := slices.IndexFunc(n.Fallback.GetChildren(), func(c *btpb.BehaviorTree_Node) bool { return proto.Message(c) == childMsg }); i >= 0 {
+			e.label = fmt.Sprintf("try %d", i+1)
+			e.onFailure = i > 0
+		}
+		for i, t := range n.Fallback.GetTries() {
+			switch childMsg {
+			case proto.Message(t.GetCondition()):
+				e.label = fmt.Sprintf("try %d if", i+1)
+				e.onFailure = i > 0
+			case proto.Message(t.GetNode()):
+				e.label = fmt.Sprintf("try %d", i+1)
+				e.onFailure = i > 0
+			}
+		}
+	case *btpb.BehaviorTree_Node_Branch:
+		switch childMsg {
+		case proto.Message(n.Branch.GetIf()):
+			e.label = "if"
+		case proto.Message(n.Branch.GetThen()):
+			e.label = "then"
+		case proto.Message(n.Branch.GetElse()):
+			e.label = "else"
+		}
+	case *btpb.BehaviorTree_Node_Loop:
+		switch childMsg {
+		case proto.Message(n.Loop.GetWhile()):
+			e.label = "while"
+		case proto.Message(n.Loop.GetDo()):
+			e.label = "do"
+		}
+	case *btpb.BehaviorTree_Node_Retry:
+		switch childMsg {
+		case proto.Message(n.Retry.GetChild()):
+			e.label = "child"
+		case proto.Message(n.Retry.GetRecovery()):
+			e.label = "recovery"
+			e.onFailure = true
+		}
+	case *btpb.BehaviorTree_Node_Task:
+		e.label = "called"
+	}
+}
+
+// New creates the graph of the given tree. Trees of called processes (in the
+// called_tree_state of task nodes) are included.
+func New(ctx context.Context, tree *btpb.BehaviorTree) (*Graph, error) {
+	b := &graphBuilder{
+		g:   &Graph{name: tree.GetName()},
+		ids: map[proto.Message]string{},
+	}
+	if err := behaviortree.Walk(ctx, tree, b, behaviortree.VisitCalledTreeState()); err != nil {
+		return nil, err
+	}
+	return b.g, nil
+}
+
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+func dotQuote(s string) string {
+	return `"` + dotEscape(s) + `"`
+}
+
+// DOT returns the graph in the Graphviz DOT language.
+func (g *Graph) DOT() string {
+	var sb strings.Builder
+	name := g.name
+	if name == "" {
+		name = "tree"
+	}
+	fmt.Fprintf(&sb, "digraph %s {\n", dotQuote(name))
+	sb.WriteString("  node [shape=box, style=\"rounded,filled\", fillcolor=white, fontname=\"Helvetica\"];\n")
+	sb.WriteString("  edge [fontname=\"Helvetica\", fontsize=10];\n")
+	for _, v := range g.vertices {
+		lines := make([]string, len(v.lines))
+		for i, l := range v.lines {
+			lines[i] = dotEscape(l)
+		}
+		attrs := []string{`label="` + strings.Join(lines, `\n`) + `"`}
+		switch v.kind {
+		case kindTree:
+			attrs = append(attrs, "shape=folder", "fillcolor=lightgrey")
+		case kindCondition:
+			attrs = append(attrs, "shape=diamond", "style=filled", "fillcolor=lightyellow")
+		case kindFail:
+			attrs = append(attrs, "color=red", "fillcolor=mistyrose")
+		case kindDisabled:
+			attrs = append(attrs, `style="rounded,dashed"`, "fontcolor=grey")
+		}
+		fmt.Fprintf(&sb, "  %s [%s];\n", v.id, strings.Join(attrs, ", "))
+	}
+	for _, e := range g.edges {
+		var attrs []string
+		if e.label != "" {
+			attrs = append(attrs, "label="+dotQuote(e.label))
+		}
+		switch {
+		case e.onFailure:
+			attrs = append(attrs, "style=dashed", "color=red", "fontcolor=red")
+		case e.decorator:
+			attrs = append(attrs, "style=dotted", "arrowhead=odiamond")
+		}
+		if len(attrs) > 0 {
+			fmt.Fprintf(&sb, "  %s -> %s [%s];\n", e.from, e.to, strings.Join(attrs, ", "))
+		} else {
+			fmt.Fprintf(&sb, "  %s -> %s;\n", e.from, e.to)
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// mermaidEscape escapes characters that are not allowed in quoted Mermaid
+// labels.
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace(s)
+}
+
+// Mermaid returns the graph as a Mermaid flowchart.
+func (g *Graph) Mermaid() string {
+	var sb strings.Builder
+	sb.WriteString("flowchart TD\n")
+	classes := map[vertexKind][]string{}
+	for _, v := range g.vertices {
+		lines := make([]string, len(v.lines))
+		for i, l := range v.lines {
+			lines[i] = mermaidEscape(l)
+		}
+		label := `"` + strings.Join(lines, "<br/>") + `"`
+		switch v.kind {
+		case kindTree:
+			fmt.Fprintf(&sb, "  %s[[%s]]\n", v.id, label)
+		case kindCondition:
+			fmt.Fprintf(&sb, "  %s{%s}\n", v.id, label)
+		default:
+			fmt.Fprintf(&sb, "  %s(%s)\n", v.id, label)
+		}
+		classes[v.kind] = append(classes[v.kind], v.id)
+	}
+	for _, e := range g.edges {
+		arrow := "-->"
+		switch {
+		case e.onFailure:
+			arrow = "-.->"
+		case e.decorator:
+			arrow = "-.-"
+		}
+		if e.label != "" {
+			fmt.Fprintf(&sb, "  %s %s|%s| %s\n", e.from, arrow, `"`+mermaidEscape(e.label)+`"`, e.to)
+		} else {
+			fmt.Fprintf(&sb, "  %s %s %s\n", e.from, arrow, e.to)
+		}
+	}
+	for _, c := range []struct {
+		kind  vertexKind
+		name  string
+		style string
+	}{
+		{kindTree, "tree", "fill:#eee,stroke:#666"},
+		{kindCondition, "condition", "fill:#ffffe0,stroke:#aa0"},
+		{kindFail, "fail", "fill:#ffe4e1,stroke:#c00"},
+		{kindDisabled, "disabled", "fill:#fff,stroke:#999,stroke-dasharray:4 4,color:#999"},
+	} {
+		if ids := classes[c.kind]; len(ids) > 0 {
+			fmt.Fprintf(&sb, "  classDef %s %s\n", c.name, c.style)
+			fmt.Fprintf(&sb, "  class %s %s\n", strings.Join(ids, ","), c.name)
+		}
+	}
+	for i, e := range g.edges {
+		if e.onFailure {
+			fmt.Fprintf(&sb, "  linkStyle %d stroke:#c00,color:#c00\n", i)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package behaviortreegraph
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/prototext"
+
+	btpb "intrinsic/executive/proto/behavior_tree_go_proto"
+)
+
+const testTree = `
+	name: "main"
+	root {
+		name: "pick"
+		fallback {
+			tries { node { name: "a" fail {} } }
+			tries {
+				node {
+					retry {
+						max_tries: 3
+						child { name: "move" task { call_behavior { skill_id: "ai.intrinsic.move" } } }
+						recovery { name: "recover" fail {} }
+					}
+				}
+			}
+		}
+	}`
+
+func mustNewGraph(t *testing.T) *Graph {
+	t.Helper()
+	tree := &btpb.BehaviorTree{}
+	if err := prototext.Unmarshal([]byte(testTree), tree); err != nil {
+		t.Fatalf("prototext.Unmarshal(%q) failed: %v", testTree, err)
+	}
+	g, err := New(context.Background(), tree)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	return g
+}
+
+func TestMermaid(t *testing.T) {
+	want := `flowchart TD
+  n0[["main<br/>tree"]]
+  n1("pick<br/>fallback")
+  n2("a<br/>fail")
+  n3("retry<br/>max tries: 3")
+  n4("move<br/>task<br/>ai.intrinsic.move")
+  n5("recover<br/>fail")
+  n0 --> n1
+  n1 -->|"try 1"| n2
+  n1 -.->|"try 2"| n3
+  n3 -->|"child"| n4
+  n3 -.->|"recovery"| n5
+  classDef tree fill:#eee,stroke:#666
+  class n0 tree
+  classDef fail fill:#ffe4e1,stroke:#c00
+  class n2,n5 fail
+  linkStyle 2 stroke:#c00,color:#c00
+  linkStyle 4 stroke:#c00,color:#c00
+`
+	if diff := cmp.Diff(want, mustNewGraph(t).Mermaid()); diff != "" {
+		t.Errorf("Mermaid() returned unexpected output (-want +got):\n%s", diff)
+	}
+}
+
+func TestDOT(t *testing.T) {
+	got := mustNewGraph(t).DOT()
+	for _, want := range []string{
+		`digraph "main" {`,
+		`n4 [label="move\ntask\nai.intrinsic.move"];`,
+		`n5 [label="recover\nfail", color=red, fillcolor=mistyrose];`,
+		`n1 -> n2 [label="try 1"];`,
+		`n3 -> n5 [label="recovery", style=dashed, color=red, fontcolor=red];`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("DOT() = %s, want it to contain %q", got, want)
+		}
+	}
+}
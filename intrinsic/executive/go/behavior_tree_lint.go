@@ -0,0 +1,230 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package behaviortreelint statically checks Behavior Trees for common
+// mistakes before they are loaded into the executive.
+//
+// A [Linter] runs a set of [Rule]s on a tree and collects their [Finding]s.
+// Rules that only need to look at one element at a time can be created with
+// [ElementRule]. Rules that depend on the solution (e.g., installed skills)
+// are created with their own constructors and are not part of
+// [DefaultRules].
+package behaviortreelint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"intrinsic/executive/go/behaviortree"
+
+	btpb "intrinsic/executive/proto/behavior_tree_go_proto"
+)
+
+// Severity is the severity of a [Finding].
+type Severity int
+
+const (
+	// SeverityWarning marks findings that are likely mistakes but do not
+	// prevent the tree from being executed.
+	SeverityWarning Severity = iota
+	// SeverityError marks findings that make the tree fail to load or execute.
+	SeverityError
+)
+
+// String returns the lower-case name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// MarshalJSON encodes the severity by its name.
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// Finding is a single problem found by a [Rule].
+type Finding struct {
+	// Rule is the name of the rule that reported the finding.
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	// TreeID is the id of the innermost tree containing the element (if set).
+	TreeID string `json:"tree_id,omitempty"`
+	// NodeID is the id of the node (if set). For conditions it is the id of the
+	// node the condition belongs to.
+	NodeID *uint32 `json:"node_id,omitempty"`
+	// Path describes the location of the element in the tree in a human-readable
+	// way, e.g., `tree "main" > sequence "pick" > task#3`.
+	Path string `json:"path"`
+}
+
+// String returns the finding in a compiler-like single-line format.
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s: %s [%s]", f.Path, f.Severity, f.Message, f.Rule)
+}
+
+// Reporter collects the findings of a single rule.
+type Reporter struct {
+	rule     string
+	findings []Finding
+}
+
+// Report adds a finding for the given element.
+func (r *Reporter) Report(element behaviortree.VisitElement, severity Severity, format string, args ...any) {
+	f := Finding{
+		Rule:     r.rule,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+		Path:     Path(element),
+	}
+	tree := element.Tree()
+	if tree == nil {
+		tree = behaviortree.EnclosingTree(element)
+	}
+	f.TreeID = tree.GetTreeId()
+	node := element.Node()
+	if element.Condition() != nil {
+		for ancestor := range element.Ancestors() {
+			if node = ancestor.Node(); node != nil || ancestor.Tree() != nil {
+				break
+			}
+		}
+	}
+	if node != nil && node.Id != nil {
+		id := node.GetId()
+		f.NodeID = &id
+	}
+	r.findings = append(r.findings, f)
+}
+
+// Rule checks a Behavior Tree for one kind of problem.
+type Rule interface {
+	// Name returns a short, unique name of the rule, e.g., "duplicate-node-id".
+	Name() string
+	// Check checks the given tree and reports all problems to the reporter. An
+	// error is returned if the check itself could not be run.
+	Check(ctx context.Context, tree *btpb.BehaviorTree, r *Reporter) error
+}
+
+// ElementCheckFunc checks a single element of a Behavior Tree.
+type ElementCheckFunc func(ctx context.Context, element behaviortree.VisitElement, r *Reporter) error
+
+type elementRule struct {
+	name  string
+	check ElementCheckFunc
+}
+
+func (e *elementRule) Name() string {
+	return e.name
+}
+
+func (e *elementRule) Check(ctx context.Context, tree *btpb.BehaviorTree, r *Reporter) error {
+	return behaviortree.Walk(ctx, tree, &elementVisitor{check: e.check, r: r})
+}
+
+type elementVisitor struct {
+	check ElementCheckFunc
+	r     *Reporter
+}
+
+func (v *elementVisitor) Visit(ctx context.Context, element behaviortree.VisitElement) error {
+	return v.check(ctx, element, v.r)
+}
+
+// ElementRule creates a rule that calls the given function for every tree,
+// node and condition in the Behavior Tree.
+func ElementRule(name string, check ElementCheckFunc) Rule {
+	return &elementRule{name: name, check: check}
+}
+
+// Linter runs a set of rules on Behavior Trees.
+type Linter struct {
+	rules []Rule
+}
+
+// New creates a linter that runs the given rules.
+func New(rules ...Rule) *Linter {
+	return &Linter{rules: rules}
+}
+
+// Lint runs all rules on the given tree and returns their findings in the
+// order of the rules. Returns an error if any rule fails to run.
+func (l *Linter) Lint(ctx context.Context, tree *btpb.BehaviorTree) ([]Finding, error) {
+	var findings []Finding
+	for _, rule := range l.rules {
+		r := &Reporter{rule: rule.Name()}
+		if err := rule.Check(ctx, tree, r); err != nil {
+			return nil, fmt.Errorf("rule %q failed: %w", rule.Name(), err)
+		}
+		findings = append(findings, r.findings...)
+	}
+	return findings, nil
+}
+
+// HasErrors returns whether any of the given findings is an error.
+func HasErrors(findings []Finding) bool {
+	for _, f := range findings {
+		if f.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func describeNode(node *btpb.BehaviorTree_Node) string {
+	s := behaviortree.NodeType(node)
+	if s == "" {
+		s = "node"
+	}
+	if node.Name != nil {
+		return fmt.Sprintf("%s %q", s, node.GetName())
+	}
+	if node.Id != nil {
+		return fmt.Sprintf("%s#%d", s, node.GetId())
+	}
+	return s
+}
+
+func describe(element behaviortree.VisitElement) string {
+	switch {
+	case element.Tree() != nil:
+		if name := element.Tree().GetName(); name != "" {
+			return fmt.Sprintf("tree %q", name)
+		}
+		return "tree"
+	case element.Node() != nil:
+		return describeNode(element.Node())
+	default:
+		return "condition"
+	}
+}
+
+// Path returns a human-readable description of the location of the given
+// element, starting at the root of the tree.
+func Path(element behaviortree.VisitElement) string {
+	var parts []string
+	for ancestor := range element.AncestorsFromRoot() {
+		parts = append(parts, describe(ancestor))
+	}
+	parts = append(parts, describe(element))
+	return strings.Join(parts, " > ")
+}
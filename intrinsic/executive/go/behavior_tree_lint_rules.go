@@ -0,0 +1,519 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package behaviortreelint
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"intrinsic/executive/go/behaviortree"
+
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	btpb "intrinsic/executive/proto/behavior_tree_go_proto"
+)
+
+// paramsKey is the blackboard key under which the parameters of a
+// parameterizable behavior tree are available.
+const paramsKey = "params"
+
+// DefaultRules returns all rules that only need the Behavior Tree itself.
+// knownBlackboardKeys are passed to UnknownBlackboardKeyRule.
+func DefaultRules(knownBlackboardKeys ...string) []Rule {
+	return []Rule{
+		DuplicateNodeIDRule(),
+		EmptyCompositeRule(),
+		MissingChildRule(),
+		UnreachableChildRule(),
+		UnknownBlackboardKeyRule(knownBlackboardKeys...),
+	}
+}
+
+// DuplicateNodeIDRule reports nodes whose id is already used by another node
+// in the same tree. Called subtrees have their own id space.
+func DuplicateNodeIDRule() Rule {
+	type treeAndID struct {
+		tree *btpb.BehaviorTree
+		id   uint32
+	}
+	return &funcRule{name: "duplicate-node-id", check: func(ctx context.Context, tree *btpb.BehaviorTree, r *Reporter) error {
+		seen := map[treeAndID]*btpb.BehaviorTree_Node{}
+		return behaviortree.Walk(ctx, tree, &elementVisitor{r: r, check: func(ctx context.Context, element behaviortree.VisitElement, r *Reporter) error {
+			node := element.Node()
+			if node == nil || node.Id == nil {
+				return nil
+			}
+			key := treeAndID{tree: behaviortree.EnclosingTree(element), id: node.GetId()}
+			if first, ok := seen[key]; ok {
+				r.Report(element, SeverityError, "node id %d is already used by %s", node.GetId(), describeNode(first))
+				return nil
+			}
+			seen[key] = node
+			return nil
+		}})
+	}}
+}
+
+// EmptyCompositeRule reports sequence, parallel, selector and fallback nodes
+// without children.
+func EmptyCompositeRule() Rule {
+	return ElementRule("empty-composite", func(ctx context.Context, element behaviortree.VisitElement, r *Reporter) error {
+		node := element.Node()
+		empty := false
+		switch node.GetNodeType().(type) {
+		case *btpb.BehaviorTree_Node_Sequence:
+			empty = len(node.GetSequence().GetChildren()) == 0
+		case *btpb.BehaviorTree_Node_Parallel:
+			empty = len(node.GetParallel().GetChildren()) == 0
+		case *btpb.BehaviorTree_Node_Selector:
+			empty = len(node.GetSelector().GetChildren()) == 0 && len(node.GetSelector().GetBranches()) == 0
+		case *btpb.BehaviorTree_Node_Fallback:
+			empty = len(node.GetFallback().GetChildren()) == 0 && len(node.GetFallback().GetTries()) == 0
+		}
+		if empty {
+			r.Report(element, SeverityWarning, "%s node has no children", behaviortree.NodeType(node))
+		}
+		return nil
+	})
+}
+
+// MissingChildRule reports nodes that wrap other nodes (e.g., retry, loop or
+// subtree nodes) but do not have the wrapped node.
+func MissingChildRule() Rule {
+	return ElementRule("missing-child", func(ctx context.Context, element behaviortree.VisitElement, r *Reporter) error {
+		if tree := element.Tree(); tree != nil {
+			if tree.GetRoot() == nil {
+				r.Report(element, SeverityError, "tree has no root node")
+			}
+			return nil
+		}
+		node := element.Node()
+		if node == nil {
+			return nil
+		}
+		switch node.GetNodeType().(type) {
+		case nil:
+			r.Report(element, SeverityError, "node has no node type")
+		case *btpb.BehaviorTree_Node_Retry:
+			if node.GetRetry().GetChild() == nil {
+				r.Report(element, SeverityError, "retry node has no child")
+			}
+		case *btpb.BehaviorTree_Node_Loop:
+			if node.GetLoop().GetDo() == nil {
+				r.Report(element, SeverityError, "loop node has no do child")
+			}
+		case *btpb.BehaviorTree_Node_Branch:
+			if node.GetBranch().GetThen() == nil && node.GetBranch().GetElse() == nil {
+				r.Report(element, SeverityError, "branch node has neither a then nor an else child")
+			}
+		case *btpb.BehaviorTree_Node_SubTree:
+			if node.GetSubTree().GetTree() == nil {
+				r.Report(element, SeverityError, "sub_tree node has no tree")
+			}
+		case *btpb.BehaviorTree_Node_Selector:
+			for i, b := range node.GetSelector().GetBranches() {
+				if b.GetNode() == nil {
+					r.Report(element, SeverityError, "branch %d of selector node has no node", i)
+				}
+			}
+		case *btpb.BehaviorTree_Node_Fallback:
+			for i, t := range node.GetFallback().GetTries() {
+				if t.GetNode() == nil {
+					r.Report(element, SeverityError, "try %d of fallback node has no node", i)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// UnreachableChildRule reports children that can never be executed because
+// a previous child always succeeds (fallback) or a previous condition is
+// always satisfied (selector, branch).
+func UnreachableChildRule() Rule {
+	return ElementRule("unreachable-child", func(ctx context.Context, element behaviortree.VisitElement, r *Reporter) error {
+		node := element.Node()
+		switch node.GetNodeType().(type) {
+		case *btpb.BehaviorTree_Node_Fallback:
+			children := node.GetFallback().GetChildren()
+			for i, c := range children {
+				if alwaysSucceeds(c) && i+1 < len(children) {
+					r.Report(element, SeverityWarning, "children after %s are unreachable because it always succeeds", describeNode(c))
+					break
+				}
+			}
+			tries := node.GetFallback().GetTries()
+			for i, t := range tries {
+				if (t.Condition == nil || alwaysTrue(t.GetCondition())) && alwaysSucceeds(t.GetNode()) && i+1 < len(tries) {
+					r.Report(element, SeverityWarning, "tries after %s are unreachable because it always succeeds", describeNode(t.GetNode()))
+					break
+				}
+			}
+		case *btpb.BehaviorTree_Node_Selector:
+			branches := node.GetSelector().GetBranches()
+			for i, b := range branches {
+				if alwaysTrue(b.GetCondition()) && i+1 < len(branches) {
+					r.Report(element, SeverityWarning, "branches after %s are unreachable because its condition is always satisfied", describeNode(b.GetNode()))
+					break
+				}
+			}
+		case *btpb.BehaviorTree_Node_Branch:
+			branch := node.GetBranch()
+			if alwaysTrue(branch.GetIf()) && branch.GetElse() != nil {
+				r.Report(element, SeverityWarning, "else child is unreachable because the condition is always satisfied")
+			}
+			if alwaysFalse(branch.GetIf()) && branch.GetThen() != nil {
+				r.Report(element, SeverityWarning, "then child is unreachable because the condition is never satisfied")
+			}
+		}
+		return nil
+	})
+}
+
+// alwaysSucceeds returns whether the given node is known to always succeed
+// (if it finishes). This is a conservative check: nodes for which this cannot
+// be determined statically are considered to potentially fail.
+func alwaysSucceeds(node *btpb.BehaviorTree_Node) bool {
+	if node == nil || node.GetDecorators().GetCondition() != nil {
+		return false
+	}
+	if s := node.GetDecorators().GetExecutionSettings(); s.GetMode() == btpb.BehaviorTree_Node_ExecutionSettings_DISABLED {
+		return s.GetDisabledResultState() == btpb.BehaviorTree_Node_ExecutionSettings_SUCCEEDED
+	}
+	switch node.GetNodeType().(type) {
+	case *btpb.BehaviorTree_Node_Sequence:
+		for _, c := range node.GetSequence().GetChildren() {
+			if !alwaysSucceeds(c) {
+				return false
+			}
+		}
+		return true
+	case *btpb.BehaviorTree_Node_Parallel:
+		for _, c := range node.GetParallel().GetChildren() {
+			if !alwaysSucceeds(c) {
+				return false
+			}
+		}
+		return true
+	case *btpb.BehaviorTree_Node_Retry:
+		// An unlimited retry without recovery only finishes when the child
+		// succeeds.
+		retry := node.GetRetry()
+		return retry.GetMaxTries() == 0 && retry.GetRecovery() == nil
+	default:
+		return false
+	}
+}
+
+// alwaysTrue returns whether the given condition is known to always be
+// satisfied.
+func alwaysTrue(cond *btpb.BehaviorTree_Condition) bool {
+	switch cond.GetConditionType().(type) {
+	case *btpb.BehaviorTree_Condition_Blackboard:
+		return strings.TrimSpace(cond.GetBlackboard().GetCelExpression()) == "true"
+	case *btpb.BehaviorTree_Condition_AllOf:
+		for _, c := range cond.GetAllOf().GetConditions() {
+			if !alwaysTrue(c) {
+				return false
+			}
+		}
+		return true
+	case *btpb.BehaviorTree_Condition_AnyOf:
+		for _, c := range cond.GetAnyOf().GetConditions() {
+			if alwaysTrue(c) {
+				return true
+			}
+		}
+		return false
+	case *btpb.BehaviorTree_Condition_Not:
+		return alwaysFalse(cond.GetNot())
+	default:
+		return false
+	}
+}
+
+// alwaysFalse returns whether the given condition is known to never be
+// satisfied.
+func alwaysFalse(cond *btpb.BehaviorTree_Condition) bool {
+	switch cond.GetConditionType().(type) {
+	case *btpb.BehaviorTree_Condition_Blackboard:
+		return strings.TrimSpace(cond.GetBlackboard().GetCelExpression()) == "false"
+	case *btpb.BehaviorTree_Condition_AllOf:
+		for _, c := range cond.GetAllOf().GetConditions() {
+			if alwaysFalse(c) {
+				return true
+			}
+		}
+		return false
+	case *btpb.BehaviorTree_Condition_AnyOf:
+		for _, c := range cond.GetAnyOf().GetConditions() {
+			if !alwaysFalse(c) {
+				return false
+			}
+		}
+		return true
+	case *btpb.BehaviorTree_Condition_Not:
+		return alwaysTrue(cond.GetNot())
+	default:
+		return false
+	}
+}
+
+// UnknownBlackboardKeyRule reports conditions that reference blackboard keys
+// which are not written by any node of the tree. Keys that are provided by
+// other means (e.g., by loading a blackboard snapshot) can be passed as
+// knownKeys.
+//
+// Only conditions with a textual CEL expression or an extended status match
+// are checked.
+func UnknownBlackboardKeyRule(knownKeys ...string) Rule {
+	return &funcRule{name: "unknown-blackboard-key", check: func(ctx context.Context, tree *btpb.BehaviorTree, r *Reporter) error {
+		produced := map[string]bool{}
+		for _, k := range knownKeys {
+			produced[k] = true
+		}
+		collector := &elementVisitor{check: func(ctx context.Context, element behaviortree.VisitElement, r *Reporter) error {
+			for _, k := range producedKeys(element) {
+				produced[k] = true
+			}
+			return nil
+		}}
+		if err := behaviortree.Walk(ctx, tree, collector); err != nil {
+			return err
+		}
+
+		return behaviortree.Walk(ctx, tree, &elementVisitor{r: r, check: func(ctx context.Context, element behaviortree.VisitElement, r *Reporter) error {
+			cond := element.Condition()
+			if cond == nil {
+				return nil
+			}
+			var keys []string
+			switch cond.GetConditionType().(type) {
+			case *btpb.BehaviorTree_Condition_Blackboard:
+				keys = celIdentifiers(cond.GetBlackboard().GetCelExpression())
+			case *btpb.BehaviorTree_Condition_StatusMatch:
+				keys = []string{cond.GetStatusMatch().GetBlackboardKey()}
+			}
+			for _, k := range keys {
+				if !produced[k] {
+					r.Report(element, SeverityWarning, "blackboard key %q is not written by any node of the tree", k)
+				}
+			}
+			return nil
+		}})
+	}}
+}
+
+// producedKeys returns the blackboard keys written by the given element.
+func producedKeys(element behaviortree.VisitElement) []string {
+	var keys []string
+	if tree := element.Tree(); tree != nil {
+		if tree.GetDescription() != nil {
+			keys = append(keys, paramsKey)
+		}
+		return keys
+	}
+	node := element.Node()
+	if node == nil {
+		return nil
+	}
+	if k := node.GetDecorators().GetOnFailure().GetEmitExtendedStatus().GetToBlackboardKey(); k != "" {
+		keys = append(keys, k)
+	}
+	switch node.GetNodeType().(type) {
+	case *btpb.BehaviorTree_Node_Task:
+		keys = append(keys, node.GetTask().GetCallBehavior().GetReturnValueName())
+		keys = append(keys, node.GetTask().GetExecuteCode().GetReturnValueKey())
+	case *btpb.BehaviorTree_Node_Data:
+		keys = append(keys, node.GetData().GetCreateOrUpdate().GetBlackboardKey())
+	case *btpb.BehaviorTree_Node_Loop:
+		keys = append(keys, node.GetLoop().GetLoopCounterBlackboardKey())
+		keys = append(keys, node.GetLoop().GetForEach().GetValueBlackboardKey())
+	case *btpb.BehaviorTree_Node_Retry:
+		keys = append(keys, node.GetRetry().GetRetryCounterBlackboardKey())
+	}
+	return keys
+}
+
+// celKeywords are identifiers of CEL that do not refer to variables.
+var celKeywords = map[string]bool{
+	"true":  true,
+	"false": true,
+	"null":  true,
+	"in":    true,
+}
+
+// celMacros are CEL macros whose first argument declares a local variable.
+var celMacros = map[string]bool{
+	"all":        true,
+	"exists":     true,
+	"exists_one": true,
+	"map":        true,
+	"filter":     true,
+}
+
+// celIdentifiers returns the top-level variables referenced by the given CEL
+// expression, i.e., identifiers that are neither selected fields, called
+// functions nor variables declared by macros. This is a lexical
+// approximation, it does not fully parse the expression.
+func celIdentifiers(expr string) []string {
+	type token struct {
+		ident    string
+		selected bool
+		called   bool
+	}
+	var tokens []token
+	runes := []rune(expr)
+	lastSignificant := rune(0)
+	for i := 0; i < len(runes); {
+		c := runes[i]
+		switch {
+		case c == '"' || c == '\'':
+			// Skip string literals (including triple-quoted ones).
+			quote := string(runes[i : i+1])
+			if i+2 < len(runes) && runes[i+1] == c && runes[i+2] == c {
+				quote = strings.Repeat(string(c), 3)
+			}
+			i += len(quote)
+			for i < len(runes) && !strings.HasPrefix(string(runes[i:]), quote) {
+				if runes[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			i += len(quote)
+			lastSignificant = c
+		case unicode.IsDigit(c):
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			lastSignificant = '0'
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			t := token{ident: string(runes[start:i]), selected: lastSignificant == '.'}
+			j := i
+			for j < len(runes) && unicode.IsSpace(runes[j]) {
+				j++
+			}
+			t.called = j < len(runes) && runes[j] == '('
+			// String and bytes literals may be prefixed, e.g., r"..." or b'...'.
+			if j == i && j < len(runes) && (runes[j] == '"' || runes[j] == '\'') {
+				continue
+			}
+			tokens = append(tokens, t)
+			lastSignificant = 'a'
+		default:
+			if !unicode.IsSpace(c) {
+				lastSignificant = c
+			}
+			i++
+		}
+	}
+
+	local := map[string]bool{}
+	for i, t := range tokens {
+		if t.selected && t.called && celMacros[t.ident] && i+1 < len(tokens) {
+			local[tokens[i+1].ident] = true
+		}
+	}
+	var idents []string
+	seen := map[string]bool{}
+	for _, t := range tokens {
+		if t.selected || t.called || celKeywords[t.ident] || local[t.ident] || seen[t.ident] {
+			continue
+		}
+		seen[t.ident] = true
+		idents = append(idents, t.ident)
+	}
+	return idents
+}
+
+// IsInstalledFunc reports whether the skill or behavior tree with the given
+// id is installed in the solution.
+type IsInstalledFunc func(ctx context.Context, id string) (bool, error)
+
+// SkillNotInstalledRule reports task nodes calling skills or behavior trees
+// that are not installed in the solution.
+func SkillNotInstalledRule(isInstalled IsInstalledFunc) Rule {
+	return ElementRule("skill-not-installed", func(ctx context.Context, element behaviortree.VisitElement, r *Reporter) error {
+		call := element.Node().GetTask().GetCallBehavior()
+		if call == nil {
+			return nil
+		}
+		installed, err := isInstalled(ctx, call.GetSkillId())
+		if err != nil {
+			return fmt.Errorf("failed to check whether %q is installed: %w", call.GetSkillId(), err)
+		}
+		if !installed {
+			r.Report(element, SeverityError, "%q is not installed", call.GetSkillId())
+		}
+		return nil
+	})
+}
+
+// UnresolvableTypeURLRule reports parameters and data of nodes whose type URL
+// cannot be resolved with the given resolver.
+func UnresolvableTypeURLRule(resolver protoregistry.MessageTypeResolver) Rule {
+	return ElementRule("unresolvable-type-url", func(ctx context.Context, element behaviortree.VisitElement, r *Reporter) error {
+		for _, a := range nodeAnys(element.Node()) {
+			if a == nil {
+				continue
+			}
+			if _, err := resolver.FindMessageByURL(a.GetTypeUrl()); err != nil {
+				r.Report(element, SeverityError, "cannot resolve type URL %q: %v", a.GetTypeUrl(), err)
+			}
+		}
+		return nil
+	})
+}
+
+// nodeAnys returns the Any protos in the parameters and data of a node.
+func nodeAnys(node *btpb.BehaviorTree_Node) []*anypb.Any {
+	switch node.GetNodeType().(type) {
+	case *btpb.BehaviorTree_Node_Task:
+		return []*anypb.Any{
+			node.GetTask().GetCallBehavior().GetParameters(),
+			node.GetTask().GetExecuteCode().GetParameters().GetProto(),
+		}
+	case *btpb.BehaviorTree_Node_Data:
+		cu := node.GetData().GetCreateOrUpdate()
+		anys := []*anypb.Any{cu.GetProto(), cu.GetFromWorld().GetProto()}
+		return append(anys, cu.GetProtos().GetItems()...)
+	case *btpb.BehaviorTree_Node_Loop:
+		return node.GetLoop().GetForEach().GetProtos().GetItems()
+	default:
+		return nil
+	}
+}
+
+// funcRule is a rule implemented by a single function.
+type funcRule struct {
+	name  string
+	check func(ctx context.Context, tree *btpb.BehaviorTree, r *Reporter) error
+}
+
+func (f *funcRule) Name() string {
+	return f.name
+}
+
+func (f *funcRule) Check(ctx context.Context, tree *btpb.BehaviorTree, r *Reporter) error {
+	return f.check(ctx, tree, r)
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package behaviortreelint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	btpb "intrinsic/executive/proto/behavior_tree_go_proto"
+)
+
+func mustParseTree(t *testing.T, text string) *btpb.BehaviorTree {
+	t.Helper()
+	tree := &btpb.BehaviorTree{}
+	if err := prototext.Unmarshal([]byte(text), tree); err != nil {
+		t.Fatalf("prototext.Unmarshal(%q) failed: %v", text, err)
+	}
+	return tree
+}
+
+// finding is the part of a [Finding] compared in tests.
+type finding struct {
+	Rule string
+	Path string
+}
+
+func lint(t *testing.T, tree *btpb.BehaviorTree, rules ...Rule) []finding {
+	t.Helper()
+	findings, err := New(rules...).Lint(context.Background(), tree)
+	if err != nil {
+		t.Fatalf("Lint() failed: %v", err)
+	}
+	var got []finding
+	for _, f := range findings {
+		got = append(got, finding{Rule: f.Rule, Path: f.Path})
+	}
+	return got
+}
+
+func TestDefaultRules(t *testing.T) {
+	tests := []struct {
+		name string
+		tree string
+		want []finding
+	}{
+		{
+			name: "unknown blackboard key",
+			tree: `
+				name: "main"
+				root {
+					id: 1
+					sequence {
+						children { id: 2 name: "a" data { create_or_update { blackboard_key: "done" cel_expression: "true" } } }
+						children { id: 3 name: "b" branch { if { blackboard { cel_expression: "done && size(items.filter(x, x > 1)) > 0" } } then { id: 4 fail {} } } }
+					}
+				}`,
+			want: []finding{{Rule: "unknown-blackboard-key", Path: `tree "main" > sequence#1 > branch "b" > condition`}},
+		},
+		{
+			name: "duplicate node id",
+			tree: `
+				name: "main"
+				root {
+					id: 1
+					sequence {
+						children { id: 2 name: "a" fail {} }
+						children { id: 2 name: "b" fail {} }
+						children { id: 3 sub_tree { tree { root { id: 2 name: "c" fail {} } } } }
+					}
+				}`,
+			want: []finding{{Rule: "duplicate-node-id", Path: `tree "main" > sequence#1 > fail "b"`}},
+		},
+		{
+			name: "empty composites and missing children",
+			tree: `
+				root {
+					sequence {
+						children { name: "empty" parallel {} }
+						children { name: "retry" retry { max_tries: 3 } }
+					}
+				}`,
+			want: []finding{
+				{Rule: "empty-composite", Path: `tree > sequence > parallel "empty"`},
+				{Rule: "missing-child", Path: `tree > sequence > retry "retry"`},
+			},
+		},
+		{
+			name: "unreachable children",
+			tree: `
+				root {
+					name: "fallback"
+					fallback {
+						tries { node { name: "always" fail {} decorators { execution_settings { mode: DISABLED disabled_result_state: SUCCEEDED } } } }
+						tries { node { name: "never" fail {} } }
+					}
+				}`,
+			want: []finding{{Rule: "unreachable-child", Path: `tree > fallback "fallback"`}},
+		},
+		{
+			name: "status match on emitted key",
+			tree: `
+				root {
+					name: "branch"
+					branch {
+						if { status_match { blackboard_key: "error" } }
+						then {
+							name: "fail"
+							fail {}
+							decorators { on_failure { emit_extended_status { to_blackboard_key: "error" } } }
+						}
+					}
+				}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := lint(t, mustParseTree(t, tc.tree), DefaultRules()...)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Lint() returned unexpected findings (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSkillNotInstalledRule(t *testing.T) {
+	tree := mustParseTree(t, `
+		root {
+			sequence {
+				children { name: "installed" task { call_behavior { skill_id: "ai.intrinsic.move" } } }
+				children { name: "missing" task { call_behavior { skill_id: "ai.intrinsic.missing" } } }
+			}
+		}`)
+	isInstalled := func(ctx context.Context, id string) (bool, error) {
+		return id == "ai.intrinsic.move", nil
+	}
+
+	got := lint(t, tree, SkillNotInstalledRule(isInstalled))
+	want := []finding{{Rule: "skill-not-installed", Path: `tree > sequence > task "missing"`}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Lint() returned unexpected findings (-want +got):\n%s", diff)
+	}
+}
+
+func TestUnresolvableTypeURLRule(t *testing.T) {
+	tree := mustParseTree(t, `
+		root {
+			sequence {
+				children {
+					name: "known"
+					data { create_or_update { blackboard_key: "a" proto { type_url: "type.googleapis.com/intrinsic_proto.executive.BehaviorTree" } } }
+				}
+				children {
+					name: "unknown"
+					task { call_behavior { skill_id: "ai.intrinsic.move" parameters { type_url: "type.googleapis.com/does.not.Exist" } } }
+				}
+			}
+		}`)
+
+	got := lint(t, tree, UnresolvableTypeURLRule(protoregistry.GlobalTypes))
+	want := []finding{{Rule: "unresolvable-type-url", Path: `tree > sequence > task "unknown"`}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Lint() returned unexpected findings (-want +got):\n%s", diff)
+	}
+}
+
+func TestCelIdentifiers(t *testing.T) {
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{expr: "true", want: nil},
+		{expr: "a.b > 3 && c", want: []string{"a", "c"}},
+		{expr: `size(x) == 2 || y == "z.w"`, want: []string{"x", "y"}},
+		{expr: "items.exists(i, i.value > limit)", want: []string{"items", "limit"}},
+		{expr: `r'raw' + b"bytes" + s`, want: []string{"s"}},
+		{expr: "has(pose.position) && 1.5 < v", want: []string{"pose", "v"}},
+	}
+	for _, tc := range tests {
+		if diff := cmp.Diff(tc.want, celIdentifiers(tc.expr)); diff != "" {
+			t.Errorf("celIdentifiers(%q) returned unexpected result (-want +got):\n%s", tc.expr, diff)
+		}
+	}
+}
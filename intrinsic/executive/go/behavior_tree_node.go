@@ -0,0 +1,65 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package behaviortree
+
+import (
+	btpb "intrinsic/executive/proto/behavior_tree_go_proto"
+)
+
+// NodeType returns the name of the `node_type` field that is set in the given
+// node (e.g., "sequence", "task" or "sub_tree"). Returns an empty string if no
+// node type is set.
+func NodeType(node *btpb.BehaviorTree_Node) string {
+	switch node.GetNodeType().(type) {
+	case *btpb.BehaviorTree_Node_Sequence:
+		return "sequence"
+	case *btpb.BehaviorTree_Node_Parallel:
+		return "parallel"
+	case *btpb.BehaviorTree_Node_Task:
+		return "task"
+	case *btpb.BehaviorTree_Node_Fail:
+		return "fail"
+	case *btpb.BehaviorTree_Node_Selector:
+		return "selector"
+	case *btpb.BehaviorTree_Node_Fallback:
+		return "fallback"
+	case *btpb.BehaviorTree_Node_Branch:
+		return "branch"
+	case *btpb.BehaviorTree_Node_Loop:
+		return "loop"
+	case *btpb.BehaviorTree_Node_Retry:
+		return "retry"
+	case *btpb.BehaviorTree_Node_SubTree:
+		return "sub_tree"
+	case *btpb.BehaviorTree_Node_Data:
+		return "data"
+	case *btpb.BehaviorTree_Node_Debug:
+		return "debug"
+	default:
+		return ""
+	}
+}
+
+// EnclosingTree returns the innermost tree that contains the given element.
+// Returns nil if the element is not part of a tree (e.g., if it was not
+// visited by [Walk]).
+func EnclosingTree(element VisitElement) *btpb.BehaviorTree {
+	for ancestor := range element.Ancestors() {
+		if tree := ancestor.Tree(); tree != nil {
+			return tree
+		}
+	}
+	return nil
+}
@@ -0,0 +1,661 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package behaviortreequery selects elements of Behavior Trees with a small
+// path language similar to XPath.
+//
+// A query is a sequence of steps separated by "/" (direct child) or "//"
+// (descendant at any depth). Each step matches elements by their type and
+// optional predicates in brackets:
+//
+//	//task[skill_id="ai.intrinsic.move_robot"]
+//	sequence[name="pick"]/*
+//	/tree/sequence/retry[max_tries=3]//fail
+//	//task[parameters.velocity!="0.5" and name]
+//
+// Types are node types (e.g., "sequence" or "sub_tree"), "tree" or
+// "condition". The wildcard "*" matches any node. The top-level tree is the
+// only child of the root "/". A query that does not start with "/" matches at
+// any depth, i.e., "task" is equivalent to "//task". Direct children follow the
+// structure visited by [behaviortree.Walk]: the child of a sub_tree node is a
+// tree whose child is the root node of the sub-tree.
+//
+// A predicate is either a field path, which checks that the field is set, or a
+// comparison of a field path with a value using "=" or "!=". Field paths are
+// dotted proto field names. For nodes they are resolved relative to the node,
+// then relative to its type-specific message (e.g., "max_tries" for retry
+// nodes) and for task nodes relative to the call_behavior or execute_code
+// message (e.g., "skill_id"). Fields of Any protos can be accessed if a
+// resolver for their type is given with [WithResolver]. Several predicates can
+// be combined with "and".
+package behaviortreequery
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"intrinsic/executive/go/behaviortree"
+
+	btpb "intrinsic/executive/proto/behavior_tree_go_proto"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	typeTree      = "tree"
+	typeCondition = "condition"
+	typeWildcard  = "*"
+)
+
+var nodeTypes = []string{
+	"sequence", "parallel", "task", "fail", "selector", "fallback", "branch",
+	"loop", "retry", "sub_tree", "data", "debug",
+}
+
+// Resolver resolves the types of Any protos.
+type Resolver interface {
+	protoregistry.ExtensionTypeResolver
+	protoregistry.MessageTypeResolver
+}
+
+type options struct {
+	resolver Resolver
+}
+
+// Option enables customizing the evaluation of queries.
+type Option func(*options)
+
+// WithResolver is an option that enables accessing fields of Any protos whose
+// types can be resolved with the given resolver.
+func WithResolver(r Resolver) Option {
+	return func(o *options) {
+		o.resolver = r
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+type predicate struct {
+	path []string
+	// op is "=", "!=" or empty for an existence check.
+	op    string
+	value string
+}
+
+type step struct {
+	// descendant is true for steps preceded by "//".
+	descendant bool
+	typ        string
+	predicates []predicate
+}
+
+// Query is a parsed query.
+type Query struct {
+	expr  string
+	steps []step
+}
+
+// String returns the query as given to [Parse].
+func (q *Query) String() string {
+	return q.expr
+}
+
+type parser struct {
+	expr string
+	pos  int
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid query %q at position %d: %s", p.expr, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.expr) && p.expr[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *parser) consume(s string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.expr[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func isIdentRune(r byte) bool {
+	return r == '_' || r == '.' || r == '-' || r == '+' || unicode.IsLetter(rune(r)) || unicode.IsDigit(rune(r))
+}
+
+// word returns the next bare word (an identifier, path or number).
+func (p *parser) word() string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.expr) && isIdentRune(p.expr[p.pos]) {
+		p.pos++
+	}
+	return p.expr[start:p.pos]
+}
+
+func (p *parser) value() (string, error) {
+	p.skipSpace()
+	if p.pos < len(p.expr) && (p.expr[p.pos] == '"' || p.expr[p.pos] == '\'') {
+		quote := p.expr[p.pos]
+		end := p.pos + 1
+		for end < len(p.expr) && p.expr[end] != quote {
+			if p.expr[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(p.expr) {
+			return "", p.errorf("unterminated string")
+		}
+		raw := p.expr[p.pos : end+1]
+		p.pos = end + 1
+		if quote == '\'' {
+			raw = `"` + strings.ReplaceAll(raw[1:len(raw)-1], `"`, `\"`) + `"`
+		}
+		s, err := strconv.Unquote(raw)
+		if err != nil {
+			return "", p.errorf("invalid string %s", raw)
+		}
+		return s, nil
+	}
+	if w := p.word(); w != "" {
+		return w, nil
+	}
+	return "", p.errorf("expected value")
+}
+
+func (p *parser) predicate() (predicate, error) {
+	path := p.word()
+	if path == "" {
+		return predicate{}, p.errorf("expected field path")
+	}
+	pred := predicate{path: strings.Split(path, ".")}
+	for _, op := range []string{"!=", "="} {
+		if p.consume(op) {
+			pred.op = op
+			value, err := p.value()
+			if err != nil {
+				return predicate{}, err
+			}
+			pred.value = value
+			break
+		}
+	}
+	return pred, nil
+}
+
+func (p *parser) step(descendant bool) (step, error) {
+	s := step{descendant: descendant}
+	if p.consume(typeWildcard) {
+		s.typ = typeWildcard
+	} else {
+		s.typ = p.word()
+		if s.typ == "" {
+			return step{}, p.errorf("expected element type or *")
+		}
+		if s.typ != typeTree && s.typ != typeCondition && !slices.Contains(nodeTypes, s.typ) {
+			return step{}, p.errorf("unknown element type %q", s.typ)
+		}
+	}
+	for p.consume("[") {
+		for {
+			pred, err := p.predicate()
+			if err != nil {
+				return step{}, err
+			}
+			s.predicates = append(s.predicates, pred)
+			p.skipSpace()
+			if !strings.HasPrefix(p.expr[p.pos:], "and ") {
+				break
+			}
+			p.pos += len("and ")
+		}
+		if !p.consume("]") {
+			return step{}, p.errorf("expected ]")
+		}
+	}
+	roots := rootDescriptors(s.typ)
+	for _, pred := range s.predicates {
+		if !slices.ContainsFunc(roots, func(d protoreflect.MessageDescriptor) bool {
+			return d.Fields().ByName(protoreflect.Name(pred.path[0])) != nil
+		}) {
+			return step{}, p.errorf("no %s element has a field %q", s.typ, pred.path[0])
+		}
+	}
+	return s, nil
+}
+
+// rootDescriptors returns the descriptors of all messages relative to which
+// field paths of elements of the given step type can be resolved, see roots.
+func rootDescriptors(typ string) []protoreflect.MessageDescriptor {
+	switch typ {
+	case typeTree:
+		return []protoreflect.MessageDescriptor{(&btpb.BehaviorTree{}).ProtoReflect().Descriptor()}
+	case typeCondition:
+		return []protoreflect.MessageDescriptor{(&btpb.BehaviorTree_Condition{}).ProtoReflect().Descriptor()}
+	}
+	node := (&btpb.BehaviorTree_Node{}).ProtoReflect().Descriptor()
+	result := []protoreflect.MessageDescriptor{node}
+	nodeTypeFields := node.Oneofs().ByName("node_type").Fields()
+	for i := 0; i < nodeTypeFields.Len(); i++ {
+		fd := nodeTypeFields.Get(i)
+		if typ != typeWildcard && string(fd.Name()) != typ {
+			continue
+		}
+		result = append(result, fd.Message())
+		if taskType := fd.Message().Oneofs().ByName("task_type"); taskType != nil {
+			for j := 0; j < taskType.Fields().Len(); j++ {
+				result = append(result, taskType.Fields().Get(j).Message())
+			}
+		}
+	}
+	return result
+}
+
+// Parse parses the given query.
+func Parse(expr string) (*Query, error) {
+	p := &parser{expr: expr}
+	q := &Query{expr: expr}
+	descendant := true
+	switch {
+	case p.consume("//"):
+	case p.consume("/"):
+		descendant = false
+	}
+	for {
+		s, err := p.step(descendant)
+		if err != nil {
+			return nil, err
+		}
+		q.steps = append(q.steps, s)
+		if p.consume("//") {
+			descendant = true
+		} else if p.consume("/") {
+			descendant = false
+		} else {
+			break
+		}
+	}
+	p.skipSpace()
+	if p.pos != len(expr) {
+		return nil, p.errorf("unexpected %q", expr[p.pos:])
+	}
+	return q, nil
+}
+
+func elementMessage(element behaviortree.VisitElement) proto.Message {
+	switch {
+	case element.Tree() != nil:
+		return element.Tree()
+	case element.Node() != nil:
+		return element.Node()
+	default:
+		return element.Condition()
+	}
+}
+
+type elementCollector struct {
+	elements []behaviortree.VisitElement
+}
+
+func (c *elementCollector) Visit(ctx context.Context, element behaviortree.VisitElement) error {
+	c.elements = append(c.elements, element)
+	return nil
+}
+
+func (s *step) matchesType(element behaviortree.VisitElement) bool {
+	switch s.typ {
+	case typeTree:
+		return element.Tree() != nil
+	case typeCondition:
+		return element.Condition() != nil
+	case typeWildcard:
+		return element.Node() != nil
+	default:
+		return element.Node() != nil && behaviortree.NodeType(element.Node()) == s.typ
+	}
+}
+
+// Select returns all elements of the tree that match the query in the order in
+// which they are visited by [behaviortree.Walk]. The returned elements contain
+// their ancestors.
+func (q *Query) Select(ctx context.Context, tree *btpb.BehaviorTree, opts ...Option) ([]behaviortree.VisitElement, error) {
+	o := newOptions(opts)
+	collector := &elementCollector{}
+	if err := behaviortree.Walk(ctx, tree, collector); err != nil {
+		return nil, err
+	}
+
+	// The context of the first step is the virtual root, which is represented by
+	// nil.
+	current := map[proto.Message]bool{nil: true}
+	var matches []behaviortree.VisitElement
+	for _, s := range q.steps {
+		matches = nil
+		for _, element := range collector.elements {
+			if !s.matchesType(element) || !inContext(element, current, s.descendant) {
+				continue
+			}
+			ok, err := s.matchesPredicates(element, o)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				matches = append(matches, element)
+			}
+		}
+		current = map[proto.Message]bool{}
+		for _, m := range matches {
+			current[elementMessage(m)] = true
+		}
+	}
+	return matches, nil
+}
+
+// inContext returns whether the parent (or any ancestor if descendant is true)
+// of the element is in the context of the step.
+func inContext(element behaviortree.VisitElement, current map[proto.Message]bool, descendant bool) bool {
+	for ancestor := range element.Ancestors() {
+		if current[elementMessage(ancestor)] {
+			return true
+		}
+		if !descendant {
+			return false
+		}
+	}
+	// All elements are descendants of the virtual root, but only the top-level
+	// tree is its child.
+	return current[nil] && (descendant || element.Ancestor() == nil)
+}
+
+// Select parses the query and returns all matching elements of the tree.
+func Select(ctx context.Context, tree *btpb.BehaviorTree, expr string, opts ...Option) ([]behaviortree.VisitElement, error) {
+	q, err := Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	return q.Select(ctx, tree, opts...)
+}
+
+// roots returns the messages relative to which field paths of the element are
+// resolved.
+func roots(element behaviortree.VisitElement) []protoreflect.Message {
+	node := element.Node()
+	if node == nil {
+		return []protoreflect.Message{elementMessage(element).ProtoReflect()}
+	}
+	m := node.ProtoReflect()
+	result := []protoreflect.Message{m}
+	oneof := m.Descriptor().Oneofs().ByName("node_type")
+	fd := m.WhichOneof(oneof)
+	if fd == nil {
+		return result
+	}
+	typed := m.Get(fd).Message()
+	result = append(result, typed)
+	if task := node.GetTask(); task != nil {
+		if fd := typed.WhichOneof(typed.Descriptor().Oneofs().ByName("task_type")); fd != nil {
+			result = append(result, typed.Get(fd).Message())
+		}
+	}
+	return result
+}
+
+// rootFor returns the first root that has a field with the given name.
+func rootFor(element behaviortree.VisitElement, field string) (protoreflect.Message, error) {
+	for _, m := range roots(element) {
+		if m.Descriptor().Fields().ByName(protoreflect.Name(field)) != nil {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("%s has no field %q", describe(element), field)
+}
+
+func describe(element behaviortree.VisitElement) string {
+	switch {
+	case element.Tree() != nil:
+		return "tree"
+	case element.Node() != nil:
+		return behaviortree.NodeType(element.Node()) + " node"
+	default:
+		return "condition"
+	}
+}
+
+func (s *step) matchesPredicates(element behaviortree.VisitElement, o *options) (bool, error) {
+	for _, pred := range s.predicates {
+		ok, err := pred.matches(element, o)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (p *predicate) matches(element behaviortree.VisitElement, o *options) (bool, error) {
+	root, err := rootFor(element, p.path[0])
+	if err != nil {
+		// Parse rejects fields that no element of the step type has, so this
+		// element is of a type that legitimately lacks the field, e.g., a
+		// sequence node for "*[skill_id]".
+		return false, nil
+	}
+	value, fd, found, err := get(root, p.path, o)
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		return p.op == "!=", nil
+	}
+	if p.op == "" {
+		return true, nil
+	}
+	if fd.Message() != nil || fd.IsList() || fd.IsMap() {
+		return false, fmt.Errorf("cannot compare field %q with a value", strings.Join(p.path, "."))
+	}
+	return (formatScalar(fd, value) == p.value) == (p.op == "="), nil
+}
+
+func isAny(m protoreflect.Message) bool {
+	return m.Descriptor().FullName() == (&anypb.Any{}).ProtoReflect().Descriptor().FullName()
+}
+
+func unpack(m protoreflect.Message, o *options) (proto.Message, error) {
+	a, ok := m.Interface().(*anypb.Any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected Any type %T", m.Interface())
+	}
+	if o.resolver == nil {
+		return nil, fmt.Errorf("cannot access fields of Any proto of type %q without a resolver", a.GetTypeUrl())
+	}
+	msg, err := a.UnmarshalNew(proto.UnmarshalOptions{Resolver: o.resolver})
+	if err != nil {
+		return nil, fmt.Errorf("cannot unpack Any proto of type %q: %w", a.GetTypeUrl(), err)
+	}
+	return msg, nil
+}
+
+// get returns the value of the field with the given path and whether it is set.
+// Fields without presence are always considered set.
+func get(m protoreflect.Message, path []string, o *options) (protoreflect.Value, protoreflect.FieldDescriptor, bool, error) {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(path[0]))
+	if fd == nil {
+		return protoreflect.Value{}, nil, false, fmt.Errorf("%s has no field %q", m.Descriptor().FullName(), path[0])
+	}
+	if len(path) == 1 {
+		found := m.Has(fd) || (!fd.HasPresence() && !fd.IsList() && !fd.IsMap())
+		return m.Get(fd), fd, found, nil
+	}
+	if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+		return protoreflect.Value{}, nil, false, fmt.Errorf("cannot access %q in field %q", path[1], fd.Name())
+	}
+	if !m.Has(fd) {
+		return protoreflect.Value{}, nil, false, nil
+	}
+	sub := m.Get(fd).Message()
+	if isAny(sub) {
+		msg, err := unpack(sub, o)
+		if err != nil {
+			return protoreflect.Value{}, nil, false, err
+		}
+		sub = msg.ProtoReflect()
+	}
+	return get(sub, path[1:], o)
+}
+
+func formatScalar(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	if fd.Enum() != nil {
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return strconv.Itoa(int(v.Enum()))
+	}
+	if fd.Kind() == protoreflect.BytesKind {
+		return string(v.Bytes())
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// Set sets the field with the given path of the element to the value parsed
+// from the given string. Field paths are resolved as in predicates. Fields of
+// Any protos are set by unpacking the Any, setting the field and packing it
+// again. Message values are parsed in text format.
+func Set(element behaviortree.VisitElement, path string, value string, opts ...Option) error {
+	o := newOptions(opts)
+	parts := strings.Split(path, ".")
+	root, err := rootFor(element, parts[0])
+	if err != nil {
+		return err
+	}
+	return set(root, parts, value, o)
+}
+
+func set(m protoreflect.Message, path []string, value string, o *options) error {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(path[0]))
+	if fd == nil {
+		return fmt.Errorf("%s has no field %q", m.Descriptor().FullName(), path[0])
+	}
+	if fd.IsList() || fd.IsMap() {
+		return fmt.Errorf("cannot set repeated or map field %q", fd.Name())
+	}
+	if len(path) == 1 {
+		v, err := parseValue(m, fd, value, o)
+		if err != nil {
+			return fmt.Errorf("invalid value for field %q: %w", fd.Name(), err)
+		}
+		m.Set(fd, v)
+		return nil
+	}
+	if fd.Message() == nil {
+		return fmt.Errorf("cannot access %q in field %q", path[1], fd.Name())
+	}
+	// Set the field in a copy, so that m is unchanged if that fails.
+	sub := m.NewField(fd).Message()
+	if m.Has(fd) {
+		sub = proto.Clone(m.Get(fd).Message().Interface()).ProtoReflect()
+	}
+	if isAny(sub) {
+		msg, err := unpack(sub, o)
+		if err != nil {
+			return err
+		}
+		if err := set(msg.ProtoReflect(), path[1:], value, o); err != nil {
+			return err
+		}
+		a := sub.Interface().(*anypb.Any)
+		// MarshalFrom uses the default type URL prefix, keep the original one,
+		// e.g., of the proto registry.
+		typeURL := a.GetTypeUrl()
+		if err := anypb.MarshalFrom(a, msg, proto.MarshalOptions{}); err != nil {
+			return err
+		}
+		a.TypeUrl = typeURL
+	} else if err := set(sub, path[1:], value, o); err != nil {
+		return err
+	}
+	m.Set(fd, protoreflect.ValueOfMessage(sub))
+	return nil
+}
+
+func parseValue(m protoreflect.Message, fd protoreflect.FieldDescriptor, s string, o *options) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(s)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		i, err := strconv.ParseInt(s, 10, 32)
+		return protoreflect.ValueOfInt32(int32(i)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		i, err := strconv.ParseInt(s, 10, 64)
+		return protoreflect.ValueOfInt64(i), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		i, err := strconv.ParseUint(s, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(i)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		i, err := strconv.ParseUint(s, 10, 64)
+		return protoreflect.ValueOfUint64(i), err
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(s, 32)
+		return protoreflect.ValueOfFloat32(float32(f)), err
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(s, 64)
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(s)), nil
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(s)); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+		i, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("unknown value %q of enum %s", s, fd.Enum().FullName())
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(i)), nil
+	default:
+		msg := m.NewField(fd).Message()
+		text := strings.TrimSpace(s)
+		if strings.HasPrefix(text, "{") && strings.HasSuffix(text, "}") {
+			text = text[1 : len(text)-1]
+		}
+		unmarshaller := prototext.UnmarshalOptions{}
+		if o.resolver != nil {
+			unmarshaller.Resolver = o.resolver
+		}
+		if err := unmarshaller.Unmarshal([]byte(text), msg.Interface()); err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfMessage(msg), nil
+	}
+}
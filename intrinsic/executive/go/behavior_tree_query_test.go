@@ -0,0 +1,276 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package behaviortreequery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"intrinsic/executive/go/behaviortree"
+	btpb "intrinsic/executive/proto/behavior_tree_go_proto"
+)
+
+const testTree = `
+	name: "main"
+	root {
+		id: 1
+		name: "root"
+		sequence {
+			children {
+				id: 2
+				name: "pick"
+				sequence {
+					children { id: 3 name: "move" task { call_behavior { skill_id: "ai.intrinsic.move_robot" } } }
+					children { id: 4 name: "grasp" task { call_behavior { skill_id: "ai.intrinsic.grasp" } } }
+				}
+			}
+			children {
+				id: 5
+				name: "place"
+				retry {
+					max_tries: 3
+					child {
+						id: 6
+						name: "place_move"
+						task { call_behavior {
+							skill_id: "ai.intrinsic.move_robot"
+							parameters { [type.googleapis.com/intrinsic_proto.executive.BehaviorTree] { name: "fast" } }
+						} }
+					}
+				}
+			}
+			children {
+				id: 7
+				name: "sub"
+				decorators { condition { blackboard { cel_expression: "x" } } }
+				sub_tree { tree { name: "inner" root { id: 1 name: "inner_move" task { call_behavior { skill_id: "ai.intrinsic.move_robot" } } } } }
+			}
+		}
+	}
+`
+
+func mustParseTree(t *testing.T, text string) *btpb.BehaviorTree {
+	t.Helper()
+	tree := &btpb.BehaviorTree{}
+	if err := prototext.Unmarshal([]byte(text), tree); err != nil {
+		t.Fatalf("prototext.Unmarshal(%q) failed: %v", text, err)
+	}
+	return tree
+}
+
+func names(elements []behaviortree.VisitElement) []string {
+	var result []string
+	for _, e := range elements {
+		switch {
+		case e.Tree() != nil:
+			result = append(result, "tree "+e.Tree().GetName())
+		case e.Node() != nil:
+			result = append(result, e.Node().GetName())
+		default:
+			result = append(result, "condition")
+		}
+	}
+	return result
+}
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{expr: `//task[skill_id="ai.intrinsic.move_robot"]`, want: []string{"move", "place_move", "inner_move"}},
+		{expr: `task[skill_id='ai.intrinsic.grasp']`, want: []string{"grasp"}},
+		{expr: `sequence[name="pick"]/*`, want: []string{"move", "grasp"}},
+		{expr: `/tree/sequence/*`, want: []string{"pick", "place", "sub"}},
+		{expr: `/sequence`},
+		{expr: `/tree[name="main"]//tree`, want: []string{"tree inner"}},
+		{expr: `sub_tree/tree/task`, want: []string{"inner_move"}},
+		{expr: `sub_tree/condition`, want: []string{"condition"}},
+		{expr: `retry[max_tries=3]//task`, want: []string{"place_move"}},
+		{expr: `retry[max_tries!=3]`},
+		{expr: `task[parameters.name="fast"]`, want: []string{"place_move"}},
+		{expr: `task[parameters and id=6]`, want: []string{"place_move"}},
+		{expr: `*[skill_id="ai.intrinsic.move_robot" and id!=6]`, want: []string{"move", "inner_move"}},
+		{expr: `*[decorators]`, want: []string{"sub"}},
+	}
+
+	tree := mustParseTree(t, testTree)
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			got, err := Select(context.Background(), tree, tc.expr, WithResolver(protoregistry.GlobalTypes))
+			if err != nil {
+				t.Fatalf("Select(%q) failed: %v", tc.expr, err)
+			}
+			if diff := cmp.Diff(tc.want, names(got)); diff != "" {
+				t.Errorf("Select(%q) returned unexpected elements (-want +got):\n%s", tc.expr, diff)
+			}
+		})
+	}
+}
+
+func TestSelectAncestry(t *testing.T) {
+	tree := mustParseTree(t, testTree)
+	got, err := Select(context.Background(), tree, `//task[name="inner_move"]`)
+	if err != nil {
+		t.Fatalf("Select() failed: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("Select() returned %d elements, want 1", len(got))
+	}
+	var ancestors []behaviortree.VisitElement
+	for a := range got[0].Ancestors() {
+		ancestors = append(ancestors, a)
+	}
+	want := []string{"tree inner", "sub", "root", "tree main"}
+	if diff := cmp.Diff(want, names(ancestors)); diff != "" {
+		t.Errorf("Ancestors() returned unexpected elements (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"//",
+		"task[",
+		"task[skill_id=]",
+		`task[skill_id="x]`,
+		"unknown_type",
+		"task/",
+		"task]",
+		"task[unknown]",
+		"tree[max_tries=3]",
+		"*[unknown and name]",
+	} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", expr)
+		}
+	}
+}
+
+func TestSelectErrors(t *testing.T) {
+	tree := mustParseTree(t, testTree)
+	for _, expr := range []string{
+		// Messages can only be tested for existence.
+		`task[call_behavior="x"]`,
+		// Any protos require a resolver.
+		`task[parameters.name="fast"]`,
+		`task[skill_id.name]`,
+	} {
+		if _, err := Select(context.Background(), tree, expr); err == nil {
+			t.Errorf("Select(%q) succeeded, want error", expr)
+		}
+	}
+}
+
+func TestSet(t *testing.T) {
+	tree := mustParseTree(t, testTree)
+	opts := []Option{WithResolver(protoregistry.GlobalTypes)}
+	elements, err := Select(context.Background(), tree, `//task[skill_id="ai.intrinsic.move_robot"]`, opts...)
+	if err != nil {
+		t.Fatalf("Select() failed: %v", err)
+	}
+	for _, e := range elements {
+		for field, value := range map[string]string{
+			"skill_id":                        "ai.intrinsic.move_robot_v2",
+			"decorators.execution_settings":   "{ mode: DISABLED }",
+			"decorators.breakpoint":           "BEFORE",
+			"call_behavior.return_value_name": "result",
+		} {
+			if err := Set(e, field, value, opts...); err != nil {
+				t.Fatalf("Set(%q, %q) failed: %v", field, value, err)
+			}
+		}
+	}
+	placeMove, err := Select(context.Background(), tree, `task[name="place_move"]`)
+	if err != nil {
+		t.Fatalf("Select() failed: %v", err)
+	}
+	if err := Set(placeMove[0], "parameters.name", "slow", opts...); err != nil {
+		t.Fatalf("Set(parameters.name) failed: %v", err)
+	}
+
+	for expr, want := range map[string][]string{
+		`task[skill_id="ai.intrinsic.move_robot_v2" and return_value_name="result"]`: {"move", "place_move", "inner_move"},
+		`task[decorators.execution_settings.mode="DISABLED"]`:                        {"move", "place_move", "inner_move"},
+		`task[decorators.breakpoint="BEFORE"]`:                                       {"move", "place_move", "inner_move"},
+		`task[parameters.name="slow"]`:                                               {"place_move"},
+	} {
+		got, err := Select(context.Background(), tree, expr, opts...)
+		if err != nil {
+			t.Fatalf("Select(%q) failed: %v", expr, err)
+		}
+		if diff := cmp.Diff(want, names(got)); diff != "" {
+			t.Errorf("Select(%q) after Set() returned unexpected elements (-want +got):\n%s", expr, diff)
+		}
+	}
+}
+
+func TestSetKeepsTypeURL(t *testing.T) {
+	tree := mustParseTree(t, testTree)
+	opts := []Option{WithResolver(protoregistry.GlobalTypes)}
+	elements, err := Select(context.Background(), tree, `task[name="place_move"]`)
+	if err != nil {
+		t.Fatalf("Select() failed: %v", err)
+	}
+	const typeURL = "type.intrinsic.ai/skills/intrinsic_proto.executive.BehaviorTree"
+	params := elements[0].Node().GetTask().GetCallBehavior().GetParameters()
+	params.TypeUrl = typeURL
+	if err := Set(elements[0], "parameters.name", "slow", opts...); err != nil {
+		t.Fatalf("Set(parameters.name) failed: %v", err)
+	}
+	params = elements[0].Node().GetTask().GetCallBehavior().GetParameters()
+	if params.GetTypeUrl() != typeURL {
+		t.Errorf("Set() changed the type URL of the parameters to %q, want %q", params.GetTypeUrl(), typeURL)
+	}
+	got, err := Select(context.Background(), tree, `task[parameters.name="slow"]`, opts...)
+	if err != nil {
+		t.Fatalf("Select() failed: %v", err)
+	}
+	if diff := cmp.Diff([]string{"place_move"}, names(got)); diff != "" {
+		t.Errorf("Select() after Set() returned unexpected elements (-want +got):\n%s", diff)
+	}
+}
+
+func TestSetErrors(t *testing.T) {
+	tree := mustParseTree(t, testTree)
+	elements, err := Select(context.Background(), tree, `task[name="place_move"]`)
+	if err != nil {
+		t.Fatalf("Select() failed: %v", err)
+	}
+	opts := []Option{WithResolver(protoregistry.GlobalTypes)}
+	want := proto.Clone(elements[0].Node())
+	for field, value := range map[string]string{
+		"unknown":               "x",
+		"id":                    "abc",
+		"decorators.breakpoint": "NOT_A_VALUE",
+		"children":              "{}",
+		"skill_id.name":         "x",
+		"parameters.unknown":    "x",
+	} {
+		if err := Set(elements[0], field, value, opts...); err == nil {
+			t.Errorf("Set(%q, %q) succeeded, want error", field, value)
+		}
+	}
+	// Failed calls must not modify the node.
+	if diff := cmp.Diff(want, elements[0].Node(), protocmp.Transform()); diff != "" {
+		t.Errorf("Set() modified the node despite failing (-want +got):\n%s", diff)
+	}
+}
@@ -685,3 +685,57 @@ func TestVisitElement_Ancestors(t *testing.T) {
 		})
 	}
 }
+
+func TestNodeType(t *testing.T) {
+	tests := []struct {
+		name string
+		node *btpb.BehaviorTree_Node
+		want string
+	}{
+		{
+			name: "sequence",
+			node: &btpb.BehaviorTree_Node{
+				NodeType: &btpb.BehaviorTree_Node_Sequence{Sequence: &btpb.BehaviorTree_SequenceNode{}},
+			},
+			want: "sequence",
+		},
+		{
+			name: "sub_tree",
+			node: &btpb.BehaviorTree_Node{
+				NodeType: &btpb.BehaviorTree_Node_SubTree{SubTree: &btpb.BehaviorTree_SubtreeNode{}},
+			},
+			want: "sub_tree",
+		},
+		{
+			name: "unset",
+			node: &btpb.BehaviorTree_Node{},
+			want: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := behaviortree.NodeType(tc.node); got != tc.want {
+				t.Errorf("NodeType() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEnclosingTree(t *testing.T) {
+	treeA := &btpb.BehaviorTree{TreeId: proto.String("Tree_A")}
+	treeB := &btpb.BehaviorTree{TreeId: proto.String("Tree_B")}
+	node1 := &btpb.BehaviorTree_Node{Name: proto.String("Node_1")}
+	node2 := &btpb.BehaviorTree_Node{Name: proto.String("Node_2")}
+
+	element := behaviortree.VisitElementFromTree(treeA).
+		AsAncestorForNode(node1).
+		AsAncestorForTree(treeB).
+		AsAncestorForNode(node2)
+	if got := behaviortree.EnclosingTree(element); got != treeB {
+		t.Errorf("EnclosingTree() = %v, want %v", got, treeB)
+	}
+	if got := behaviortree.EnclosingTree(behaviortree.VisitElementFromNode(node1)); got != nil {
+		t.Errorf("EnclosingTree() = %v, want nil", got)
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package blackboard provides a Go client for the blackboard of executive
+// operations.
+package blackboard
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	bbgrpcpb "intrinsic/executive/proto/blackboard_service_go_proto"
+	bbpb "intrinsic/executive/proto/blackboard_service_go_proto"
+	espb "intrinsic/util/status/extended_status_go_proto"
+)
+
+// Client reads and modifies the blackboard of executive operations.
+type Client struct {
+	client bbgrpcpb.ExecutiveBlackboardClient
+}
+
+// NewClient creates a new blackboard client that uses the given connection to
+// the executive.
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{client: bbgrpcpb.NewExecutiveBlackboardClient(conn)}
+}
+
+// ListOptions configures which blackboard values are listed by [Client.List].
+type ListOptions struct {
+	// Scope limits the result to the given scope. Values of all scopes are
+	// returned if empty.
+	Scope string
+	// WithValues requests the full values. Otherwise only keys, scopes and type
+	// URLs are returned.
+	WithValues bool
+}
+
+// List returns the values on the blackboard of the given operation.
+func (c *Client) List(ctx context.Context, operation string, opts ListOptions) ([]*bbpb.BlackboardValue, error) {
+	req := &bbpb.ListBlackboardValuesRequest{
+		OperationName: operation,
+		View:          bbpb.ListBlackboardValuesRequest_ANY_TYPEURL_ONLY,
+	}
+	if opts.Scope != "" {
+		req.Scope = proto.String(opts.Scope)
+	}
+	if opts.WithValues {
+		req.View = bbpb.ListBlackboardValuesRequest_FULL
+	}
+	resp, err := c.client.ListBlackboardValues(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blackboard values of operation %q: %w", operation, err)
+	}
+	return resp.GetValues(), nil
+}
+
+// Get returns the value with the given key. If scope is empty, the scope of
+// the operation's process tree is used.
+func (c *Client) Get(ctx context.Context, operation string, scope string, key string) (*bbpb.BlackboardValue, error) {
+	req := &bbpb.GetBlackboardValueRequest{
+		OperationName: operation,
+		Key:           key,
+	}
+	if scope != "" {
+		req.Scope = proto.String(scope)
+	}
+	value, err := c.client.GetBlackboardValue(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blackboard value %q: %w", key, err)
+	}
+	return value, nil
+}
+
+// Set updates the value with the given key and returns the updated value.
+func (c *Client) Set(ctx context.Context, operation string, scope string, key string, value *anypb.Any) (*bbpb.BlackboardValue, error) {
+	updated, err := c.client.UpdateBlackboardValue(ctx, &bbpb.UpdateBlackboardValueRequest{
+		Value: &bbpb.BlackboardValue{
+			OperationName: operation,
+			Scope:         scope,
+			Key:           key,
+			Value:         value,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update blackboard value %q: %w", key, err)
+	}
+	return updated, nil
+}
+
+// Delete removes the value with the given key. If scope is empty, the scope
+// of the operation's process tree is used.
+func (c *Client) Delete(ctx context.Context, operation string, scope string, key string) error {
+	if _, err := c.client.DeleteBlackboardValue(ctx, &bbpb.DeleteBlackboardValueRequest{
+		OperationName: operation,
+		Scope:         scope,
+		Key:           key,
+	}); err != nil {
+		return fmt.Errorf("failed to delete blackboard value %q: %w", key, err)
+	}
+	return nil
+}
+
+// CreateSnapshot saves the blackboard of the given operation as a snapshot on
+// the executive.
+func (c *Client) CreateSnapshot(ctx context.Context, operation string, displayName string) (*bbpb.BlackboardSnapshot, error) {
+	resp, err := c.client.CreateBlackboardSnapshot(ctx, &bbpb.CreateBlackboardSnapshotRequest{
+		OperationName:  operation,
+		DisplayName:    displayName,
+		SnapshotSource: bbpb.BlackboardSnapshot_SNAPSHOT_SOURCE_USER,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blackboard snapshot of operation %q: %w", operation, err)
+	}
+	return resp.GetSnapshot(), nil
+}
+
+// LoadSnapshot merges the snapshot with the given handle into the blackboard
+// of the given operation. The returned diagnostics describe problems with
+// individual values and may be nil.
+func (c *Client) LoadSnapshot(ctx context.Context, operation string, handle string) (*espb.ExtendedStatus, error) {
+	resp, err := c.client.LoadBlackboardSnapshot(ctx, &bbpb.LoadBlackboardSnapshotRequest{
+		OperationName:   operation,
+		Handle:          handle,
+		IntegrationMode: bbpb.LoadBlackboardSnapshotRequest_INTEGRATION_MODE_MERGE,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load blackboard snapshot %q: %w", handle, err)
+	}
+	return resp.GetDiagnostics(), nil
+}
+
+// ListSnapshots returns all snapshots saved on the executive.
+func (c *Client) ListSnapshots(ctx context.Context) ([]*bbpb.BlackboardSnapshot, error) {
+	var snapshots []*bbpb.BlackboardSnapshot
+	req := &bbpb.ListBlackboardSnapshotsRequest{}
+	for {
+		resp, err := c.client.ListBlackboardSnapshots(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list blackboard snapshots: %w", err)
+		}
+		snapshots = append(snapshots, resp.GetSnapshots()...)
+		if resp.GetNextPageToken() == "" {
+			return snapshots, nil
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+}
+
+// DeleteSnapshot deletes the snapshot with the given handle.
+func (c *Client) DeleteSnapshot(ctx context.Context, handle string) error {
+	if _, err := c.client.DeleteBlackboardSnapshot(ctx, &bbpb.DeleteBlackboardSnapshotRequest{
+		Handle: handle,
+	}); err != nil {
+		return fmt.Errorf("failed to delete blackboard snapshot %q: %w", handle, err)
+	}
+	return nil
+}
+
+// Export returns all values on the blackboard of the given operation. Unlike
+// snapshots created with [Client.CreateSnapshot], the result can be stored
+// outside of the executive and written to a different operation or cluster
+// with [Client.Import].
+func (c *Client) Export(ctx context.Context, operation string) (*bbpb.ListBlackboardValuesResponse, error) {
+	values, err := c.List(ctx, operation, ListOptions{WithValues: true})
+	if err != nil {
+		return nil, err
+	}
+	for _, value := range values {
+		value.OperationName = ""
+	}
+	return &bbpb.ListBlackboardValuesResponse{Values: values}, nil
+}
+
+// Import writes all values of an export created with [Client.Export] to the
+// blackboard of the given operation. Existing values with the same key and
+// scope are overwritten, all other values are left untouched.
+func (c *Client) Import(ctx context.Context, operation string, export *bbpb.ListBlackboardValuesResponse) error {
+	for _, value := range export.GetValues() {
+		if _, err := c.Set(ctx, operation, value.GetScope(), value.GetKey(), value.GetValue()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blackboard_test
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+
+	"intrinsic/executive/go/blackboard"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	bbgrpcpb "intrinsic/executive/proto/blackboard_service_go_proto"
+	bbpb "intrinsic/executive/proto/blackboard_service_go_proto"
+)
+
+// fakeBlackboard stores the values of a single operation keyed by scope and
+// key.
+type fakeBlackboard struct {
+	bbgrpcpb.UnimplementedExecutiveBlackboardServer
+
+	values    map[[2]string]*bbpb.BlackboardValue
+	snapshots []*bbpb.BlackboardSnapshot
+	pageSize  int
+}
+
+func (f *fakeBlackboard) ListBlackboardValues(ctx context.Context, req *bbpb.ListBlackboardValuesRequest) (*bbpb.ListBlackboardValuesResponse, error) {
+	resp := &bbpb.ListBlackboardValuesResponse{}
+	for _, v := range f.values {
+		resp.Values = append(resp.Values, v)
+	}
+	return resp, nil
+}
+
+func (f *fakeBlackboard) UpdateBlackboardValue(ctx context.Context, req *bbpb.UpdateBlackboardValueRequest) (*bbpb.BlackboardValue, error) {
+	f.values[[2]string{req.GetValue().GetScope(), req.GetValue().GetKey()}] = req.GetValue()
+	return req.GetValue(), nil
+}
+
+func (f *fakeBlackboard) ListBlackboardSnapshots(ctx context.Context, req *bbpb.ListBlackboardSnapshotsRequest) (*bbpb.ListBlackboardSnapshotsResponse, error) {
+	start := 0
+	if req.GetPageToken() != "" {
+		var err error
+		if start, err = strconv.Atoi(req.GetPageToken()); err != nil {
+			return nil, err
+		}
+	}
+	end := min(start+f.pageSize, len(f.snapshots))
+	resp := &bbpb.ListBlackboardSnapshotsResponse{Snapshots: f.snapshots[start:end]}
+	if end < len(f.snapshots) {
+		resp.NextPageToken = strconv.Itoa(end)
+	}
+	return resp, nil
+}
+
+func newClient(t *testing.T, server *fakeBlackboard) *blackboard.Client {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	bbgrpcpb.RegisterExecutiveBlackboardServer(s, server)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return blackboard.NewClient(conn)
+}
+
+func mustAny(t *testing.T, v int64) *anypb.Any {
+	t.Helper()
+	a, err := anypb.New(wrapperspb.Int64(v))
+	if err != nil {
+		t.Fatalf("anypb.New() failed: %v", err)
+	}
+	return a
+}
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	source := &fakeBlackboard{values: map[[2]string]*bbpb.BlackboardValue{
+		{"", "counter"}: {Key: "counter", OperationName: "op1", Value: mustAny(t, 3)},
+		{"sub", "pose"}: {Key: "pose", Scope: "sub", OperationName: "op1", Value: mustAny(t, 4)},
+	}}
+	target := &fakeBlackboard{values: map[[2]string]*bbpb.BlackboardValue{
+		{"", "other"}: {Key: "other", OperationName: "op2", Value: mustAny(t, 5)},
+	}}
+
+	export, err := newClient(t, source).Export(ctx, "op1")
+	if err != nil {
+		t.Fatalf("Export() failed: %v", err)
+	}
+	for _, v := range export.GetValues() {
+		if v.GetOperationName() != "" {
+			t.Errorf("Export() returned value %q with operation name %q, want none", v.GetKey(), v.GetOperationName())
+		}
+	}
+	if err := newClient(t, target).Import(ctx, "op2", export); err != nil {
+		t.Fatalf("Import() failed: %v", err)
+	}
+
+	want := map[[2]string]*bbpb.BlackboardValue{
+		{"", "counter"}: {Key: "counter", OperationName: "op2", Value: mustAny(t, 3)},
+		{"sub", "pose"}: {Key: "pose", Scope: "sub", OperationName: "op2", Value: mustAny(t, 4)},
+		{"", "other"}:   {Key: "other", OperationName: "op2", Value: mustAny(t, 5)},
+	}
+	if diff := cmp.Diff(want, target.values, protocmp.Transform()); diff != "" {
+		t.Errorf("Import() resulted in unexpected blackboard values (-want +got):\n%s", diff)
+	}
+}
+
+func TestListSnapshots(t *testing.T) {
+	server := &fakeBlackboard{pageSize: 2}
+	for _, h := range []string{"a", "b", "c", "d", "e"} {
+		server.snapshots = append(server.snapshots, &bbpb.BlackboardSnapshot{Handle: h})
+	}
+
+	got, err := newClient(t, server).ListSnapshots(context.Background())
+	if err != nil {
+		t.Fatalf("ListSnapshots() failed: %v", err)
+	}
+	if diff := cmp.Diff(server.snapshots, got, protocmp.Transform()); diff != "" {
+		t.Errorf("ListSnapshots() returned unexpected snapshots (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nodeidentifier resolves nodes in Behavior Trees to node identifiers
+// (tree id and node id) and vice versa.
+//
+// Node identifiers are used by the executive to address nodes of a loaded
+// operation, e.g., for breakpoints or node execution settings.
+package nodeidentifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"intrinsic/executive/go/behaviortree"
+
+	btpb "intrinsic/executive/proto/behavior_tree_go_proto"
+)
+
+// ErrNotFound is returned if no node matches the search criteria.
+var ErrNotFound = errors.New("node not found")
+
+// ErrNotUnique is returned if more than one node matches the search criteria
+// but a unique node was requested.
+var ErrNotUnique = errors.New("node not unique")
+
+// Match is a node that was found in a Behavior Tree.
+type Match struct {
+	// Element is the visit element of the node, which gives access to the node
+	// and its ancestors.
+	Element behaviortree.VisitElement
+	// Identifier identifies the node within the searched tree.
+	Identifier *btpb.BehaviorTree_NodeIdentifier
+}
+
+// String returns a human-readable representation of the match.
+func (m Match) String() string {
+	return fmt.Sprintf("%q (%s)", m.Element.Node().GetName(), Format(m.Identifier))
+}
+
+// Format returns a compact string representation of a node identifier in the
+// form "tree_id:node_id". Nested identifiers for nodes within called behavior
+// trees are separated by "/".
+func Format(id *btpb.BehaviorTree_NodeIdentifier) string {
+	var parts []string
+	for ; id != nil; id = id.NodeWithinTaskNode {
+		parts = append(parts, fmt.Sprintf("%s:%d", id.GetTreeId(), id.GetNodeId()))
+	}
+	return strings.Join(parts, "/")
+}
+
+// Innermost returns the innermost identifier of a (potentially nested) node
+// identifier. This is the tree id and node id of the node itself, which is
+// what flat references like breakpoints use.
+func Innermost(id *btpb.BehaviorTree_NodeIdentifier) *btpb.BehaviorTree_NodeIdentifier {
+	for id.GetNodeWithinTaskNode() != nil {
+		id = id.GetNodeWithinTaskNode()
+	}
+	return id
+}
+
+func enclosingTreeElement(element behaviortree.VisitElement) (behaviortree.VisitElement, bool) {
+	for ancestor := range element.Ancestors() {
+		if ancestor.Tree() != nil {
+			return ancestor, true
+		}
+	}
+	return behaviortree.VisitElement{}, false
+}
+
+// FromVisitElement returns the node identifier for the node held by the given
+// element. The ancestry of the element is used to determine the enclosing tree.
+// If that tree is the called tree state of a task node (see
+// [behaviortree.VisitCalledTreeState]), the returned identifier refers to the
+// task node and identifies the node in `node_within_task_node`.
+//
+// Returns an error if the element does not hold a node or if the node or any
+// of the relevant trees do not have an id.
+func FromVisitElement(element behaviortree.VisitElement) (*btpb.BehaviorTree_NodeIdentifier, error) {
+	node := element.Node()
+	if node == nil {
+		return nil, fmt.Errorf("visit element is not a node")
+	}
+	if node.Id == nil {
+		return nil, fmt.Errorf("node %q does not have an id", node.GetName())
+	}
+	treeElement, ok := enclosingTreeElement(element)
+	if !ok {
+		return nil, fmt.Errorf("node %q is not part of a tree", node.GetName())
+	}
+	tree := treeElement.Tree()
+	if tree.TreeId == nil {
+		return nil, fmt.Errorf("tree containing node %q does not have an id", node.GetName())
+	}
+	id := &btpb.BehaviorTree_NodeIdentifier{
+		TreeId: tree.GetTreeId(),
+		NodeId: node.GetId(),
+	}
+
+	parent := treeElement.Ancestor()
+	if parent == nil || parent.Node().GetTask().GetCalledTreeState() != tree {
+		return id, nil
+	}
+	outer, err := FromVisitElement(*parent)
+	if err != nil {
+		return nil, err
+	}
+	Innermost(outer).NodeWithinTaskNode = id
+	return outer, nil
+}
+
+type nameMatcher struct {
+	name    string
+	matches []Match
+}
+
+func (m *nameMatcher) Visit(ctx context.Context, element behaviortree.VisitElement) error {
+	node := element.Node()
+	if node == nil || node.GetName() != m.name {
+		return nil
+	}
+	id, err := FromVisitElement(element)
+	if err != nil {
+		return fmt.Errorf("node with name %q found, but cannot be identified: %w", m.name, err)
+	}
+	m.matches = append(m.matches, Match{Element: element, Identifier: id})
+	return nil
+}
+
+// FindByName returns all nodes with the given name in the given tree. Nodes in
+// the called tree state of task nodes are included.
+func FindByName(ctx context.Context, tree *btpb.BehaviorTree, name string) ([]Match, error) {
+	matcher := &nameMatcher{name: name}
+	if err := behaviortree.Walk(ctx, tree, matcher, behaviortree.VisitCalledTreeState()); err != nil {
+		return nil, err
+	}
+	return matcher.matches, nil
+}
+
+// FindUniqueByName returns the node with the given name in the given tree.
+// Returns [ErrNotFound] if there is no such node and [ErrNotUnique] if there is
+// more than one.
+func FindUniqueByName(ctx context.Context, tree *btpb.BehaviorTree, name string) (Match, error) {
+	matches, err := FindByName(ctx, tree, name)
+	if err != nil {
+		return Match{}, err
+	}
+	switch len(matches) {
+	case 0:
+		return Match{}, fmt.Errorf("%w: no node with name %q", ErrNotFound, name)
+	case 1:
+		return matches[0], nil
+	default:
+		var found []string
+		for _, m := range matches {
+			found = append(found, Format(m.Identifier))
+		}
+		return Match{}, fmt.Errorf("%w: found %d nodes with name %q: %s", ErrNotUnique, len(matches), name, strings.Join(found, ", "))
+	}
+}
+
+type idMatcher struct {
+	treeID string
+	nodeID uint32
+	match  *Match
+}
+
+func (m *idMatcher) Visit(ctx context.Context, element behaviortree.VisitElement) error {
+	node := element.Node()
+	if node == nil || node.Id == nil || node.GetId() != m.nodeID {
+		return nil
+	}
+	if behaviortree.EnclosingTree(element).GetTreeId() != m.treeID {
+		return nil
+	}
+	id, err := FromVisitElement(element)
+	if err != nil {
+		return err
+	}
+	m.match = &Match{Element: element, Identifier: id}
+	return behaviortree.Stop
+}
+
+// FindByID returns the node with the given node id in the tree with the given
+// tree id. The tree can be the given tree itself or any tree nested in it.
+// Returns [ErrNotFound] if there is no such node.
+func FindByID(ctx context.Context, tree *btpb.BehaviorTree, treeID string, nodeID uint32) (Match, error) {
+	matcher := &idMatcher{treeID: treeID, nodeID: nodeID}
+	if err := behaviortree.Walk(ctx, tree, matcher, behaviortree.VisitCalledTreeState()); err != nil {
+		return Match{}, err
+	}
+	if matcher.match == nil {
+		return Match{}, fmt.Errorf("%w: no node with id %s:%d", ErrNotFound, treeID, nodeID)
+	}
+	return *matcher.match, nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeidentifier_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"intrinsic/executive/go/nodeidentifier"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	btpb "intrinsic/executive/proto/behavior_tree_go_proto"
+)
+
+func testTree() *btpb.BehaviorTree {
+	return &btpb.BehaviorTree{
+		TreeId: proto.String("Tree_A"),
+		Root: &btpb.BehaviorTree_Node{
+			Name: proto.String("Root"),
+			Id:   proto.Uint32(1),
+			NodeType: &btpb.BehaviorTree_Node_Sequence{
+				Sequence: &btpb.BehaviorTree_SequenceNode{
+					Children: []*btpb.BehaviorTree_Node{
+						{Name: proto.String("Move"), Id: proto.Uint32(2)},
+						{Name: proto.String("NoID")},
+						{
+							Name: proto.String("Subtree"),
+							Id:   proto.Uint32(3),
+							NodeType: &btpb.BehaviorTree_Node_SubTree{
+								SubTree: &btpb.BehaviorTree_SubtreeNode{
+									Tree: &btpb.BehaviorTree{
+										TreeId: proto.String("Tree_B"),
+										Root:   &btpb.BehaviorTree_Node{Name: proto.String("Move"), Id: proto.Uint32(1)},
+									},
+								},
+							},
+						},
+						{
+							Name: proto.String("Call"),
+							Id:   proto.Uint32(4),
+							NodeType: &btpb.BehaviorTree_Node_Task{
+								Task: &btpb.BehaviorTree_TaskNode{
+									CalledTreeState: &btpb.BehaviorTree{
+										TreeId: proto.String("Tree_C"),
+										Root:   &btpb.BehaviorTree_Node{Name: proto.String("Grasp"), Id: proto.Uint32(7)},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestFindUniqueByName(t *testing.T) {
+	tests := []struct {
+		name     string
+		nodeName string
+		want     *btpb.BehaviorTree_NodeIdentifier
+		wantErr  error
+	}{
+		{
+			name:     "root",
+			nodeName: "Root",
+			want:     &btpb.BehaviorTree_NodeIdentifier{TreeId: "Tree_A", NodeId: 1},
+		},
+		{
+			name:     "called_tree_state",
+			nodeName: "Grasp",
+			want: &btpb.BehaviorTree_NodeIdentifier{
+				TreeId: "Tree_A",
+				NodeId: 4,
+				NodeWithinTaskNode: &btpb.BehaviorTree_NodeIdentifier{
+					TreeId: "Tree_C",
+					NodeId: 7,
+				},
+			},
+		},
+		{
+			name:     "not_unique",
+			nodeName: "Move",
+			wantErr:  nodeidentifier.ErrNotUnique,
+		},
+		{
+			name:     "not_found",
+			nodeName: "Unknown",
+			wantErr:  nodeidentifier.ErrNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := nodeidentifier.FindUniqueByName(context.Background(), testTree(), tc.nodeName)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("FindUniqueByName(%q) returned error %v, want %v", tc.nodeName, err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got.Identifier, protocmp.Transform()); diff != "" {
+				t.Errorf("FindUniqueByName(%q) returned unexpected identifier (-want +got):\n%s", tc.nodeName, diff)
+			}
+		})
+	}
+}
+
+func TestFindByName_NodeWithoutID(t *testing.T) {
+	if _, err := nodeidentifier.FindByName(context.Background(), testTree(), "NoID"); err == nil {
+		t.Errorf("FindByName() succeeded for node without id, want error")
+	}
+}
+
+func TestFindByID(t *testing.T) {
+	got, err := nodeidentifier.FindByID(context.Background(), testTree(), "Tree_B", 1)
+	if err != nil {
+		t.Fatalf("FindByID() returned unexpected error: %v", err)
+	}
+	if name := got.Element.Node().GetName(); name != "Move" {
+		t.Errorf("FindByID() returned node %q, want %q", name, "Move")
+	}
+
+	if _, err := nodeidentifier.FindByID(context.Background(), testTree(), "Tree_B", 2); !errors.Is(err, nodeidentifier.ErrNotFound) {
+		t.Errorf("FindByID() returned error %v, want %v", err, nodeidentifier.ErrNotFound)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	id := &btpb.BehaviorTree_NodeIdentifier{
+		TreeId:             "Tree_A",
+		NodeId:             4,
+		NodeWithinTaskNode: &btpb.BehaviorTree_NodeIdentifier{TreeId: "Tree_C", NodeId: 7},
+	}
+	if got, want := nodeidentifier.Format(id), "Tree_A:4/Tree_C:7"; got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+	if got := nodeidentifier.Innermost(id); got.GetTreeId() != "Tree_C" || got.GetNodeId() != 7 {
+		t.Errorf("Innermost() = %v, want Tree_C:7", got)
+	}
+}
@@ -0,0 +1,382 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package actions creates action descriptions for the built-in ICON actions.
+//
+// A Builder fills the parameters of an action, checks them against the
+// action's signature on the server and returns an icon.ActionDescription that
+// is ready to be added to a session:
+//
+//	b := actions.NewBuilder(client, session)
+//	move, err := b.PointToPointMove(ctx, "arm", actions.PointToPointMoveParams{GoalPosition: goal})
+//	if err != nil {
+//		// error handling
+//	}
+//	move.Reactions = append(move.Reactions, icon.NewReaction(icon.IsTrue(actions.PointToPointMoveIsSettled), icon.EmitEventSignal(settled)))
+//	_, err = session.AddAction(move)
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"intrinsic/icon/go/icon"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	adiopb "intrinsic/icon/actions/adio_go_proto"
+	cartesianjoggingpb "intrinsic/icon/actions/cartesian_jogging_go_proto"
+	p2ppb "intrinsic/icon/actions/point_to_point_move_go_proto"
+	simplegripperpb "intrinsic/icon/actions/simple_gripper_go_proto"
+	tarepb "intrinsic/icon/actions/tare_force_torque_sensor_go_proto"
+	trajectorytrackingpb "intrinsic/icon/actions/trajectory_tracking_action_go_proto"
+	waitforsettlingpb "intrinsic/icon/actions/wait_for_settling_action_go_proto"
+	cartspacepb "intrinsic/icon/proto/cart_space_go_proto"
+	jointspacepb "intrinsic/icon/proto/joint_space_go_proto"
+	typespb "intrinsic/icon/proto/v1/types_go_proto"
+	jointlimitspb "intrinsic/kinematics/types/joint_limits_go_proto"
+)
+
+// Action type names of the built-in actions.
+const (
+	PointToPointMoveActionType      = "intrinsic.point_to_point_move"
+	TrajectoryTrackingActionType    = "intrinsic.trajectory_tracking"
+	ADIOActionType                  = "intrinsic.adio"
+	SimpleGripperActionType         = "intrinsic.simple_gripper"
+	CartesianJoggingActionType      = "intrinsic.cartesian_jogging"
+	WaitForSettlingActionType       = "intrinsic.wait_for_settling_action"
+	TareForceTorqueSensorActionType = "intrinsic.tare_force_torque_sensor"
+	StopActionType                  = "intrinsic.stop"
+)
+
+// State variables of the built-in actions, for use in reaction conditions.
+const (
+	PointToPointMoveIsSettled              = "intrinsic.is_settled"
+	PointToPointMoveIsSettledUncertainty   = "is_settled_uncertainty"
+	PointToPointMoveDistanceToSensed       = "intrinsic.distance_to_sensed"
+	PointToPointMoveSetpointDoneForSeconds = "intrinsic.setpoint_done_for_seconds"
+	TrajectoryTrackingIsSettled            = "is_settled"
+	TrajectoryTrackingIsSettledUncertainty = "is_settled_uncertainty"
+	TrajectoryTrackingProgress             = "trajectory_progress"
+	TrajectoryTrackingTimeSinceStart       = "time_since_trajectory_start"
+	TrajectoryTrackingCartesianArcLength   = "cartesian_arc_length_along_trajectory"
+	TrajectoryTrackingDistanceToFinal      = "distance_to_final_setpoint"
+	TrajectoryTrackingDoneForSeconds       = "trajectory_done_for_seconds"
+	ADIOAllInputsMatch                     = "intrinsic.all_inputs_match"
+	ADIOAnyInputsMatch                     = "intrinsic.any_inputs_match"
+	ADIOOutputsSet                         = "intrinsic.outputs_set"
+	SimpleGripperSentCommand               = "intrinsic.simple_gripper.sent_command"
+	SimpleGripperGrasped                   = "intrinsic.grasped"
+	SimpleGripperReleased                  = "intrinsic.released"
+	CartesianJoggingTimedOut               = "intrinsic.timed_out"
+	WaitForSettlingElapsedTimeSeconds      = "elapsed_time_seconds"
+	WaitForSettlingMaximumJointVelocity    = "maximum_joint_velocity_magnitude"
+	StopIsSettled                          = "is_settled"
+)
+
+// CartesianJoggingStreamingInput is the name of the streaming input of the
+// Cartesian jogging action, which accepts CartesianJoggingStreamingParams.
+const CartesianJoggingStreamingInput = "cartesian_jogging_command"
+
+// TrajectoryTrackingSignalPathAccurateStop is the realtime signal that
+// requests a path-accurate stop of the trajectory tracking action.
+const TrajectoryTrackingSignalPathAccurateStop = "signal_path_accurate_stop"
+
+const (
+	minUncertaintyThreshold = 0.01
+	maxUncertaintyThreshold = 0.5
+)
+
+var (
+	// ErrInvalidParams occurs when the parameters of an action are invalid.
+	ErrInvalidParams = errors.New("invalid action parameters")
+	// ErrIncompatiblePart occurs when a part cannot run an action.
+	ErrIncompatiblePart = errors.New("part is not compatible with action")
+	// ErrSignatureMismatch occurs when the parameters do not match the action
+	// signature of the server, e.g., because the server runs a different
+	// version.
+	ErrSignatureMismatch = errors.New("parameters do not match action signature")
+)
+
+// Builder creates action descriptions for a session. It fetches the action
+// signatures from the server once and is safe for concurrent use.
+type Builder struct {
+	client  icon.Client
+	session *icon.Session
+
+	mu         sync.Mutex
+	signatures map[string]*typespb.ActionSignature
+}
+
+// NewBuilder creates a Builder for actions of the given session.
+func NewBuilder(client icon.Client, session *icon.Session) *Builder {
+	return &Builder{
+		client:     client,
+		session:    session,
+		signatures: make(map[string]*typespb.ActionSignature),
+	}
+}
+
+func (b *Builder) signature(ctx context.Context, actionType string) (*typespb.ActionSignature, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if sig, ok := b.signatures[actionType]; ok {
+		return sig, nil
+	}
+	sig, err := b.client.ActionSignatureByName(ctx, actionType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signature of action type %q: %w", actionType, err)
+	}
+	b.signatures[actionType] = sig
+	return sig, nil
+}
+
+// checkParams checks that params have the type of the fixed parameters of the
+// signature and that all fields that are set exist on the server with the same
+// name, cardinality and type.
+func checkParams(sig *typespb.ActionSignature, params proto.Message) error {
+	want := sig.GetFixedParametersMessageType()
+	if params == nil {
+		if want != "" {
+			return fmt.Errorf("action type %q expects parameters of type %q: %w", sig.GetActionTypeName(), want, ErrSignatureMismatch)
+		}
+		return nil
+	}
+	m := params.ProtoReflect()
+	if got := string(m.Descriptor().FullName()); got != want {
+		return fmt.Errorf("action type %q expects parameters of type %q, not %q: %w", sig.GetActionTypeName(), want, got, ErrSignatureMismatch)
+	}
+	if sig.GetFixedParametersDescriptorSet() == nil {
+		return nil
+	}
+	files, err := protodesc.NewFiles(sig.GetFixedParametersDescriptorSet())
+	if err != nil {
+		return fmt.Errorf("invalid descriptor set for action type %q: %v", sig.GetActionTypeName(), err)
+	}
+	d, err := files.FindDescriptorByName(protoreflect.FullName(want))
+	if err != nil {
+		return fmt.Errorf("descriptor set of action type %q does not describe %q: %w", sig.GetActionTypeName(), want, ErrSignatureMismatch)
+	}
+	md, ok := d.(protoreflect.MessageDescriptor)
+	if !ok {
+		return fmt.Errorf("%q is not a message: %w", want, ErrSignatureMismatch)
+	}
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		serverFd := md.Fields().ByNumber(fd.Number())
+		if serverFd == nil || serverFd.Name() != fd.Name() {
+			err = fmt.Errorf("field %q is not known to the server: %w", fd.Name(), ErrSignatureMismatch)
+			return false
+		}
+		if !sameFieldType(fd, serverFd) {
+			err = fmt.Errorf("field %q has a different type on the server: %w", fd.Name(), ErrSignatureMismatch)
+			return false
+		}
+		return true
+	})
+	return err
+}
+
+// sameFieldType returns true if both fields have the same cardinality, kind
+// and, for message and enum fields, the same message or enum type.
+func sameFieldType(a, b protoreflect.FieldDescriptor) bool {
+	if a.Cardinality() != b.Cardinality() || a.Kind() != b.Kind() || a.IsMap() != b.IsMap() {
+		return false
+	}
+	switch a.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return a.Message().FullName() == b.Message().FullName()
+	case protoreflect.EnumKind:
+		return a.Enum().FullName() == b.Enum().FullName()
+	default:
+		return true
+	}
+}
+
+// newAction validates an action against its signature and the part and
+// creates its description.
+func (b *Builder) newAction(ctx context.Context, actionType string, part string, params proto.Message) (*icon.ActionDescription, error) {
+	if part == "" {
+		return nil, fmt.Errorf("missing part for action type %q: %w", actionType, ErrInvalidParams)
+	}
+	sig, err := b.signature(ctx, actionType)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkParams(sig, params); err != nil {
+		return nil, err
+	}
+	compatible, err := b.client.IsActionCompatible(ctx, actionType, part)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check compatibility of part %q with action type %q: %w", part, actionType, err)
+	}
+	if !compatible {
+		return nil, fmt.Errorf("part %q, action type %q: %w", part, actionType, ErrIncompatiblePart)
+	}
+	return &icon.ActionDescription{
+		Handle:     b.session.MakeActionHandle(),
+		ActionType: actionType,
+		Params:     params,
+		SlotData:   icon.FromPartName(part),
+	}, nil
+}
+
+// PointToPointMoveParams are the parameters of a point-to-point move.
+type PointToPointMoveParams struct {
+	// GoalPosition is the joint position to move to.
+	GoalPosition []float64
+	// GoalVelocity is the joint velocity when reaching the goal position.
+	// Defaults to zero, i.e., stopping at the goal.
+	GoalVelocity []float64
+	// JointLimits optionally restrict the limits of the part for this motion.
+	JointLimits *jointlimitspb.JointLimits
+}
+
+// PointToPointMove creates an action that moves the joints of a part to a goal
+// position along a jerk-limited, time-optimal trajectory. The action reports
+// PointToPointMoveIsSettled once residual oscillations have vanished.
+func (b *Builder) PointToPointMove(ctx context.Context, part string, p PointToPointMoveParams) (*icon.ActionDescription, error) {
+	if len(p.GoalPosition) == 0 {
+		return nil, fmt.Errorf("missing goal position: %w", ErrInvalidParams)
+	}
+	velocity := p.GoalVelocity
+	if velocity == nil {
+		velocity = make([]float64, len(p.GoalPosition))
+	}
+	if len(velocity) != len(p.GoalPosition) {
+		return nil, fmt.Errorf("goal velocity has %d joints, goal position has %d: %w", len(velocity), len(p.GoalPosition), ErrInvalidParams)
+	}
+	params := &p2ppb.PointToPointMoveFixedParams{
+		GoalPosition: &jointspacepb.JointVec{Joints: p.GoalPosition},
+		GoalVelocity: &jointspacepb.JointVec{Joints: velocity},
+		JointLimits:  p.JointLimits,
+	}
+	return b.newAction(ctx, PointToPointMoveActionType, part, params)
+}
+
+// TrajectoryTracking creates an action that follows a joint trajectory. The
+// previously commanded setpoint of the part must be close to the first state
+// of the trajectory.
+func (b *Builder) TrajectoryTracking(ctx context.Context, part string, trajectory *jointspacepb.JointTrajectoryPVA) (*icon.ActionDescription, error) {
+	if len(trajectory.GetState()) == 0 {
+		return nil, fmt.Errorf("empty trajectory: %w", ErrInvalidParams)
+	}
+	if len(trajectory.GetState()) != len(trajectory.GetTimeSinceStart()) {
+		return nil, fmt.Errorf("trajectory has %d states but %d timestamps: %w", len(trajectory.GetState()), len(trajectory.GetTimeSinceStart()), ErrInvalidParams)
+	}
+	params := &trajectorytrackingpb.TrajectoryTrackingActionFixedParams{Trajectory: trajectory}
+	return b.newAction(ctx, TrajectoryTrackingActionType, part, params)
+}
+
+// ADIOParams are the parameters of an ADIO action. Block names refer to the
+// input and output blocks of the part.
+type ADIOParams struct {
+	// DigitalOutputs are set when the action starts.
+	DigitalOutputs map[string]*adiopb.DigitalBlock
+	// AnalogOutputs are set when the action starts.
+	AnalogOutputs map[string]*adiopb.AnalogOutputBlock
+	// Expectations on inputs, which are reported by ADIOAllInputsMatch and
+	// ADIOAnyInputsMatch.
+	Expectations *adiopb.AnalogDigitalInExpectations
+}
+
+// ADIO creates an action that sets analog and digital outputs of a part and
+// watches its inputs.
+func (b *Builder) ADIO(ctx context.Context, part string, p ADIOParams) (*icon.ActionDescription, error) {
+	if len(p.DigitalOutputs) == 0 && len(p.AnalogOutputs) == 0 && p.Expectations == nil {
+		return nil, fmt.Errorf("neither outputs nor expectations: %w", ErrInvalidParams)
+	}
+	params := &adiopb.ADIOFixedParams{Expectations: p.Expectations}
+	if len(p.DigitalOutputs) > 0 || len(p.AnalogOutputs) > 0 {
+		params.Outputs = &adiopb.SetAnalogDigitalOutputs{
+			DigitalOutputs: p.DigitalOutputs,
+			AnalogOutputs:  p.AnalogOutputs,
+		}
+	}
+	return b.newAction(ctx, ADIOActionType, part, params)
+}
+
+// SimpleGripper creates an action that sends a grasp or release command to a
+// gripper part.
+func (b *Builder) SimpleGripper(ctx context.Context, part string, command simplegripperpb.SimpleGripperFixedParams_Command) (*icon.ActionDescription, error) {
+	if command == simplegripperpb.SimpleGripperFixedParams_UNKNOWN {
+		return nil, fmt.Errorf("missing gripper command: %w", ErrInvalidParams)
+	}
+	params := &simplegripperpb.SimpleGripperFixedParams{Command: command}
+	return b.newAction(ctx, SimpleGripperActionType, part, params)
+}
+
+// CartesianJoggingParams are the parameters of a Cartesian jogging action.
+type CartesianJoggingParams struct {
+	// CartesianLimits of the motion. Required.
+	CartesianLimits *cartspacepb.CartesianLimits
+	// JointLimits optionally restrict the limits of the part.
+	JointLimits *jointlimitspb.JointLimits
+	// Frames optionally set the frame in which twists are given.
+	Frames *cartesianjoggingpb.CartesianJoggingFrames
+}
+
+// CartesianJogging creates an action that moves the end effector of a part
+// with the twists written to its CartesianJoggingStreamingInput, see
+// CartesianJoggingCommand.
+func (b *Builder) CartesianJogging(ctx context.Context, part string, p CartesianJoggingParams) (*icon.ActionDescription, error) {
+	if p.CartesianLimits == nil {
+		return nil, fmt.Errorf("missing Cartesian limits: %w", ErrInvalidParams)
+	}
+	params := &cartesianjoggingpb.CartesianJoggingFixedParams{
+		CartesianLimits: p.CartesianLimits,
+		JointLimits:     p.JointLimits,
+		Frames:          p.Frames,
+	}
+	return b.newAction(ctx, CartesianJoggingActionType, part, params)
+}
+
+// CartesianJoggingCommand creates a value for the streaming input of a
+// Cartesian jogging action.
+func CartesianJoggingCommand(twist *cartspacepb.Twist) *cartesianjoggingpb.CartesianJoggingStreamingParams {
+	return &cartesianjoggingpb.CartesianJoggingStreamingParams{GoalTwist: twist}
+}
+
+// WaitForSettling creates an action that waits until the joints of a part have
+// settled. uncertaintyThreshold must be in [0.01, 0.5], or 0 for the server's
+// default.
+func (b *Builder) WaitForSettling(ctx context.Context, part string, uncertaintyThreshold float64) (*icon.ActionDescription, error) {
+	params := &waitforsettlingpb.WaitForSettlingActionFixedParams{}
+	if uncertaintyThreshold != 0 {
+		if uncertaintyThreshold < minUncertaintyThreshold || uncertaintyThreshold > maxUncertaintyThreshold {
+			return nil, fmt.Errorf("uncertainty threshold %v is not in [%v, %v]: %w", uncertaintyThreshold, minUncertaintyThreshold, maxUncertaintyThreshold, ErrInvalidParams)
+		}
+		params.UncertaintyThreshold = proto.Float64(uncertaintyThreshold)
+	}
+	return b.newAction(ctx, WaitForSettlingActionType, part, params)
+}
+
+// TareForceTorqueSensor creates an action that resets the bias of a
+// force-torque sensor, averaged over numTaringCycles control cycles (0 for the
+// server's default). Only run it while the robot does not move.
+func (b *Builder) TareForceTorqueSensor(ctx context.Context, part string, numTaringCycles uint32) (*icon.ActionDescription, error) {
+	params := &tarepb.TareForceTorqueSensorParams{}
+	if numTaringCycles != 0 {
+		params.NumTaringCycles = proto.Uint32(numTaringCycles)
+	}
+	return b.newAction(ctx, TareForceTorqueSensorActionType, part, params)
+}
+
+// Stop creates an action that stops the joints of a part.
+func (b *Builder) Stop(ctx context.Context, part string) (*icon.ActionDescription, error) {
+	return b.newAction(ctx, StopActionType, part, nil)
+}
@@ -0,0 +1,196 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"intrinsic/icon/go/icon"
+	"intrinsic/icon/go/icontest"
+
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	p2ppb "intrinsic/icon/actions/point_to_point_move_go_proto"
+	simplegripperpb "intrinsic/icon/actions/simple_gripper_go_proto"
+	typespb "intrinsic/icon/proto/v1/types_go_proto"
+	jointlimitspb "intrinsic/kinematics/types/joint_limits_go_proto"
+
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+)
+
+var jointPositionSlot = map[string]*typespb.ActionSignature_PartSlotInfo{
+	"arm": {RequiredFeatureInterfaces: []typespb.FeatureInterfaceTypes{typespb.FeatureInterfaceTypes_FEATURE_INTERFACE_JOINT_POSITION}},
+}
+
+// pointToPointMoveDescriptors returns the descriptors of the point-to-point
+// move parameters and their dependencies, with the parameters message modified
+// by edit, to simulate a server with a different version of the action.
+func pointToPointMoveDescriptors(edit func(*descriptorpb.DescriptorProto)) *descriptorpb.FileDescriptorSet {
+	params := (&p2ppb.PointToPointMoveFixedParams{}).ProtoReflect().Descriptor()
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := map[string]bool{}
+	var add func(protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+		for i := 0; i < fd.Imports().Len(); i++ {
+			add(fd.Imports().Get(i).FileDescriptor)
+		}
+		fdp := protodesc.ToFileDescriptorProto(fd)
+		if fd == params.ParentFile() {
+			for _, m := range fdp.GetMessageType() {
+				if m.GetName() == string(params.Name()) {
+					edit(m)
+				}
+			}
+		}
+		set.File = append(set.File, fdp)
+	}
+	add(params.ParentFile())
+	return set
+}
+
+// oldPointToPointMoveDescriptors describes a version of the point-to-point
+// move parameters without joint limits.
+var oldPointToPointMoveDescriptors = pointToPointMoveDescriptors(func(m *descriptorpb.DescriptorProto) {
+	m.Field = slices.DeleteFunc(m.Field, func(f *descriptorpb.FieldDescriptorProto) bool {
+		return f.GetName() == "joint_limits"
+	})
+})
+
+// changedPointToPointMoveDescriptors describes a version of the point-to-point
+// move parameters whose goal position is a list of doubles.
+var changedPointToPointMoveDescriptors = pointToPointMoveDescriptors(func(m *descriptorpb.DescriptorProto) {
+	for _, f := range m.Field {
+		if f.GetName() == "goal_position" {
+			f.Type = descriptorpb.FieldDescriptorProto_TYPE_DOUBLE.Enum()
+			f.TypeName = nil
+			f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		}
+	}
+})
+
+func newTestBuilder(t *testing.T, signatures ...*typespb.ActionSignature) (*Builder, *icon.Session) {
+	t.Helper()
+	srv := icontest.NewServer(
+		icontest.WithParts(
+			&typespb.PartConfig{Name: "arm", FeatureInterfaces: []typespb.FeatureInterfaceTypes{typespb.FeatureInterfaceTypes_FEATURE_INTERFACE_JOINT_POSITION}},
+			&typespb.PartConfig{Name: "gripper", FeatureInterfaces: []typespb.FeatureInterfaceTypes{typespb.FeatureInterfaceTypes_FEATURE_INTERFACE_SIMPLE_GRIPPER}},
+		),
+		icontest.WithActionSignatures(signatures...),
+	)
+	t.Cleanup(srv.Close)
+	ctx := context.Background()
+	client, err := srv.NewClient(ctx)
+	if err != nil {
+		t.Fatalf("NewClient() failed: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	session, err := client.StartSession(ctx, []string{"arm", "gripper"}, nil)
+	if err != nil {
+		t.Fatalf("StartSession() failed: %v", err)
+	}
+	t.Cleanup(func() { session.End() })
+	return NewBuilder(client, session), session
+}
+
+func TestPointToPointMove(t *testing.T) {
+	b, session := newTestBuilder(t, &typespb.ActionSignature{
+		ActionTypeName:             PointToPointMoveActionType,
+		FixedParametersMessageType: "intrinsic_proto.icon.actions.proto.PointToPointMoveFixedParams",
+		PartSlotInfos:              jointPositionSlot,
+	})
+	ctx := context.Background()
+
+	ad, err := b.PointToPointMove(ctx, "arm", PointToPointMoveParams{GoalPosition: []float64{1, 2, 3}})
+	if err != nil {
+		t.Fatalf("PointToPointMove() failed: %v", err)
+	}
+	params, ok := ad.Params.(*p2ppb.PointToPointMoveFixedParams)
+	if !ok {
+		t.Fatalf("PointToPointMove() has params of type %T, want PointToPointMoveFixedParams", ad.Params)
+	}
+	if got := params.GetGoalVelocity().GetJoints(); len(got) != 3 {
+		t.Errorf("PointToPointMove() has goal velocity %v, want 3 zeros", got)
+	}
+	if _, err := session.AddAction(ad); err != nil {
+		t.Errorf("AddAction() failed: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		part string
+		p    PointToPointMoveParams
+		want error
+	}{
+		{name: "no goal", part: "arm", want: ErrInvalidParams},
+		{name: "velocity size", part: "arm", p: PointToPointMoveParams{GoalPosition: []float64{1, 2}, GoalVelocity: []float64{0}}, want: ErrInvalidParams},
+		{name: "incompatible part", part: "gripper", p: PointToPointMoveParams{GoalPosition: []float64{1}}, want: ErrIncompatiblePart},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := b.PointToPointMove(ctx, tc.part, tc.p); !errors.Is(err, tc.want) {
+				t.Errorf("PointToPointMove() returned error %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestSignatureMismatch(t *testing.T) {
+	b, _ := newTestBuilder(t,
+		&typespb.ActionSignature{
+			ActionTypeName:               PointToPointMoveActionType,
+			FixedParametersMessageType:   "intrinsic_proto.icon.actions.proto.PointToPointMoveFixedParams",
+			FixedParametersDescriptorSet: oldPointToPointMoveDescriptors,
+		},
+		&typespb.ActionSignature{
+			ActionTypeName:             SimpleGripperActionType,
+			FixedParametersMessageType: "intrinsic_proto.icon.actions.proto.OtherGripperParams",
+		},
+	)
+	ctx := context.Background()
+
+	if _, err := b.PointToPointMove(ctx, "arm", PointToPointMoveParams{GoalPosition: []float64{1}}); err != nil {
+		t.Errorf("PointToPointMove() failed: %v", err)
+	}
+	if _, err := b.PointToPointMove(ctx, "arm", PointToPointMoveParams{
+		GoalPosition: []float64{1},
+		JointLimits:  &jointlimitspb.JointLimits{},
+	}); !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("PointToPointMove() with a field unknown to the server returned error %v, want %v", err, ErrSignatureMismatch)
+	}
+	if _, err := b.SimpleGripper(ctx, "gripper", simplegripperpb.SimpleGripperFixedParams_GRASP); !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("SimpleGripper() returned error %v, want %v", err, ErrSignatureMismatch)
+	}
+	if _, err := b.Stop(ctx, "arm"); err == nil {
+		t.Errorf("Stop() of an unknown action type succeeded, want error")
+	}
+}
+
+func TestSignatureFieldTypeMismatch(t *testing.T) {
+	b, _ := newTestBuilder(t, &typespb.ActionSignature{
+		ActionTypeName:               PointToPointMoveActionType,
+		FixedParametersMessageType:   "intrinsic_proto.icon.actions.proto.PointToPointMoveFixedParams",
+		FixedParametersDescriptorSet: changedPointToPointMoveDescriptors,
+	})
+	if _, err := b.PointToPointMove(context.Background(), "arm", PointToPointMoveParams{GoalPosition: []float64{1}}); !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("PointToPointMove() with a field of a different type on the server returned error %v, want %v", err, ErrSignatureMismatch)
+	}
+}
@@ -26,15 +26,18 @@ package icon
 
 import (
 	"context"
+	"io"
 
 	log "github.com/golang/glog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
+	loggingmodepb "intrinsic/icon/proto/logging_mode_go_proto"
 	grpcpb "intrinsic/icon/proto/v1/service_go_proto"
 	pb "intrinsic/icon/proto/v1/service_go_proto"
 	typespb "intrinsic/icon/proto/v1/types_go_proto"
 	contextpb "intrinsic/logging/proto/context_go_proto"
+	robotpayloadpb "intrinsic/world/proto/robot_payload_go_proto"
 
 	epb "google.golang.org/protobuf/types/known/emptypb"
 )
@@ -188,6 +191,32 @@ type Client interface {
 	//
 	// NOTE: Restarting the server also ends all Sessions, and shuts down any hardware safely.
 	RestartServer(ctx context.Context) error
+
+	// GetLatestStreamingOutput returns the most recent streaming output of an
+	// action in the session with the given ID (see Session.ID). Blocks until the
+	// action writes its first output or ctx expires, but returns an error
+	// immediately if the action does not exist.
+	GetLatestStreamingOutput(ctx context.Context, sessionID int64, actionID ActionID) (*StreamingOutput, error)
+
+	// GetPlannedTrajectory returns the trajectory planned by an action in the
+	// session with the given ID (see Session.ID). Returns a NotFound error if
+	// the action has no planned trajectory.
+	GetPlannedTrajectory(ctx context.Context, sessionID int64, actionID ActionID) (*PlannedTrajectory, error)
+
+	// Returns the current logging mode of the server.
+	GetLoggingMode(ctx context.Context) (loggingmodepb.LoggingMode, error)
+
+	// Sets the logging mode of the server, i.e., whether robot status is logged
+	// at full rate or throttled.
+	SetLoggingMode(ctx context.Context, mode loggingmodepb.LoggingMode) error
+
+	// Returns the payload with the given name of a part, or nil if the payload
+	// is not set.
+	GetPayload(ctx context.Context, part string, payloadName string) (*robotpayloadpb.RobotPayload, error)
+
+	// Sets the payload with the given name of a part. Compatible parts use the
+	// payload, e.g., for gravity compensation.
+	SetPayload(ctx context.Context, part string, payloadName string, payload *robotpayloadpb.RobotPayload) error
 }
 
 // clientOptions configure an InitClient call. clientOptions are set by the
@@ -359,7 +388,7 @@ func (c *grpcClient) Parts(ctx context.Context) ([]string, error) {
 
 func (c *grpcClient) StartSession(ctx context.Context, parts []string, logContext *contextpb.Context) (*Session, error) {
 	ctx = c.addOutgoingMetadata(ctx)
-	return newSession(ctx, c, parts, logContext)
+	return newSession(ctx, c, parts, logContext, nil)
 }
 
 func (c *grpcClient) Enable(ctx context.Context) error {
@@ -445,3 +474,71 @@ func (c *grpcClient) RestartServer(ctx context.Context) error {
 	_, err := c.client.RestartServer(ctx, &epb.Empty{})
 	return err
 }
+
+func (c *grpcClient) GetLatestStreamingOutput(ctx context.Context, sessionID int64, actionID ActionID) (*StreamingOutput, error) {
+	ctx = c.addOutgoingMetadata(ctx)
+	resp, err := c.client.GetLatestStreamingOutput(ctx, &pb.GetLatestStreamingOutputRequest{
+		SessionId: sessionID,
+		ActionId:  uint64(actionID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newStreamingOutput(resp.GetOutput()), nil
+}
+
+func (c *grpcClient) GetPlannedTrajectory(ctx context.Context, sessionID int64, actionID ActionID) (*PlannedTrajectory, error) {
+	ctx = c.addOutgoingMetadata(ctx)
+	stream, err := c.client.GetPlannedTrajectory(ctx, &pb.GetPlannedTrajectoryRequest{
+		SessionId: sessionID,
+		ActionId:  uint64(actionID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	t := &PlannedTrajectory{}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return t, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		t.addSegment(resp.GetPlannedTrajectorySegment())
+	}
+}
+
+func (c *grpcClient) GetLoggingMode(ctx context.Context) (loggingmodepb.LoggingMode, error) {
+	ctx = c.addOutgoingMetadata(ctx)
+	resp, err := c.client.GetLoggingMode(ctx, &pb.GetLoggingModeRequest{})
+	if err != nil {
+		return loggingmodepb.LoggingMode_LOGGING_MODE_UNSPECIFIED, err
+	}
+	return resp.GetLoggingMode(), nil
+}
+
+func (c *grpcClient) SetLoggingMode(ctx context.Context, mode loggingmodepb.LoggingMode) error {
+	ctx = c.addOutgoingMetadata(ctx)
+	_, err := c.client.SetLoggingMode(ctx, &pb.SetLoggingModeRequest{LoggingMode: mode})
+	return err
+}
+
+func (c *grpcClient) GetPayload(ctx context.Context, part string, payloadName string) (*robotpayloadpb.RobotPayload, error) {
+	ctx = c.addOutgoingMetadata(ctx)
+	resp, err := c.client.GetPayload(ctx, &pb.GetPayloadRequest{PartName: part, PayloadName: payloadName})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+func (c *grpcClient) SetPayload(ctx context.Context, part string, payloadName string, payload *robotpayloadpb.RobotPayload) error {
+	ctx = c.addOutgoingMetadata(ctx)
+	_, err := c.client.SetPayload(ctx, &pb.SetPayloadRequest{
+		PartName:    part,
+		PayloadName: payloadName,
+		Payload:     payload,
+	})
+	return err
+}
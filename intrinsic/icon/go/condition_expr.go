@@ -0,0 +1,492 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package icon
+
+// This file contains a parser and a printer for a textual syntax of
+// Conditions, e.g.
+//
+//	@arm.ArmPart.sensed_position[0] > 1.2 && !xfa.is_settled
+//
+// The syntax, from lowest to highest precedence, is:
+//
+//	a || b                  AnyOf(a, b)
+//	a && b                  AllOf(a, b)
+//	!a                      Not(a)
+//	(a)                     a
+//	true, false             AllOf(), AnyOf()
+//	x                       IsTrue(x)
+//	x == v, x != v          comparisons with bool, integer or float values
+//	x < v, x <= v, ...      comparisons with integer or float values
+//	x ~= v +/- e            ApproxEqual(x, v, e)
+//	x !~= v +/- e           ApproxNotEqual(x, v, e)
+//
+// Integer literals (e.g. 1) compare as int64 values and float literals (e.g.
+// 1.0) compare as double values. State variable names starting with "@" are
+// part state variable paths and must be valid according to
+// ValidateStateVariablePath.
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	conditiontypespb "intrinsic/icon/proto/v1/condition_types_go_proto"
+	typespb "intrinsic/icon/proto/v1/types_go_proto"
+)
+
+const approxTolerance = "+/-"
+
+// ConditionSyntaxError is returned by ParseCondition for an invalid condition
+// expression.
+type ConditionSyntaxError struct {
+	// Column is the 1-based column of the offending character in the expression.
+	Column int
+	Msg    string
+}
+
+func (e *ConditionSyntaxError) Error() string {
+	return fmt.Sprintf("invalid condition at column %d: %s", e.Column, e.Msg)
+}
+
+// ConditionParseOption is an option for ParseCondition.
+type ConditionParseOption func(*conditionParseOptions)
+
+type conditionParseOptions struct {
+	parts []string
+}
+
+// WithPartConfigs makes ParseCondition check that part state variable paths
+// refer to one of the given parts, e.g. as returned by Client.Config.
+func WithPartConfigs(parts []*typespb.PartConfig) ConditionParseOption {
+	return func(o *conditionParseOptions) {
+		o.parts = make([]string, 0, len(parts))
+		for _, p := range parts {
+			o.parts = append(o.parts, p.GetName())
+		}
+	}
+}
+
+type conditionTokenKind int
+
+const (
+	tokenEOF conditionTokenKind = iota
+	tokenName
+	tokenNumber
+	tokenTrue
+	tokenFalse
+	tokenLeftParen
+	tokenRightParen
+	tokenNot
+	tokenAnd
+	tokenOr
+	tokenComparison
+	tokenTolerance
+)
+
+type conditionToken struct {
+	kind conditionTokenKind
+	text string
+	// pos is the byte offset of the token in the expression.
+	pos int
+}
+
+// comparisonOps maps comparison operators to their operations.
+var comparisonOps = map[string]conditiontypespb.Comparison_OpEnum{
+	"==":  conditiontypespb.Comparison_EQUAL,
+	"!=":  conditiontypespb.Comparison_NOT_EQUAL,
+	"~=":  conditiontypespb.Comparison_APPROX_EQUAL,
+	"!~=": conditiontypespb.Comparison_APPROX_NOT_EQUAL,
+	"<":   conditiontypespb.Comparison_LESS_THAN,
+	"<=":  conditiontypespb.Comparison_LESS_THAN_OR_EQUAL,
+	">":   conditiontypespb.Comparison_GREATER_THAN,
+	">=":  conditiontypespb.Comparison_GREATER_THAN_OR_EQUAL,
+}
+
+// comparisonOpStrings maps comparison operations to their operators.
+var comparisonOpStrings = map[conditiontypespb.Comparison_OpEnum]string{}
+
+func init() {
+	for s, op := range comparisonOps {
+		comparisonOpStrings[op] = s
+	}
+}
+
+// punctuation lists the operator tokens, longest first.
+var punctuation = []struct {
+	text string
+	kind conditionTokenKind
+}{
+	{"!~=", tokenComparison},
+	{approxTolerance, tokenTolerance},
+	{"&&", tokenAnd},
+	{"||", tokenOr},
+	{"==", tokenComparison},
+	{"!=", tokenComparison},
+	{"~=", tokenComparison},
+	{"<=", tokenComparison},
+	{">=", tokenComparison},
+	{"<", tokenComparison},
+	{">", tokenComparison},
+	{"!", tokenNot},
+	{"(", tokenLeftParen},
+	{")", tokenRightParen},
+}
+
+func isNameStart(c byte) bool {
+	return c == '@' || c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || isDigit(c) || c == '.' || c == '[' || c == ']'
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+type conditionParser struct {
+	expr   string
+	tokens []conditionToken
+	next   int
+	opts   conditionParseOptions
+}
+
+func (p *conditionParser) errorf(pos int, format string, args ...any) error {
+	return &ConditionSyntaxError{
+		Column: utf8.RuneCountInString(p.expr[:pos]) + 1,
+		Msg:    fmt.Sprintf(format, args...),
+	}
+}
+
+// tokenize splits the expression into tokens, ending with a tokenEOF.
+func (p *conditionParser) tokenize() error {
+	s := p.expr
+	for i := 0; ; {
+		for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n' || s[i] == '\r') {
+			i++
+		}
+		if i == len(s) {
+			p.tokens = append(p.tokens, conditionToken{kind: tokenEOF, pos: i})
+			return nil
+		}
+		start := i
+		switch c := s[i]; {
+		case isDigit(c) || ((c == '-' || c == '.') && i+1 < len(s) && isDigit(s[i+1])):
+			if c == '-' {
+				i++
+			}
+			for i < len(s) && (isDigit(s[i]) || s[i] == '.' || s[i] == 'e' || s[i] == 'E' ||
+				((s[i] == '-' || s[i] == '+') && (s[i-1] == 'e' || s[i-1] == 'E'))) {
+				i++
+			}
+			p.tokens = append(p.tokens, conditionToken{kind: tokenNumber, text: s[start:i], pos: start})
+		case isNameStart(c):
+			for i < len(s) && isNameChar(s[i]) {
+				i++
+			}
+			kind := tokenName
+			switch s[start:i] {
+			case "true":
+				kind = tokenTrue
+			case "false":
+				kind = tokenFalse
+			}
+			p.tokens = append(p.tokens, conditionToken{kind: kind, text: s[start:i], pos: start})
+		default:
+			matched := false
+			for _, punct := range punctuation {
+				if strings.HasPrefix(s[i:], punct.text) {
+					p.tokens = append(p.tokens, conditionToken{kind: punct.kind, text: punct.text, pos: start})
+					i += len(punct.text)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				r, _ := utf8.DecodeRuneInString(s[i:])
+				return p.errorf(start, "unexpected character %q", r)
+			}
+		}
+	}
+}
+
+func (p *conditionParser) peek() conditionToken {
+	return p.tokens[p.next]
+}
+
+func (p *conditionParser) advance() conditionToken {
+	t := p.tokens[p.next]
+	if t.kind != tokenEOF {
+		p.next++
+	}
+	return t
+}
+
+func describeToken(t conditionToken) string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(t.text)
+}
+
+// parseOr parses a || b || ...
+func (p *conditionParser) parseOr() (*conditiontypespb.Condition, error) {
+	return p.parseConjunction(tokenOr, AnyOf, p.parseAnd)
+}
+
+// parseAnd parses a && b && ...
+func (p *conditionParser) parseAnd() (*conditiontypespb.Condition, error) {
+	return p.parseConjunction(tokenAnd, AllOf, p.parseUnary)
+}
+
+func (p *conditionParser) parseConjunction(op conditionTokenKind, combine func(...*conditiontypespb.Condition) *conditiontypespb.Condition, operand func() (*conditiontypespb.Condition, error)) (*conditiontypespb.Condition, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	conditions := []*conditiontypespb.Condition{first}
+	for p.peek().kind == op {
+		p.advance()
+		c, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, c)
+	}
+	if len(conditions) == 1 {
+		return first, nil
+	}
+	return combine(conditions...), nil
+}
+
+// parseUnary parses a negation, a parenthesized expression, a constant or a
+// comparison.
+func (p *conditionParser) parseUnary() (*conditiontypespb.Condition, error) {
+	switch t := p.advance(); t.kind {
+	case tokenNot:
+		c, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not(c), nil
+	case tokenLeftParen:
+		c, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.advance(); closing.kind != tokenRightParen {
+			return nil, p.errorf(closing.pos, "expected \")\" to match \"(\" at column %d, got %s", utf8.RuneCountInString(p.expr[:t.pos])+1, describeToken(closing))
+		}
+		return c, nil
+	case tokenTrue:
+		return AllOf(), nil
+	case tokenFalse:
+		return AnyOf(), nil
+	case tokenName:
+		return p.parseComparison(t)
+	default:
+		return nil, p.errorf(t.pos, "expected state variable, \"!\" or \"(\", got %s", describeToken(t))
+	}
+}
+
+// parseComparison parses the rest of a comparison of the state variable name.
+func (p *conditionParser) parseComparison(name conditionToken) (*conditiontypespb.Condition, error) {
+	if IsStateVariablePath(name.text) {
+		if err := ValidateStateVariablePath(name.text, p.opts.parts); err != nil {
+			return nil, p.errorf(name.pos, "%v", err)
+		}
+	}
+	if p.peek().kind != tokenComparison {
+		return IsTrue(name.text), nil
+	}
+	opToken := p.advance()
+	op := comparisonOps[opToken.text]
+	value := p.advance()
+	switch value.kind {
+	case tokenTrue, tokenFalse:
+		if op != conditiontypespb.Comparison_EQUAL && op != conditiontypespb.Comparison_NOT_EQUAL {
+			return nil, p.errorf(opToken.pos, "operator %q cannot compare with a bool", opToken.text)
+		}
+		return boolComparison(name.text, op, value.kind == tokenTrue), nil
+	case tokenNumber:
+	default:
+		return nil, p.errorf(value.pos, "expected value to compare with, got %s", describeToken(value))
+	}
+
+	if op == conditiontypespb.Comparison_APPROX_EQUAL || op == conditiontypespb.Comparison_APPROX_NOT_EQUAL {
+		v, err := p.parseFloat(value)
+		if err != nil {
+			return nil, err
+		}
+		if t := p.advance(); t.kind != tokenTolerance {
+			return nil, p.errorf(t.pos, "expected %q and a tolerance after %q, got %s", approxTolerance, opToken.text, describeToken(t))
+		}
+		epsToken := p.advance()
+		if epsToken.kind != tokenNumber {
+			return nil, p.errorf(epsToken.pos, "expected tolerance, got %s", describeToken(epsToken))
+		}
+		eps, err := p.parseFloat(epsToken)
+		if err != nil {
+			return nil, err
+		}
+		if eps < 0 {
+			return nil, p.errorf(epsToken.pos, "tolerance must not be negative, got %v", eps)
+		}
+		return floatComparison(name.text, op, v, eps), nil
+	}
+	if !strings.ContainsAny(value.text, ".eE") {
+		v, err := strconv.ParseInt(value.text, 10, 64)
+		if err != nil {
+			return nil, p.errorf(value.pos, "invalid integer %q", value.text)
+		}
+		return int64Comparison(name.text, op, v), nil
+	}
+	v, err := p.parseFloat(value)
+	if err != nil {
+		return nil, err
+	}
+	return exactFloatComparison(name.text, op, v), nil
+}
+
+func (p *conditionParser) parseFloat(t conditionToken) (float64, error) {
+	v, err := strconv.ParseFloat(t.text, 64)
+	if err != nil {
+		return 0, p.errorf(t.pos, "invalid number %q", t.text)
+	}
+	return v, nil
+}
+
+// ParseCondition parses a condition expression, such as
+// "@arm.ArmPart.sensed_position[0] > 1.2 && !xfa.is_settled". Errors are of
+// type *ConditionSyntaxError.
+func ParseCondition(expr string, opts ...ConditionParseOption) (*conditiontypespb.Condition, error) {
+	p := &conditionParser{expr: expr}
+	for _, opt := range opts {
+		opt(&p.opts)
+	}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+	c, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.errorf(t.pos, "unexpected %s after condition", describeToken(t))
+	}
+	return c, nil
+}
+
+// FormatCondition renders c in the syntax accepted by ParseCondition.
+// Conjunctions of a single condition are rendered as that condition.
+func FormatCondition(c *conditiontypespb.Condition) string {
+	switch c.GetCondition().(type) {
+	case *conditiontypespb.Condition_Comparison:
+		return formatComparison(c.GetComparison())
+	case *conditiontypespb.Condition_ConjunctionCondition:
+		conj := c.GetConjunctionCondition()
+		op := " && "
+		if conj.GetOperation() == conditiontypespb.ConjunctionCondition_ANY_OF {
+			op = " || "
+		}
+		switch len(conj.GetConditions()) {
+		case 0:
+			if conj.GetOperation() == conditiontypespb.ConjunctionCondition_ANY_OF {
+				return "false"
+			}
+			return "true"
+		case 1:
+			return FormatCondition(conj.GetConditions()[0])
+		}
+		operands := make([]string, 0, len(conj.GetConditions()))
+		for _, operand := range conj.GetConditions() {
+			s := FormatCondition(operand)
+			// Nested conjunctions of the same operation are parenthesized so that
+			// parsing the result reproduces the nesting.
+			if op, ok := conjunctionOp(operand); ok && !(op == conditiontypespb.ConjunctionCondition_ALL_OF && conj.GetOperation() == conditiontypespb.ConjunctionCondition_ANY_OF) {
+				s = "(" + s + ")"
+			}
+			operands = append(operands, s)
+		}
+		return strings.Join(operands, op)
+	case *conditiontypespb.Condition_NegatedCondition:
+		operand := c.GetNegatedCondition().GetCondition()
+		s := FormatCondition(operand)
+		if _, ok := conjunctionOp(operand); ok || (operand.GetComparison() != nil && !isBareStateVariable(operand.GetComparison())) {
+			s = "(" + s + ")"
+		}
+		return "!" + s
+	default:
+		return "<invalid condition>"
+	}
+}
+
+// conjunctionOp returns the operation of c if c is rendered with a binary
+// operator at its top level.
+func conjunctionOp(c *conditiontypespb.Condition) (conditiontypespb.ConjunctionCondition_OpEnum, bool) {
+	conditions := c.GetConjunctionCondition().GetConditions()
+	switch len(conditions) {
+	case 0:
+		return 0, false
+	case 1:
+		return conjunctionOp(conditions[0])
+	default:
+		return c.GetConjunctionCondition().GetOperation(), true
+	}
+}
+
+// isBareStateVariable returns whether cmp is rendered as just the state
+// variable name.
+func isBareStateVariable(cmp *conditiontypespb.Comparison) bool {
+	v, ok := cmp.GetValue().(*conditiontypespb.Comparison_BoolValue)
+	return ok && v.BoolValue && cmp.GetOperation() == conditiontypespb.Comparison_EQUAL
+}
+
+func formatComparison(cmp *conditiontypespb.Comparison) string {
+	if isBareStateVariable(cmp) {
+		return cmp.GetStateVariableName()
+	}
+	op, ok := comparisonOpStrings[cmp.GetOperation()]
+	if !ok {
+		return "<invalid comparison>"
+	}
+	var value string
+	switch v := cmp.GetValue().(type) {
+	case *conditiontypespb.Comparison_BoolValue:
+		value = strconv.FormatBool(v.BoolValue)
+	case *conditiontypespb.Comparison_Int64Value:
+		value = strconv.FormatInt(v.Int64Value, 10)
+	case *conditiontypespb.Comparison_DoubleValue:
+		value = formatDouble(v.DoubleValue)
+	default:
+		return "<invalid comparison>"
+	}
+	s := fmt.Sprintf("%s %s %s", cmp.GetStateVariableName(), op, value)
+	if cmp.GetOperation() == conditiontypespb.Comparison_APPROX_EQUAL || cmp.GetOperation() == conditiontypespb.Comparison_APPROX_NOT_EQUAL {
+		s += fmt.Sprintf(" %s %s", approxTolerance, formatDouble(cmp.GetMaxAbsError()))
+	}
+	return s
+}
+
+// formatDouble renders v such that ParseCondition reads it as a float.
+func formatDouble(v float64) string {
+	s := strconv.FormatFloat(v, 'g', -1, 64)
+	if !math.IsInf(v, 0) && !math.IsNaN(v) && !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package icon
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	conditiontypespb "intrinsic/icon/proto/v1/condition_types_go_proto"
+	typespb "intrinsic/icon/proto/v1/types_go_proto"
+)
+
+func TestParseCondition(t *testing.T) {
+	tests := []struct {
+		expr string
+		want *conditiontypespb.Condition
+		// formatted is the expected FormatCondition output, if it differs from expr.
+		formatted string
+	}{
+		{expr: "xfa.is_settled", want: IsTrue("xfa.is_settled")},
+		{expr: "!xfa.is_settled", want: Not(IsTrue("xfa.is_settled"))},
+		{expr: "closed == false", want: IsFalse("closed")},
+		{expr: "count != 3", want: NotEqualInt64("count", 3)},
+		{expr: "count >= -2", want: GreaterThanOrEqualToInt64("count", -2)},
+		{expr: "distance < 1.5", want: LessThan("distance", 1.5)},
+		{expr: "distance <= 1e-06", want: LessThanOrEqual("distance", 1e-6)},
+		{expr: "distance>1.", want: GreaterThan("distance", 1), formatted: "distance > 1.0"},
+		{expr: "pos ~= 0.5 +/- 0.01", want: ApproxEqual("pos", 0.5, 0.01)},
+		{expr: "pos !~= 1 +/- 0", want: ApproxNotEqual("pos", 1, 0), formatted: "pos !~= 1.0 +/- 0.0"},
+		{expr: "true", want: AllOf()},
+		{expr: "false", want: AnyOf()},
+		{
+			expr: "@arm.ArmPart.sensed_position[0] > 1.2 && !@gripper.GripperPart.sensed_state",
+			want: AllOf(GreaterThan(ArmSensedPosition("arm", 0), 1.2), Not(IsTrue(GripperSensedState("gripper")))),
+		},
+		{
+			expr: "a || b && c || !(d && e)",
+			want: AnyOf(IsTrue("a"), AllOf(IsTrue("b"), IsTrue("c")), Not(AllOf(IsTrue("d"), IsTrue("e")))),
+		},
+		{
+			expr: "(a || b) && (c && d)",
+			want: AllOf(AnyOf(IsTrue("a"), IsTrue("b")), AllOf(IsTrue("c"), IsTrue("d"))),
+		},
+		{expr: "!(x > 1)", want: Not(GreaterThanInt64("x", 1))},
+		{expr: "!!((a))", want: Not(Not(IsTrue("a"))), formatted: "!!a"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			got, err := ParseCondition(tc.expr)
+			if err != nil {
+				t.Fatalf("ParseCondition(%q) failed: %v", tc.expr, err)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ParseCondition(%q) returned unexpected condition (-want +got):\n%s", tc.expr, diff)
+			}
+			wantFormatted := tc.formatted
+			if wantFormatted == "" {
+				wantFormatted = tc.expr
+			}
+			if formatted := FormatCondition(got); formatted != wantFormatted {
+				t.Errorf("FormatCondition(%v) = %q, want %q", got, formatted, wantFormatted)
+			}
+		})
+	}
+}
+
+func TestParseConditionErrors(t *testing.T) {
+	parts := WithPartConfigs([]*typespb.PartConfig{{Name: "arm"}})
+	tests := []struct {
+		expr       string
+		opts       []ConditionParseOption
+		wantColumn int
+	}{
+		{expr: "", wantColumn: 1},
+		{expr: "a &&", wantColumn: 5},
+		{expr: "a # b", wantColumn: 3},
+		{expr: "(a || b", wantColumn: 8},
+		{expr: "a b", wantColumn: 3},
+		{expr: "a < true", wantColumn: 3},
+		{expr: "a ~= 1.0", wantColumn: 9},
+		{expr: "a ~= 1.0 +/- -1", wantColumn: 14},
+		{expr: "a == 99999999999999999999", wantColumn: 6},
+		{expr: "x && @arm.ArmPart.sensed_positon[0]", wantColumn: 6},
+		{expr: "@arm.ArmPart.base_twist_tip_sensed[6] > 0.0", wantColumn: 1},
+		{expr: "@arm.ArmPart.sensed_position > 0.0", wantColumn: 1},
+		{expr: "@Safety.enable_button", wantColumn: 1},
+		{expr: "@io.ADIOPart.di.block > 0", wantColumn: 1},
+		{expr: "a || @xfa.ArmPart.current_control_mode == 1", opts: []ConditionParseOption{parts}, wantColumn: 6},
+	}
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			_, err := ParseCondition(tc.expr, tc.opts...)
+			var syntaxErr *ConditionSyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("ParseCondition(%q) returned error %v, want a ConditionSyntaxError", tc.expr, err)
+			}
+			if syntaxErr.Column != tc.wantColumn {
+				t.Errorf("ParseCondition(%q) returned error at column %d, want %d: %v", tc.expr, syntaxErr.Column, tc.wantColumn, err)
+			}
+		})
+	}
+}
+
+func TestValidateStateVariablePath(t *testing.T) {
+	parts := []string{"arm", "ft", "io"}
+	for _, path := range []string{
+		ArmSensedPosition("arm", 3),
+		ArmBaseTwistTipSensed("arm", TwistRZ),
+		ArmCurrentControlMode("arm"),
+		FTWrenchAtTip("ft", WrenchZ),
+		FTWrenchStabilityIndex("ft"),
+		ADIODigitalInput("io", "block", 2),
+		ADIOAnalogOutput("io", "block", 0),
+		SafetyEnableButtonStatus(),
+	} {
+		if err := ValidateStateVariablePath(path, parts); err != nil {
+			t.Errorf("ValidateStateVariablePath(%q) failed: %v", path, err)
+		}
+	}
+	if err := ValidateStateVariablePath(GripperSensedState("gripper"), parts); err == nil {
+		t.Errorf("ValidateStateVariablePath() of a path for an unknown part succeeded, want error")
+	}
+	if err := ValidateStateVariablePath(GripperSensedState("gripper"), nil); err != nil {
+		t.Errorf("ValidateStateVariablePath() without parts failed: %v", err)
+	}
+}
@@ -26,3 +26,28 @@ type ReactionEvent struct {
 }
 
 func (*ReactionEvent) isEvent() {}
+
+// SessionLostEvent signifies that a supervised session lost its connection to
+// the server, see StartSupervisedSession. The server stops all actions of a
+// lost session and its parts are no longer claimed.
+type SessionLostEvent struct {
+	// Err is the error that ended the session's streams.
+	Err error
+	// Reopening is true if the session tries to re-open itself. If it is
+	// false, NextEvent returns ErrSessionLost after this event.
+	Reopening bool
+}
+
+func (*SessionLostEvent) isEvent() {}
+
+// SessionRestoredEvent signifies that a supervised session was re-opened after
+// it was lost. Its actions and reactions exist on the server again, but none of
+// its actions is active until the caller starts one.
+type SessionRestoredEvent struct {
+	// PreviousID is the ID of the lost session on the server.
+	PreviousID int64
+	// ID is the new ID of the session on the server.
+	ID int64
+}
+
+func (*SessionRestoredEvent) isEvent() {}
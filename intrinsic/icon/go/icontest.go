@@ -0,0 +1,1348 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package icontest provides an in-process fake of the ICON API, so that code
+// using an icon.Client can be tested hermetically.
+//
+// The fake does not run a control loop. Instead, tests script the parts and
+// action signatures that the server offers and set the values of state
+// variables. Every change evaluates the reactions of the running actions, and
+// reactions whose condition became true fire like on a real server: they
+// start actions and are reported to WatchReactions.
+//
+//	srv := icontest.NewServer(icontest.WithParts(arm), icontest.WithActionSignatures(sig))
+//	defer srv.Close()
+//	client, err := srv.NewClient(ctx)
+package icontest
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	"intrinsic/icon/go/icon"
+	"intrinsic/icon/go/intsequence"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	jointspacepb "intrinsic/icon/proto/joint_space_go_proto"
+	loggingmodepb "intrinsic/icon/proto/logging_mode_go_proto"
+	streamingoutputpb "intrinsic/icon/proto/streaming_output_go_proto"
+	conditiontypespb "intrinsic/icon/proto/v1/condition_types_go_proto"
+	grpcpb "intrinsic/icon/proto/v1/service_go_proto"
+	pb "intrinsic/icon/proto/v1/service_go_proto"
+	typespb "intrinsic/icon/proto/v1/types_go_proto"
+	robotpayloadpb "intrinsic/world/proto/robot_payload_go_proto"
+
+	statuspb "google.golang.org/genproto/googleapis/rpc/status"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	epb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	bufSize = 1024 * 1024
+	// maxReactionRounds bounds the number of times reactions are evaluated after
+	// a change, in case reactions keep starting each other.
+	maxReactionRounds = 100
+)
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithParts adds parts to the server. Actions can only use parts that offer
+// the feature interfaces required by the action's part slots.
+func WithParts(parts ...*typespb.PartConfig) Option {
+	return func(s *Server) {
+		s.parts = append(s.parts, parts...)
+	}
+}
+
+// WithActionSignatures adds action types to the server.
+func WithActionSignatures(signatures ...*typespb.ActionSignature) Option {
+	return func(s *Server) {
+		s.signatures = append(s.signatures, signatures...)
+	}
+}
+
+// WithServerConfig sets the server config that GetConfig returns.
+func WithServerConfig(config *typespb.ServerConfig) Option {
+	return func(s *Server) {
+		s.serverConfig = config
+	}
+}
+
+type streamKey struct {
+	action int64
+	field  string
+}
+
+type payloadKey struct {
+	part string
+	name string
+}
+
+type reactionState struct {
+	proto *typespb.Reaction
+	// wasTrue is the value of the condition when it was last evaluated.
+	// Reactions fire when their condition changes from false to true.
+	wasTrue bool
+	// done is set when a fire_once reaction has fired.
+	done bool
+}
+
+type session struct {
+	id        int64
+	parts     []string
+	actions   map[int64]*typespb.ActionInstance
+	reactions map[int64]*reactionState
+	active    map[int64]bool
+	// stateVariables holds the state variables of each action.
+	stateVariables map[int64]map[string]any
+	outputs        map[int64]*streamingoutputpb.StreamingOutput
+	trajectories   map[int64][]*jointspacepb.JointTrajectoryPVA
+	written        map[streamKey][]*anypb.Any
+	// events are the reactions that fired but were not sent to WatchReactions
+	// yet.
+	events   []*pb.WatchReactionsResponse
+	watching bool
+	ended    bool
+	// aborted is closed when the server ends the session.
+	aborted     chan struct{}
+	abortReason string
+}
+
+// Server is an in-memory implementation of the IconApi service, which serves
+// over a bufconn listener.
+type Server struct {
+	grpcpb.UnimplementedIconApiServer
+
+	start time.Time
+
+	mu sync.Mutex
+	// changed is closed and replaced whenever the state of a session changes.
+	changed           chan struct{}
+	parts             []*typespb.PartConfig
+	signatures        []*typespb.ActionSignature
+	serverConfig      *typespb.ServerConfig
+	stateVariables    map[string]any
+	sessions          map[int64]*session
+	sessionIDs        intsequence.Generator
+	operationalStatus *typespb.OperationalStatus
+	speedOverride     float64
+	loggingMode       loggingmodepb.LoggingMode
+	partProperties    map[string]*pb.PartPropertyValues
+	payloads          map[payloadKey]*robotpayloadpb.RobotPayload
+
+	listener   *bufconn.Listener
+	grpcServer *grpc.Server
+}
+
+// NewServer creates a Server and starts serving. Call Close when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		start:             time.Now(),
+		changed:           make(chan struct{}),
+		serverConfig:      &typespb.ServerConfig{Name: "icontest", FrequencyHz: 1000},
+		stateVariables:    make(map[string]any),
+		sessions:          make(map[int64]*session),
+		operationalStatus: &typespb.OperationalStatus{State: typespb.OperationalState_ENABLED},
+		speedOverride:     1,
+		loggingMode:       loggingmodepb.LoggingMode_LOGGING_MODE_FULL_RATE,
+		partProperties:    make(map[string]*pb.PartPropertyValues),
+		payloads:          make(map[payloadKey]*robotpayloadpb.RobotPayload),
+		listener:          bufconn.Listen(bufSize),
+		grpcServer:        grpc.NewServer(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	grpcpb.RegisterIconApiServer(s.grpcServer, s)
+	go s.grpcServer.Serve(s.listener)
+	return s
+}
+
+// Close stops the server and ends all sessions.
+func (s *Server) Close() {
+	s.grpcServer.Stop()
+	s.listener.Close()
+}
+
+// Dial opens a connection to the server.
+func (s *Server) Dial(ctx context.Context, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	opts = append([]grpc.DialOption{
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return s.listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}, opts...)
+	return grpc.DialContext(ctx, "bufnet", opts...)
+}
+
+// NewClient returns an icon.Client that is connected to the server. Closing
+// the client closes the connection.
+func (s *Server) NewClient(ctx context.Context, opts ...icon.ClientOption) (icon.Client, error) {
+	conn, err := s.Dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return icon.InitClientFromConn(conn, opts...), nil
+}
+
+// SetStateVariable sets a state variable that all reactions can refer to, such
+// as the state of a part. The value must be a bool, an int64 or a float64.
+// Comparisons with unset state variables are false.
+func (s *Server) SetStateVariable(name string, value any) error {
+	v, err := stateVariableValue(value)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stateVariables[name] = v
+	for _, sess := range s.sessions {
+		s.evaluateReactions(sess)
+	}
+	s.notify()
+	return nil
+}
+
+// SetActionStateVariable sets a state variable of an action, which the
+// reactions associated with that action can refer to. Action state variables
+// take precedence over the ones set with SetStateVariable.
+func (s *Server) SetActionStateVariable(sessionID int64, actionID icon.ActionID, name string, value any) error {
+	v, err := stateVariableValue(value)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, err := s.lookupAction(sessionID, int64(actionID))
+	if err != nil {
+		return err
+	}
+	if sess.stateVariables[int64(actionID)] == nil {
+		sess.stateVariables[int64(actionID)] = make(map[string]any)
+	}
+	sess.stateVariables[int64(actionID)][name] = v
+	s.evaluateReactions(sess)
+	s.notify()
+	return nil
+}
+
+// SetStreamingOutput sets the latest streaming output of an action.
+func (s *Server) SetStreamingOutput(sessionID int64, actionID icon.ActionID, msg proto.Message) error {
+	payload, err := anypb.New(msg)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, err := s.lookupAction(sessionID, int64(actionID))
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	sess.outputs[int64(actionID)] = &streamingoutputpb.StreamingOutput{
+		TimestampNs:          int64(now.Sub(s.start)),
+		WallClockTimestampNs: now.UnixNano(),
+		Payload:              payload,
+	}
+	s.notify()
+	return nil
+}
+
+// SetPlannedTrajectory sets the segments of the planned trajectory of an
+// action.
+func (s *Server) SetPlannedTrajectory(sessionID int64, actionID icon.ActionID, segments ...*jointspacepb.JointTrajectoryPVA) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, err := s.lookupAction(sessionID, int64(actionID))
+	if err != nil {
+		return err
+	}
+	sess.trajectories[int64(actionID)] = segments
+	return nil
+}
+
+// WrittenValues returns the values that were written to a streaming input of
+// an action, in the order in which they were written.
+func (s *Server) WrittenValues(sessionID int64, actionID icon.ActionID, field string) ([]*anypb.Any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, err := s.lookupAction(sessionID, int64(actionID))
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(sess.written[streamKey{action: int64(actionID), field: field}]), nil
+}
+
+// ActiveActions returns the ids of the active actions of a session in
+// ascending order.
+func (s *Server) ActiveActions(sessionID int64) ([]icon.ActionID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[sessionID]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "session %d does not exist", sessionID)
+	}
+	var ids []icon.ActionID
+	for _, id := range sortedKeys(sess.active) {
+		ids = append(ids, icon.ActionID(id))
+	}
+	return ids, nil
+}
+
+// Sessions returns the ids of all open sessions in ascending order.
+func (s *Server) Sessions() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sortedKeys(s.sessions)
+}
+
+// Fault puts the server into the faulted state and aborts all sessions, as
+// if hardware reported a fault.
+func (s *Server) Fault(reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.operationalStatus = &typespb.OperationalStatus{State: typespb.OperationalState_FAULTED, FaultReason: reason}
+	s.abortSessions(reason)
+}
+
+// AbortSessions aborts all sessions without changing the operational state,
+// as if the connections to the clients were lost.
+func (s *Server) AbortSessions(reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.abortSessions(reason)
+}
+
+func stateVariableValue(value any) (any, error) {
+	switch v := value.(type) {
+	case bool, int64, float64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	}
+	return nil, fmt.Errorf("unsupported state variable type %T, want bool, int64 or float64", value)
+}
+
+func sortedKeys[V any](m map[int64]V) []int64 {
+	keys := make([]int64, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// notify wakes up all calls that wait for a change. Requires s.mu.
+func (s *Server) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// lookupAction returns the session that contains the given action. Requires
+// s.mu.
+func (s *Server) lookupAction(sessionID int64, actionID int64) (*session, error) {
+	sess, ok := s.sessions[sessionID]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "session %d does not exist", sessionID)
+	}
+	if _, ok := sess.actions[actionID]; !ok {
+		return nil, status.Errorf(codes.NotFound, "action %d does not exist in session %d", actionID, sessionID)
+	}
+	return sess, nil
+}
+
+func (s *Server) signature(actionType string) *typespb.ActionSignature {
+	for _, sig := range s.signatures {
+		if sig.GetActionTypeName() == actionType {
+			return sig
+		}
+	}
+	return nil
+}
+
+func (s *Server) part(name string) *typespb.PartConfig {
+	for _, p := range s.parts {
+		if p.GetName() == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// fitsSlot reports whether the part offers all feature interfaces that the
+// slot requires.
+func fitsSlot(part *typespb.PartConfig, slot *typespb.ActionSignature_PartSlotInfo) bool {
+	for _, fi := range slot.GetRequiredFeatureInterfaces() {
+		if !slices.Contains(part.GetFeatureInterfaces(), fi) {
+			return false
+		}
+	}
+	return true
+}
+
+// slotParts checks that the parts assigned to the slots of an action exist
+// and are compatible with the action and returns them. Action types without
+// slots accept any part. Requires s.mu.
+func (s *Server) slotParts(sig *typespb.ActionSignature, partName string, slotPartMap map[string]string) ([]string, error) {
+	slots := sig.GetPartSlotInfos()
+	if partName != "" {
+		part := s.part(partName)
+		if part == nil {
+			return nil, status.Errorf(codes.NotFound, "part %q does not exist", partName)
+		}
+		if len(slots) > 1 {
+			return nil, status.Errorf(codes.InvalidArgument, "action type %q has %d slots and needs a slot part map", sig.GetActionTypeName(), len(slots))
+		}
+		for _, slot := range slots {
+			if !fitsSlot(part, slot) {
+				return nil, status.Errorf(codes.InvalidArgument, "part %q is not compatible with action type %q", partName, sig.GetActionTypeName())
+			}
+		}
+		return []string{partName}, nil
+	}
+	if len(slotPartMap) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "no parts assigned to action type %q", sig.GetActionTypeName())
+	}
+	var parts []string
+	for slotName, partName := range slotPartMap {
+		part := s.part(partName)
+		if part == nil {
+			return nil, status.Errorf(codes.NotFound, "part %q does not exist", partName)
+		}
+		if len(slots) > 0 {
+			slot, ok := slots[slotName]
+			if !ok {
+				return nil, status.Errorf(codes.InvalidArgument, "action type %q has no slot %q", sig.GetActionTypeName(), slotName)
+			}
+			if !fitsSlot(part, slot) {
+				return nil, status.Errorf(codes.InvalidArgument, "part %q is not compatible with slot %q of action type %q", partName, slotName, sig.GetActionTypeName())
+			}
+		}
+		parts = append(parts, partName)
+	}
+	slices.Sort(parts)
+	return parts, nil
+}
+
+// compatible reports whether a part fits into any slot of an action type.
+func compatible(sig *typespb.ActionSignature, part *typespb.PartConfig) bool {
+	if len(sig.GetPartSlotInfos()) == 0 {
+		return true
+	}
+	for _, slot := range sig.GetPartSlotInfos() {
+		if fitsSlot(part, slot) {
+			return true
+		}
+	}
+	return false
+}
+
+func actionParts(a *typespb.ActionInstance) []string {
+	if a.GetPartName() != "" {
+		return []string{a.GetPartName()}
+	}
+	var parts []string
+	for _, p := range a.GetSlotPartMap().GetSlotNameToPartName() {
+		parts = append(parts, p)
+	}
+	return parts
+}
+
+// toStatus converts err to a status proto, which is OK for a nil error.
+func toStatus(err error) *statuspb.Status {
+	if err == nil {
+		return &statuspb.Status{Code: int32(codes.OK)}
+	}
+	return status.Convert(err).Proto()
+}
+
+func compareOrdered[T cmp.Ordered](op conditiontypespb.Comparison_OpEnum, a, b T) bool {
+	switch op {
+	case conditiontypespb.Comparison_EQUAL:
+		return a == b
+	case conditiontypespb.Comparison_NOT_EQUAL:
+		return a != b
+	case conditiontypespb.Comparison_LESS_THAN_OR_EQUAL:
+		return a <= b
+	case conditiontypespb.Comparison_LESS_THAN:
+		return a < b
+	case conditiontypespb.Comparison_GREATER_THAN_OR_EQUAL:
+		return a >= b
+	case conditiontypespb.Comparison_GREATER_THAN:
+		return a > b
+	}
+	return false
+}
+
+func toFloat(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// compare evaluates a comparison of the state variable value v.
+func compare(c *conditiontypespb.Comparison, v any) bool {
+	op := c.GetOperation()
+	if want, ok := c.GetValue().(*conditiontypespb.Comparison_BoolValue); ok {
+		got, ok := v.(bool)
+		if !ok {
+			return false
+		}
+		switch op {
+		case conditiontypespb.Comparison_EQUAL:
+			return got == want.BoolValue
+		case conditiontypespb.Comparison_NOT_EQUAL:
+			return got != want.BoolValue
+		}
+		return false
+	}
+	if want, ok := c.GetValue().(*conditiontypespb.Comparison_Int64Value); ok {
+		if got, ok := v.(int64); ok && op != conditiontypespb.Comparison_APPROX_EQUAL && op != conditiontypespb.Comparison_APPROX_NOT_EQUAL {
+			return compareOrdered(op, got, want.Int64Value)
+		}
+	}
+	got, ok := toFloat(v)
+	if !ok {
+		return false
+	}
+	var want float64
+	switch value := c.GetValue().(type) {
+	case *conditiontypespb.Comparison_DoubleValue:
+		want = value.DoubleValue
+	case *conditiontypespb.Comparison_Int64Value:
+		want = float64(value.Int64Value)
+	default:
+		return false
+	}
+	switch op {
+	case conditiontypespb.Comparison_APPROX_EQUAL:
+		return math.Abs(got-want) <= c.GetMaxAbsError()
+	case conditiontypespb.Comparison_APPROX_NOT_EQUAL:
+		return math.Abs(got-want) > c.GetMaxAbsError()
+	}
+	return compareOrdered(op, got, want)
+}
+
+// evaluate evaluates a condition. lookup returns the value of a state
+// variable and whether it is set.
+func evaluate(c *conditiontypespb.Condition, lookup func(string) (any, bool)) bool {
+	switch c := c.GetCondition().(type) {
+	case *conditiontypespb.Condition_Comparison:
+		v, ok := lookup(c.Comparison.GetStateVariableName())
+		return ok && compare(c.Comparison, v)
+	case *conditiontypespb.Condition_ConjunctionCondition:
+		switch c.ConjunctionCondition.GetOperation() {
+		case conditiontypespb.ConjunctionCondition_ALL_OF:
+			for _, child := range c.ConjunctionCondition.GetConditions() {
+				if !evaluate(child, lookup) {
+					return false
+				}
+			}
+			return true
+		case conditiontypespb.ConjunctionCondition_ANY_OF:
+			for _, child := range c.ConjunctionCondition.GetConditions() {
+				if evaluate(child, lookup) {
+					return true
+				}
+			}
+		}
+		return false
+	case *conditiontypespb.Condition_NegatedCondition:
+		return !evaluate(c.NegatedCondition.GetCondition(), lookup)
+	}
+	return false
+}
+
+// activate starts an action and stops the active actions that use any of its
+// parts. Requires s.mu.
+func (s *Server) activate(sess *session, actionID int64) {
+	parts := actionParts(sess.actions[actionID])
+	for id := range sess.active {
+		for _, p := range actionParts(sess.actions[id]) {
+			if slices.Contains(parts, p) {
+				delete(sess.active, id)
+				break
+			}
+		}
+	}
+	sess.active[actionID] = true
+	// Reactions of a freshly started action fire if their condition already
+	// holds.
+	for _, r := range sess.reactions {
+		if r.proto.GetActionAssociation().GetActionInstanceId() == actionID {
+			r.wasTrue = false
+		}
+	}
+}
+
+// evaluateReactions fires all reactions of a session whose condition became
+// true. Requires s.mu.
+func (s *Server) evaluateReactions(sess *session) {
+	for range maxReactionRounds {
+		fired := false
+		for _, id := range sortedKeys(sess.reactions) {
+			r := sess.reactions[id]
+			if r.done {
+				continue
+			}
+			association := r.proto.GetActionAssociation()
+			if association != nil && !sess.active[association.GetActionInstanceId()] {
+				continue
+			}
+			actionID := association.GetActionInstanceId()
+			v := evaluate(r.proto.GetCondition(), func(name string) (any, bool) {
+				if v, ok := sess.stateVariables[actionID][name]; ok {
+					return v, true
+				}
+				v, ok := s.stateVariables[name]
+				return v, ok
+			})
+			risen := v && !r.wasTrue
+			r.wasTrue = v
+			if !risen {
+				continue
+			}
+			s.fire(sess, r)
+			fired = true
+		}
+		if !fired {
+			return
+		}
+	}
+}
+
+// fire applies the response of a reaction and queues its event. Requires s.mu.
+func (s *Server) fire(sess *session, r *reactionState) {
+	r.done = r.proto.GetFireOnce()
+	event := &typespb.ReactionEvent{ReactionId: r.proto.GetReactionInstanceId()}
+	association := r.proto.GetActionAssociation()
+	if association != nil {
+		event.PreviousActionInstanceId = proto.Int64(association.GetActionInstanceId())
+	}
+	if start := r.proto.GetResponse().GetStartActionInstanceId(); start != 0 {
+		if _, ok := sess.actions[start]; ok {
+			if association.GetStopAssociatedAction() {
+				delete(sess.active, association.GetActionInstanceId())
+			}
+			s.activate(sess, start)
+			event.CurrentActionInstanceId = proto.Int64(start)
+		}
+	} else if association != nil {
+		event.CurrentActionInstanceId = proto.Int64(association.GetActionInstanceId())
+	}
+	sess.events = append(sess.events, &pb.WatchReactionsResponse{
+		Timestamp:     timestamppb.Now(),
+		ReactionEvent: event,
+	})
+}
+
+// abortSessions ends all sessions with an error. Requires s.mu.
+func (s *Server) abortSessions(reason string) {
+	for id, sess := range s.sessions {
+		sess.abortReason = reason
+		close(sess.aborted)
+		s.removeSession(id)
+	}
+	s.notify()
+}
+
+// removeSession discards a session. Requires s.mu.
+func (s *Server) removeSession(id int64) {
+	if sess, ok := s.sessions[id]; ok {
+		sess.ended = true
+		delete(s.sessions, id)
+	}
+}
+
+// openSession creates a session that claims the given parts. Requires s.mu.
+func (s *Server) openSession(data *pb.OpenSessionRequest_InitialSessionData) (*session, error) {
+	if data == nil {
+		return nil, status.Error(codes.InvalidArgument, "the first request must contain initial session data")
+	}
+	parts := data.GetAllocateParts().GetPart()
+	for _, p := range parts {
+		if s.part(p) == nil {
+			return nil, status.Errorf(codes.NotFound, "part %q does not exist", p)
+		}
+		for _, other := range s.sessions {
+			if slices.Contains(other.parts, p) {
+				return nil, status.Errorf(codes.FailedPrecondition, "part %q is claimed by session %d", p, other.id)
+			}
+		}
+	}
+	sess := &session{
+		id:             s.sessionIDs.Next(),
+		parts:          slices.Clone(parts),
+		actions:        make(map[int64]*typespb.ActionInstance),
+		reactions:      make(map[int64]*reactionState),
+		active:         make(map[int64]bool),
+		stateVariables: make(map[int64]map[string]any),
+		outputs:        make(map[int64]*streamingoutputpb.StreamingOutput),
+		trajectories:   make(map[int64][]*jointspacepb.JointTrajectoryPVA),
+		written:        make(map[streamKey][]*anypb.Any),
+		aborted:        make(chan struct{}),
+	}
+	s.sessions[sess.id] = sess
+	return sess, nil
+}
+
+// addActionsAndReactions validates and adds actions and reactions to a
+// session. Requires s.mu.
+func (s *Server) addActionsAndReactions(sess *session, req *typespb.ActionsAndReactions) error {
+	actions := make(map[int64]*typespb.ActionInstance)
+	for _, a := range req.GetActionInstances() {
+		id := a.GetActionInstanceId()
+		if id == 0 {
+			return status.Error(codes.InvalidArgument, "action instance id must not be 0")
+		}
+		if _, ok := sess.actions[id]; ok {
+			return status.Errorf(codes.AlreadyExists, "action %d already exists", id)
+		}
+		if _, ok := actions[id]; ok {
+			return status.Errorf(codes.InvalidArgument, "action %d is added twice", id)
+		}
+		sig := s.signature(a.GetActionTypeName())
+		if sig == nil {
+			return status.Errorf(codes.NotFound, "action type %q does not exist", a.GetActionTypeName())
+		}
+		if want := sig.GetFixedParametersMessageType(); want != "" {
+			if got := string(a.GetFixedParameters().MessageName()); got != want {
+				return status.Errorf(codes.InvalidArgument, "action %d has fixed parameters of type %q, want %q", id, got, want)
+			}
+		}
+		parts, err := s.slotParts(sig, a.GetPartName(), a.GetSlotPartMap().GetSlotNameToPartName())
+		if err != nil {
+			return err
+		}
+		for _, p := range parts {
+			if !slices.Contains(sess.parts, p) {
+				return status.Errorf(codes.FailedPrecondition, "part %q is not claimed by session %d", p, sess.id)
+			}
+		}
+		actions[id] = a
+	}
+	exists := func(id int64) bool {
+		_, inSession := sess.actions[id]
+		_, inRequest := actions[id]
+		return inSession || inRequest
+	}
+	reactions := make(map[int64]*typespb.Reaction)
+	for _, r := range req.GetReactions() {
+		id := r.GetReactionInstanceId()
+		if id == 0 {
+			return status.Error(codes.InvalidArgument, "reaction instance id must not be 0")
+		}
+		if _, ok := sess.reactions[id]; ok {
+			return status.Errorf(codes.AlreadyExists, "reaction %d already exists", id)
+		}
+		if _, ok := reactions[id]; ok {
+			return status.Errorf(codes.InvalidArgument, "reaction %d is added twice", id)
+		}
+		if r.GetCondition() == nil {
+			return status.Errorf(codes.InvalidArgument, "reaction %d has no condition", id)
+		}
+		if a := r.GetActionAssociation(); a != nil && !exists(a.GetActionInstanceId()) {
+			return status.Errorf(codes.NotFound, "reaction %d is associated with unknown action %d", id, a.GetActionInstanceId())
+		}
+		if start := r.GetResponse().GetStartActionInstanceId(); start != 0 && !exists(start) {
+			return status.Errorf(codes.NotFound, "reaction %d starts unknown action %d", id, start)
+		}
+		reactions[id] = r
+	}
+	for id, a := range actions {
+		sess.actions[id] = a
+	}
+	for id, r := range reactions {
+		sess.reactions[id] = &reactionState{proto: r}
+	}
+	return nil
+}
+
+// removeActionsAndReactions removes actions, including their reactions, and
+// reactions from a session. Requires s.mu.
+func (s *Server) removeActionsAndReactions(sess *session, req *typespb.ActionAndReactionIds) error {
+	for _, id := range req.GetActionInstanceIds() {
+		if _, ok := sess.actions[id]; !ok {
+			return status.Errorf(codes.NotFound, "action %d does not exist", id)
+		}
+	}
+	for _, id := range req.GetReactionIds() {
+		if _, ok := sess.reactions[id]; !ok {
+			return status.Errorf(codes.NotFound, "reaction %d does not exist", id)
+		}
+	}
+	for _, id := range req.GetActionInstanceIds() {
+		delete(sess.actions, id)
+		delete(sess.active, id)
+		delete(sess.stateVariables, id)
+		delete(sess.outputs, id)
+		delete(sess.trajectories, id)
+		for rid, r := range sess.reactions {
+			if r.proto.GetActionAssociation().GetActionInstanceId() == id {
+				delete(sess.reactions, rid)
+			}
+		}
+	}
+	for _, id := range req.GetReactionIds() {
+		delete(sess.reactions, id)
+	}
+	return nil
+}
+
+// clearActionsAndReactions removes all actions and reactions from a session.
+// Requires s.mu.
+func (s *Server) clearActionsAndReactions(sess *session) {
+	sess.actions = make(map[int64]*typespb.ActionInstance)
+	sess.reactions = make(map[int64]*reactionState)
+	sess.active = make(map[int64]bool)
+	sess.stateVariables = make(map[int64]map[string]any)
+	sess.outputs = make(map[int64]*streamingoutputpb.StreamingOutput)
+	sess.trajectories = make(map[int64][]*jointspacepb.JointTrajectoryPVA)
+}
+
+// startActions starts actions of a session. Requires s.mu.
+func (s *Server) startActions(sess *session, req *pb.OpenSessionRequest_StartActionsRequestData) error {
+	if state := s.operationalStatus.GetState(); state != typespb.OperationalState_ENABLED {
+		return status.Errorf(codes.FailedPrecondition, "cannot start actions while the server is %v", state)
+	}
+	for _, id := range req.GetActionInstanceIds() {
+		if _, ok := sess.actions[id]; !ok {
+			return status.Errorf(codes.NotFound, "action %d does not exist", id)
+		}
+	}
+	if req.GetStopActiveActions() {
+		sess.active = make(map[int64]bool)
+	}
+	for _, id := range req.GetActionInstanceIds() {
+		s.activate(sess, id)
+	}
+	return nil
+}
+
+// handleSessionRequest applies a request to an open session.
+func (s *Server) handleSessionRequest(sess *session, req *pb.OpenSessionRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var err error
+	switch r := req.GetActionRequest().(type) {
+	case *pb.OpenSessionRequest_AddActionsAndReactions:
+		err = s.addActionsAndReactions(sess, r.AddActionsAndReactions)
+	case *pb.OpenSessionRequest_RemoveActionAndReactionIds:
+		err = s.removeActionsAndReactions(sess, r.RemoveActionAndReactionIds)
+	case *pb.OpenSessionRequest_ClearAllActionsReactions:
+		s.clearActionsAndReactions(sess)
+	}
+	if err != nil {
+		return err
+	}
+	if req.StartActionsRequest != nil {
+		if err := s.startActions(sess, req.GetStartActionsRequest()); err != nil {
+			return err
+		}
+	}
+	s.evaluateReactions(sess)
+	s.notify()
+	return nil
+}
+
+// OpenSession implements IconApiServer.
+func (s *Server) OpenSession(stream grpcpb.IconApi_OpenSessionServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	sess, err := s.openSession(req.GetInitialSessionData())
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.removeSession(sess.id)
+		s.notify()
+	}()
+
+	// The initial request may also contain actions to add and start.
+	if err := stream.Send(&pb.OpenSessionResponse{
+		Status:             toStatus(s.handleSessionRequest(sess, req)),
+		InitialSessionData: &pb.OpenSessionResponse_InitialSessionData{SessionId: sess.id},
+	}); err != nil {
+		return err
+	}
+
+	// Receive in the background, so that the server can abort the session
+	// while the client does not send anything.
+	requests := make(chan *pb.OpenSessionRequest)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case requests <- req:
+			case <-stream.Context().Done():
+				return
+			}
+		}
+	}()
+	for {
+		select {
+		case req := <-requests:
+			if err := stream.Send(&pb.OpenSessionResponse{Status: toStatus(s.handleSessionRequest(sess, req))}); err != nil {
+				return err
+			}
+		case err := <-recvErr:
+			if err == io.EOF {
+				// The client ended the session.
+				return nil
+			}
+			return err
+		case <-sess.aborted:
+			return status.Errorf(codes.Aborted, "session %d aborted: %s", sess.id, sess.abortReason)
+		}
+	}
+}
+
+// WatchReactions implements IconApiServer.
+func (s *Server) WatchReactions(req *pb.WatchReactionsRequest, stream grpcpb.IconApi_WatchReactionsServer) error {
+	s.mu.Lock()
+	sess, ok := s.sessions[req.GetSessionId()]
+	if !ok {
+		s.mu.Unlock()
+		return status.Errorf(codes.NotFound, "session %d does not exist", req.GetSessionId())
+	}
+	if sess.watching {
+		s.mu.Unlock()
+		return status.Errorf(codes.Unavailable, "reactions of session %d are already watched", sess.id)
+	}
+	sess.watching = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		sess.watching = false
+	}()
+
+	// An empty response signals that the watcher is ready.
+	if err := stream.Send(&pb.WatchReactionsResponse{}); err != nil {
+		return err
+	}
+	for {
+		s.mu.Lock()
+		events := sess.events
+		sess.events = nil
+		ended := sess.ended
+		changed := s.changed
+		s.mu.Unlock()
+		for _, e := range events {
+			if err := stream.Send(e); err != nil {
+				return err
+			}
+		}
+		if ended {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// addWriteStream checks that an action has the given streaming input and
+// returns the message type of its values. Requires s.mu.
+func (s *Server) addWriteStream(sessionID int64, req *pb.AddStreamRequest) (string, error) {
+	sess, err := s.lookupAction(sessionID, int64(req.GetActionId()))
+	if err != nil {
+		return "", err
+	}
+	sig := s.signature(sess.actions[int64(req.GetActionId())].GetActionTypeName())
+	for _, info := range sig.GetStreamingInputInfos() {
+		if info.GetParameterName() == req.GetFieldName() {
+			return info.GetValueMessageType(), nil
+		}
+	}
+	return "", status.Errorf(codes.InvalidArgument, "action type %q has no streaming input %q", sig.GetActionTypeName(), req.GetFieldName())
+}
+
+// write records a value written to a streaming input.
+func (s *Server) write(sessionID int64, key streamKey, valueType string, value *anypb.Any) error {
+	if value == nil {
+		return status.Error(codes.InvalidArgument, "missing value")
+	}
+	if valueType != "" && string(value.MessageName()) != valueType {
+		return status.Errorf(codes.InvalidArgument, "streaming input %q has type %q, got %q", key.field, valueType, value.MessageName())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, err := s.lookupAction(sessionID, key.action)
+	if err != nil {
+		return err
+	}
+	sess.written[key] = append(sess.written[key], value)
+	return nil
+}
+
+// OpenWriteStream implements IconApiServer.
+func (s *Server) OpenWriteStream(stream grpcpb.IconApi_OpenWriteStreamServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	add := req.GetAddWriteStream()
+	if add == nil {
+		return status.Error(codes.InvalidArgument, "the first request must add a write stream")
+	}
+	sessionID := req.GetSessionId()
+	s.mu.Lock()
+	valueType, err := s.addWriteStream(sessionID, add)
+	s.mu.Unlock()
+	if err := stream.Send(&pb.OpenWriteStreamResponse{
+		StreamOperationResponse: &pb.OpenWriteStreamResponse_AddStreamResponse{
+			AddStreamResponse: &pb.AddStreamResponse{Status: toStatus(err)},
+		},
+	}); err != nil {
+		return err
+	}
+	if err != nil {
+		return nil
+	}
+	key := streamKey{action: int64(add.GetActionId()), field: add.GetFieldName()}
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		err = s.write(sessionID, key, valueType, req.GetWriteValue().GetValue())
+		if err := stream.Send(&pb.OpenWriteStreamResponse{WriteValueResponse: toStatus(err)}); err != nil {
+			return err
+		}
+	}
+}
+
+// GetLatestStreamingOutput implements IconApiServer.
+func (s *Server) GetLatestStreamingOutput(ctx context.Context, req *pb.GetLatestStreamingOutputRequest) (*pb.GetLatestStreamingOutputResponse, error) {
+	for {
+		s.mu.Lock()
+		sess, err := s.lookupAction(req.GetSessionId(), int64(req.GetActionId()))
+		if err != nil {
+			s.mu.Unlock()
+			return nil, err
+		}
+		out := sess.outputs[int64(req.GetActionId())]
+		changed := s.changed
+		s.mu.Unlock()
+		if out != nil {
+			return &pb.GetLatestStreamingOutputResponse{Output: out}, nil
+		}
+		// Like ICON, block until the action writes its first output.
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
+
+// GetPlannedTrajectory implements IconApiServer.
+func (s *Server) GetPlannedTrajectory(req *pb.GetPlannedTrajectoryRequest, stream grpcpb.IconApi_GetPlannedTrajectoryServer) error {
+	s.mu.Lock()
+	sess, err := s.lookupAction(req.GetSessionId(), int64(req.GetActionId()))
+	var segments []*jointspacepb.JointTrajectoryPVA
+	if err == nil {
+		segments = sess.trajectories[int64(req.GetActionId())]
+	}
+	s.mu.Unlock()
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, status.Convert(err).Message())
+	}
+	if len(segments) == 0 {
+		return status.Errorf(codes.NotFound, "action %d has no planned trajectory", req.GetActionId())
+	}
+	for _, segment := range segments {
+		if err := stream.Send(&pb.GetPlannedTrajectoryResponse{PlannedTrajectorySegment: segment}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetActionSignatureByName implements IconApiServer.
+func (s *Server) GetActionSignatureByName(ctx context.Context, req *pb.GetActionSignatureByNameRequest) (*pb.GetActionSignatureByNameResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sig := s.signature(req.GetName())
+	if sig == nil {
+		return nil, status.Errorf(codes.NotFound, "action type %q does not exist", req.GetName())
+	}
+	return &pb.GetActionSignatureByNameResponse{ActionSignature: sig}, nil
+}
+
+// GetConfig implements IconApiServer.
+func (s *Server) GetConfig(ctx context.Context, req *pb.GetConfigRequest) (*pb.GetConfigResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &pb.GetConfigResponse{
+		PartConfigs:        s.parts,
+		ControlFrequencyHz: s.serverConfig.GetFrequencyHz(),
+		ServerConfig:       s.serverConfig,
+	}, nil
+}
+
+// GetStatus implements IconApiServer.
+func (s *Server) GetStatus(ctx context.Context, req *pb.GetStatusRequest) (*pb.GetStatusResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp := &pb.GetStatusResponse{
+		Sessions:             make(map[uint64]*pb.GetStatusResponse_SessionStatus),
+		CurrentSpeedOverride: s.speedOverride,
+	}
+	for id, sess := range s.sessions {
+		resp.Sessions[uint64(id)] = &pb.GetStatusResponse_SessionStatus{
+			PartGroup: &typespb.PartGroup{Parts: sess.parts},
+			ActionIds: sortedKeys(sess.active),
+		}
+	}
+	return resp, nil
+}
+
+// IsActionCompatible implements IconApiServer.
+func (s *Server) IsActionCompatible(ctx context.Context, req *pb.IsActionCompatibleRequest) (*pb.IsActionCompatibleResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sig := s.signature(req.GetActionTypeName())
+	if sig == nil {
+		return nil, status.Errorf(codes.NotFound, "action type %q does not exist", req.GetActionTypeName())
+	}
+	_, err := s.slotParts(sig, req.GetPartName(), req.GetSlotPartMap().GetSlotNameToPartName())
+	return &pb.IsActionCompatibleResponse{IsCompatible: err == nil}, nil
+}
+
+// ListActionSignatures implements IconApiServer.
+func (s *Server) ListActionSignatures(ctx context.Context, req *pb.ListActionSignaturesRequest) (*pb.ListActionSignaturesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &pb.ListActionSignaturesResponse{ActionSignatures: s.signatures}, nil
+}
+
+// ListCompatibleParts implements IconApiServer.
+func (s *Server) ListCompatibleParts(ctx context.Context, req *pb.ListCompatiblePartsRequest) (*pb.ListCompatiblePartsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var sigs []*typespb.ActionSignature
+	for _, name := range req.GetActionTypeNames() {
+		sig := s.signature(name)
+		if sig == nil {
+			return nil, status.Errorf(codes.NotFound, "action type %q does not exist", name)
+		}
+		sigs = append(sigs, sig)
+	}
+	resp := &pb.ListCompatiblePartsResponse{}
+	for _, p := range s.parts {
+		if !slices.ContainsFunc(sigs, func(sig *typespb.ActionSignature) bool { return !compatible(sig, p) }) {
+			resp.Parts = append(resp.Parts, p.GetName())
+		}
+	}
+	return resp, nil
+}
+
+// ListParts implements IconApiServer.
+func (s *Server) ListParts(ctx context.Context, req *pb.ListPartsRequest) (*pb.ListPartsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp := &pb.ListPartsResponse{}
+	for _, p := range s.parts {
+		resp.Parts = append(resp.Parts, p.GetName())
+	}
+	return resp, nil
+}
+
+// Enable implements IconApiServer.
+func (s *Server) Enable(ctx context.Context, req *pb.EnableRequest) (*pb.EnableResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.operationalStatus.GetState() == typespb.OperationalState_FAULTED {
+		return nil, status.Errorf(codes.FailedPrecondition, "server is faulted: %s", s.operationalStatus.GetFaultReason())
+	}
+	s.operationalStatus = &typespb.OperationalStatus{State: typespb.OperationalState_ENABLED}
+	return &pb.EnableResponse{}, nil
+}
+
+// Disable implements IconApiServer.
+func (s *Server) Disable(ctx context.Context, req *pb.DisableRequest) (*pb.DisableResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.operationalStatus.GetState() != typespb.OperationalState_FAULTED {
+		s.operationalStatus = &typespb.OperationalStatus{State: typespb.OperationalState_DISABLED}
+	}
+	s.abortSessions("server disabled")
+	return &pb.DisableResponse{}, nil
+}
+
+// ClearFaults implements IconApiServer.
+func (s *Server) ClearFaults(ctx context.Context, req *pb.ClearFaultsRequest) (*pb.ClearFaultsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.operationalStatus.GetState() == typespb.OperationalState_FAULTED {
+		s.operationalStatus = &typespb.OperationalStatus{State: typespb.OperationalState_ENABLED}
+	}
+	return &pb.ClearFaultsResponse{}, nil
+}
+
+// GetOperationalStatus implements IconApiServer. Cell control hardware is
+// always enabled.
+func (s *Server) GetOperationalStatus(ctx context.Context, req *pb.GetOperationalStatusRequest) (*pb.GetOperationalStatusResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &pb.GetOperationalStatusResponse{
+		OperationalStatus:         proto.Clone(s.operationalStatus).(*typespb.OperationalStatus),
+		CellControlHardwareStatus: &typespb.OperationalStatus{State: typespb.OperationalState_ENABLED},
+	}, nil
+}
+
+// RestartServer implements IconApiServer. It ends all sessions, clears faults
+// and enables the server.
+func (s *Server) RestartServer(ctx context.Context, req *epb.Empty) (*epb.Empty, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.abortSessions("server restarted")
+	s.operationalStatus = &typespb.OperationalStatus{State: typespb.OperationalState_ENABLED}
+	return &epb.Empty{}, nil
+}
+
+// SetSpeedOverride implements IconApiServer.
+func (s *Server) SetSpeedOverride(ctx context.Context, req *pb.SetSpeedOverrideRequest) (*pb.SetSpeedOverrideResponse, error) {
+	if f := req.GetOverrideFactor(); f < 0 || f > 1 {
+		return nil, status.Errorf(codes.InvalidArgument, "speed override factor %v is not in [0, 1]", f)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.speedOverride = req.GetOverrideFactor()
+	return &pb.SetSpeedOverrideResponse{}, nil
+}
+
+// GetSpeedOverride implements IconApiServer.
+func (s *Server) GetSpeedOverride(ctx context.Context, req *pb.GetSpeedOverrideRequest) (*pb.GetSpeedOverrideResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &pb.GetSpeedOverrideResponse{OverrideFactor: s.speedOverride}, nil
+}
+
+// SetLoggingMode implements IconApiServer.
+func (s *Server) SetLoggingMode(ctx context.Context, req *pb.SetLoggingModeRequest) (*pb.SetLoggingModeResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loggingMode = req.GetLoggingMode()
+	return &pb.SetLoggingModeResponse{}, nil
+}
+
+// GetLoggingMode implements IconApiServer.
+func (s *Server) GetLoggingMode(ctx context.Context, req *pb.GetLoggingModeRequest) (*pb.GetLoggingModeResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &pb.GetLoggingModeResponse{LoggingMode: s.loggingMode}, nil
+}
+
+// GetPartProperties implements IconApiServer.
+func (s *Server) GetPartProperties(ctx context.Context, req *pb.GetPartPropertiesRequest) (*pb.GetPartPropertiesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	resp := &pb.GetPartPropertiesResponse{
+		TimestampControl:         durationpb.New(now.Sub(s.start)),
+		TimestampWall:            timestamppb.New(now),
+		PartPropertiesByPartName: make(map[string]*pb.PartPropertyValues),
+	}
+	for part, values := range s.partProperties {
+		resp.PartPropertiesByPartName[part] = proto.Clone(values).(*pb.PartPropertyValues)
+	}
+	return resp, nil
+}
+
+// SetPartProperties implements IconApiServer.
+func (s *Server) SetPartProperties(ctx context.Context, req *pb.SetPartPropertiesRequest) (*pb.SetPartPropertiesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for part := range req.GetPartPropertiesByPartName() {
+		if s.part(part) == nil {
+			return nil, status.Errorf(codes.NotFound, "part %q does not exist", part)
+		}
+	}
+	for part, values := range req.GetPartPropertiesByPartName() {
+		if s.partProperties[part] == nil {
+			s.partProperties[part] = &pb.PartPropertyValues{PropertyValuesByName: make(map[string]*pb.PartPropertyValue)}
+		}
+		for name, v := range values.GetPropertyValuesByName() {
+			s.partProperties[part].PropertyValuesByName[name] = v
+		}
+	}
+	return &pb.SetPartPropertiesResponse{}, nil
+}
+
+// SetPayload implements IconApiServer.
+func (s *Server) SetPayload(ctx context.Context, req *pb.SetPayloadRequest) (*pb.SetPayloadResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.part(req.GetPartName()) == nil {
+		return nil, status.Errorf(codes.NotFound, "part %q does not exist", req.GetPartName())
+	}
+	if req.GetPayload() == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing payload")
+	}
+	s.payloads[payloadKey{part: req.GetPartName(), name: req.GetPayloadName()}] = req.GetPayload()
+	return &pb.SetPayloadResponse{}, nil
+}
+
+// GetPayload implements IconApiServer.
+func (s *Server) GetPayload(ctx context.Context, req *pb.GetPayloadRequest) (*pb.GetPayloadResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.part(req.GetPartName()) == nil {
+		return nil, status.Errorf(codes.NotFound, "part %q does not exist", req.GetPartName())
+	}
+	payload, ok := s.payloads[payloadKey{part: req.GetPartName(), name: req.GetPayloadName()}]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "part %q has no payload %q", req.GetPartName(), req.GetPayloadName())
+	}
+	return &pb.GetPayloadResponse{Payload: payload}, nil
+}
@@ -0,0 +1,435 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package icontest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"intrinsic/icon/go/icon"
+	"intrinsic/icon/go/sessionutil"
+
+	conditiontypespb "intrinsic/icon/proto/v1/condition_types_go_proto"
+	typespb "intrinsic/icon/proto/v1/types_go_proto"
+
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	testPart       = "arm"
+	testActionType = "xfa.test_move"
+)
+
+func newTestServer(t *testing.T) (*Server, icon.Client) {
+	t.Helper()
+	srv := NewServer(
+		WithParts(
+			&typespb.PartConfig{Name: testPart, FeatureInterfaces: []typespb.FeatureInterfaceTypes{typespb.FeatureInterfaceTypes_FEATURE_INTERFACE_JOINT_POSITION}},
+			&typespb.PartConfig{Name: "gripper"},
+		),
+		WithActionSignatures(&typespb.ActionSignature{
+			ActionTypeName: testActionType,
+			PartSlotInfos: map[string]*typespb.ActionSignature_PartSlotInfo{
+				"arm": {RequiredFeatureInterfaces: []typespb.FeatureInterfaceTypes{typespb.FeatureInterfaceTypes_FEATURE_INTERFACE_JOINT_POSITION}},
+			},
+			StreamingInputInfos: []*typespb.ActionSignature_ParameterInfo{
+				{ParameterName: "target", ValueMessageType: "google.protobuf.DoubleValue"},
+			},
+		}),
+	)
+	t.Cleanup(srv.Close)
+	client, err := srv.NewClient(context.Background())
+	if err != nil {
+		t.Fatalf("NewClient() failed: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return srv, client
+}
+
+func startTestSession(t *testing.T, client icon.Client) *icon.Session {
+	t.Helper()
+	session, err := client.StartSession(context.Background(), []string{testPart}, nil)
+	if err != nil {
+		t.Fatalf("StartSession() failed: %v", err)
+	}
+	t.Cleanup(func() { session.End() })
+	return session
+}
+
+func waitForEventSignal(t *testing.T, session *icon.Session, es icon.EventSignal) {
+	t.Helper()
+	cancel := make(chan struct{})
+	timer := time.AfterFunc(5*time.Second, func() { close(cancel) })
+	defer timer.Stop()
+	if err := sessionutil.WaitForEventSignal(session, es, cancel); err != nil {
+		t.Fatalf("WaitForEventSignal() failed: %v", err)
+	}
+}
+
+func TestReactions(t *testing.T) {
+	srv, client := newTestServer(t)
+	session := startTestSession(t, client)
+
+	first := session.MakeActionHandle()
+	second := session.MakeActionHandle()
+	settled := session.MakeEventSignal()
+	done := session.MakeEventSignal()
+	if _, err := session.AddActions(
+		&icon.ActionDescription{
+			Handle:     first,
+			ActionType: testActionType,
+			SlotData:   icon.FromPartName(testPart),
+			Reactions: []*icon.Reaction{
+				icon.NewReaction(icon.IsTrue("xfa.is_settled"), icon.StartActionInRealTime(second), icon.EmitEventSignal(settled)),
+			},
+		},
+		&icon.ActionDescription{
+			Handle:     second,
+			ActionType: testActionType,
+			SlotData:   icon.FromSlotPartMap(map[string]string{"arm": testPart}),
+			Reactions: []*icon.Reaction{
+				icon.NewReaction(icon.AllOf(icon.GreaterThan("distance", 0.5), icon.Not(icon.IsTrue("stopped"))), icon.EmitEventSignal(done)),
+			},
+		},
+	); err != nil {
+		t.Fatalf("AddActions() failed: %v", err)
+	}
+	if err := session.StartAction(first); err != nil {
+		t.Fatalf("StartAction() failed: %v", err)
+	}
+
+	if err := srv.SetActionStateVariable(session.ID(), first.ID(), "xfa.is_settled", true); err != nil {
+		t.Fatalf("SetActionStateVariable() failed: %v", err)
+	}
+	waitForEventSignal(t, session, settled)
+	active, err := srv.ActiveActions(session.ID())
+	if err != nil {
+		t.Fatalf("ActiveActions() failed: %v", err)
+	}
+	if diff := cmp.Diff([]icon.ActionID{second.ID()}, active); diff != "" {
+		t.Errorf("ActiveActions() returned unexpected actions (-want +got):\n%s", diff)
+	}
+
+	if err := srv.SetStateVariable("stopped", false); err != nil {
+		t.Fatalf("SetStateVariable() failed: %v", err)
+	}
+	if err := srv.SetActionStateVariable(session.ID(), second.ID(), "distance", 1.0); err != nil {
+		t.Fatalf("SetActionStateVariable() failed: %v", err)
+	}
+	waitForEventSignal(t, session, done)
+}
+
+func TestEvaluate(t *testing.T) {
+	state := map[string]any{
+		"flag":  true,
+		"count": int64(3),
+		"pos":   0.5,
+	}
+	lookup := func(name string) (any, bool) {
+		v, ok := state[name]
+		return v, ok
+	}
+	tests := []struct {
+		name string
+		c    *conditiontypespb.Condition
+		want bool
+	}{
+		{name: "IsTrue", c: icon.IsTrue("flag"), want: true},
+		{name: "IsFalse", c: icon.IsFalse("flag"), want: false},
+		{name: "EqualInt64", c: icon.EqualInt64("count", 3), want: true},
+		{name: "NotEqualInt64", c: icon.NotEqualInt64("count", 3), want: false},
+		{name: "GreaterThanInt64", c: icon.GreaterThanInt64("count", 2), want: true},
+		{name: "LessThanOrEqualToInt64", c: icon.LessThanOrEqualToInt64("count", 2), want: false},
+		{name: "ApproxEqual", c: icon.ApproxEqual("pos", 0.55, 0.1), want: true},
+		{name: "ApproxNotEqual", c: icon.ApproxNotEqual("pos", 0.55, 0.01), want: true},
+		{name: "LessThan", c: icon.LessThan("pos", 0.5), want: false},
+		{name: "GreaterThanOrEqual", c: icon.GreaterThanOrEqual("pos", 0.5), want: true},
+		{name: "int64 as double", c: icon.GreaterThan("count", 2.5), want: true},
+		{name: "bool as double", c: icon.GreaterThan("flag", 0), want: false},
+		{name: "unset", c: icon.IsTrue("unset"), want: false},
+		{name: "AllOf", c: icon.AllOf(icon.IsTrue("flag"), icon.LessThan("pos", 0)), want: false},
+		{name: "AnyOf", c: icon.AnyOf(icon.IsFalse("flag"), icon.LessThan("pos", 1)), want: true},
+		{name: "Not", c: icon.Not(icon.IsTrue("flag")), want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := evaluate(tc.c, lookup); got != tc.want {
+				t.Errorf("evaluate(%v) = %v, want %v", tc.c, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStreams(t *testing.T) {
+	srv, client := newTestServer(t)
+	session := startTestSession(t, client)
+	ctx := context.Background()
+
+	action, err := session.AddAction(&icon.ActionDescription{
+		Handle:     session.MakeActionHandle(),
+		ActionType: testActionType,
+		SlotData:   icon.FromPartName(testPart),
+	})
+	if err != nil {
+		t.Fatalf("AddAction() failed: %v", err)
+	}
+
+	ws, err := session.OpenWriteStream(ctx, action, "target")
+	if err != nil {
+		t.Fatalf("OpenWriteStream() failed: %v", err)
+	}
+	defer ws.Close()
+	if err := ws.Write(wrapperspb.Double(1.5)); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+	if err := ws.Write(wrapperspb.String("wrong type")); err == nil {
+		t.Errorf("Write() of a value with the wrong type succeeded, want error")
+	}
+	written, err := srv.WrittenValues(session.ID(), action.ID(), "target")
+	if err != nil {
+		t.Fatalf("WrittenValues() failed: %v", err)
+	}
+	if len(written) != 1 {
+		t.Fatalf("WrittenValues() returned %d values, want 1", len(written))
+	}
+	if _, err := session.OpenWriteStream(ctx, action, "unknown"); err == nil {
+		t.Errorf("OpenWriteStream() of an unknown input succeeded, want error")
+	}
+
+	if err := srv.SetStreamingOutput(session.ID(), action.ID(), wrapperspb.Double(2.5)); err != nil {
+		t.Fatalf("SetStreamingOutput() failed: %v", err)
+	}
+	rs, err := session.OpenReadStream(action)
+	if err != nil {
+		t.Fatalf("OpenReadStream() failed: %v", err)
+	}
+	got := &wrapperspb.DoubleValue{}
+	if _, err := rs.ReadUnpacked(ctx, got); err != nil {
+		t.Fatalf("ReadUnpacked() failed: %v", err)
+	}
+	if got.GetValue() != 2.5 {
+		t.Errorf("ReadUnpacked() = %v, want 2.5", got.GetValue())
+	}
+
+	typed := icon.NewTypedReadStream[*wrapperspb.DoubleValue](rs)
+	iterCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for v, err := range typed.All(iterCtx, time.Millisecond) {
+		if err != nil {
+			t.Fatalf("All() failed: %v", err)
+		}
+		if v.GetValue() != 2.5 {
+			t.Errorf("All() yielded %v, want 2.5", v.GetValue())
+		}
+		break
+	}
+}
+
+func TestWaitForEventSignals(t *testing.T) {
+	srv, client := newTestServer(t)
+	session := startTestSession(t, client)
+
+	first := session.MakeEventSignal()
+	second := session.MakeEventSignal()
+	third := session.MakeEventSignal()
+	action, err := session.AddAction(&icon.ActionDescription{
+		Handle:     session.MakeActionHandle(),
+		ActionType: testActionType,
+		SlotData:   icon.FromPartName(testPart),
+		Reactions: []*icon.Reaction{
+			icon.NewReaction(icon.IsTrue("first"), icon.EmitEventSignal(first)),
+			icon.NewReaction(icon.IsTrue("second"), icon.EmitEventSignal(second)),
+			icon.NewReaction(icon.IsTrue("third"), icon.EmitEventSignal(third)),
+		},
+	})
+	if err != nil {
+		t.Fatalf("AddAction() failed: %v", err)
+	}
+	if err := session.StartAction(action); err != nil {
+		t.Fatalf("StartAction() failed: %v", err)
+	}
+
+	for _, name := range []string{"second", "first"} {
+		if err := srv.SetActionStateVariable(session.ID(), action.ID(), name, true); err != nil {
+			t.Fatalf("SetActionStateVariable(%q) failed: %v", name, err)
+		}
+	}
+	if err := sessionutil.WaitForAllEventSignals(session, 5*time.Second, first, second); err != nil {
+		t.Fatalf("WaitForAllEventSignals() failed: %v", err)
+	}
+	if _, err := sessionutil.WaitForAnyEventSignal(session, 50*time.Millisecond, first, third); !errors.Is(err, sessionutil.ErrTimeout) {
+		t.Errorf("WaitForAnyEventSignal() without events returned %v, want %v", err, sessionutil.ErrTimeout)
+	}
+
+	if err := srv.SetActionStateVariable(session.ID(), action.ID(), "third", true); err != nil {
+		t.Fatalf("SetActionStateVariable() failed: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for event, err := range session.Events(ctx) {
+		if err != nil {
+			t.Fatalf("Events() failed: %v", err)
+		}
+		if e, ok := event.(*icon.ReactionEvent); ok && e.EventSignal == third {
+			break
+		}
+	}
+
+	subCtx, subCancel := context.WithCancel(context.Background())
+	events := session.SubscribeEvents(subCtx)
+	subCancel()
+	for r := range events {
+		if r.Err != nil && !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("SubscribeEvents() returned %v after cancellation, want %v", r.Err, context.Canceled)
+		}
+	}
+}
+
+func TestSessionErrors(t *testing.T) {
+	_, client := newTestServer(t)
+	ctx := context.Background()
+	startTestSession(t, client)
+
+	if _, err := client.StartSession(ctx, []string{testPart}, nil); err == nil {
+		t.Errorf("StartSession() for a claimed part succeeded, want error")
+	}
+	if _, err := client.StartSession(ctx, []string{"unknown"}, nil); err == nil {
+		t.Errorf("StartSession() for an unknown part succeeded, want error")
+	}
+
+	session, err := client.StartSession(ctx, []string{"gripper"}, nil)
+	if err != nil {
+		t.Fatalf("StartSession() failed: %v", err)
+	}
+	defer session.End()
+	if _, err := session.AddAction(&icon.ActionDescription{
+		Handle:     session.MakeActionHandle(),
+		ActionType: testActionType,
+		SlotData:   icon.FromPartName("gripper"),
+	}); err == nil {
+		t.Errorf("AddAction() with an incompatible part succeeded, want error")
+	}
+}
+
+func TestFaultAbortsSessions(t *testing.T) {
+	srv, client := newTestServer(t)
+	ctx := context.Background()
+	session := startTestSession(t, client)
+
+	srv.Fault("emergency stop")
+	if _, err := session.NextEvent(nil); err == nil {
+		t.Errorf("NextEvent() after a fault succeeded, want error")
+	}
+	status, err := client.OperationalStatus(ctx)
+	if err != nil {
+		t.Fatalf("OperationalStatus() failed: %v", err)
+	}
+	if status.GetState() != typespb.OperationalState_FAULTED {
+		t.Errorf("OperationalStatus() = %v, want FAULTED", status.GetState())
+	}
+	if err := client.Enable(ctx); err == nil {
+		t.Errorf("Enable() of a faulted server succeeded, want error")
+	}
+	if err := client.ClearFaults(ctx); err != nil {
+		t.Fatalf("ClearFaults() failed: %v", err)
+	}
+	if _, err := client.StartSession(ctx, []string{testPart}, nil); err != nil {
+		t.Errorf("StartSession() after ClearFaults() failed: %v", err)
+	}
+}
+
+func TestSupervisedSession(t *testing.T) {
+	srv, client := newTestServer(t)
+	ctx := context.Background()
+	session, err := icon.StartSupervisedSession(ctx, client, []string{testPart}, nil, icon.WithReopen(3, 10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("StartSupervisedSession() failed: %v", err)
+	}
+	defer session.End()
+	var recording bytes.Buffer
+	if err := session.StartRecording(&recording); err != nil {
+		t.Fatalf("StartRecording() failed: %v", err)
+	}
+	done := session.MakeEventSignal()
+	action, err := session.AddAction(&icon.ActionDescription{
+		Handle:     session.MakeActionHandle(),
+		ActionType: testActionType,
+		SlotData:   icon.FromPartName(testPart),
+		Reactions:  []*icon.Reaction{icon.NewReaction(icon.IsTrue("go"), icon.EmitEventSignal(done))},
+	})
+	if err != nil {
+		t.Fatalf("AddAction() failed: %v", err)
+	}
+	if err := session.StartAction(action); err != nil {
+		t.Fatalf("StartAction() failed: %v", err)
+	}
+	previousID := session.ID()
+
+	srv.AbortSessions("network outage")
+	if event, err := session.NextEvent(nil); err != nil {
+		t.Fatalf("NextEvent() failed: %v", err)
+	} else if lost, ok := event.(*icon.SessionLostEvent); !ok || !lost.Reopening {
+		t.Fatalf("NextEvent() = %#v, want a SessionLostEvent with Reopening", event)
+	}
+	event, err := session.NextEvent(nil)
+	if err != nil {
+		t.Fatalf("NextEvent() failed: %v", err)
+	}
+	restored, ok := event.(*icon.SessionRestoredEvent)
+	if !ok || restored.PreviousID != previousID || restored.ID != session.ID() || restored.ID == previousID {
+		t.Fatalf("NextEvent() = %#v, want a SessionRestoredEvent from session %d to %d", event, previousID, session.ID())
+	}
+	// The recording ended with the lost session, it would not be replayable.
+	if err := session.StopRecording(); !errors.Is(err, icon.ErrRecording) {
+		t.Errorf("StopRecording() after re-opening returned %v, want %v", err, icon.ErrRecording)
+	}
+	// Re-opening must not restart actions.
+	if active, err := srv.ActiveActions(session.ID()); err != nil || len(active) != 0 {
+		t.Errorf("ActiveActions() = %v, %v, want no active actions", active, err)
+	}
+	// The re-added action and its reaction work with the original handles.
+	if err := session.StartAction(action); err != nil {
+		t.Fatalf("StartAction() after re-opening failed: %v", err)
+	}
+	if err := srv.SetStateVariable("go", true); err != nil {
+		t.Fatalf("SetStateVariable() failed: %v", err)
+	}
+	waitForEventSignal(t, session, done)
+
+	// A disabled server is never enabled again to re-open the session.
+	if err := client.Disable(ctx, icon.AllHardware); err != nil {
+		t.Fatalf("Disable() failed: %v", err)
+	}
+	if event, err := session.NextEvent(nil); err != nil {
+		t.Fatalf("NextEvent() failed: %v", err)
+	} else if _, ok := event.(*icon.SessionLostEvent); !ok {
+		t.Fatalf("NextEvent() = %#v, want a SessionLostEvent", event)
+	}
+	if _, err := session.NextEvent(nil); !errors.Is(err, icon.ErrSessionLost) {
+		t.Errorf("NextEvent() returned error %v, want %v", err, icon.ErrSessionLost)
+	}
+	status, err := client.OperationalStatus(ctx)
+	if err != nil {
+		t.Fatalf("OperationalStatus() failed: %v", err)
+	}
+	if status.GetState() != typespb.OperationalState_DISABLED {
+		t.Errorf("OperationalStatus() = %v, want DISABLED", status.GetState())
+	}
+}
@@ -0,0 +1,532 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package icon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	jogpb "intrinsic/icon/proto/v1/jogging_service_go_proto"
+	worldrefspb "intrinsic/world/proto/object_world_refs_go_proto"
+)
+
+const (
+	// defaultHeartbeatInterval is used if the stop timeout of a part is unknown.
+	defaultHeartbeatInterval = 50 * time.Millisecond
+	// defaultDeadmanTimeout is the default time after which a jogging command
+	// that was not renewed is no longer sent to the server.
+	defaultDeadmanTimeout = 250 * time.Millisecond
+)
+
+var (
+	// ErrJoggerClosed occurs when a command is sent with a closed Jogger.
+	ErrJoggerClosed = errors.New("jogger already closed")
+	// ErrInvalidJoggingCommand occurs when a jogging command is invalid, e.g.,
+	// because it does not match the jogging mode or its velocity is out of range.
+	ErrInvalidJoggingCommand = errors.New("invalid jogging command")
+	// ErrJoggingRejected occurs when the server rejects a jogging session or
+	// command. Use errors.As with *JoggingRejectedError to get the details.
+	ErrJoggingRejected = errors.New("jogging rejected by server")
+)
+
+// JoggingRejectedError is the error returned when the server ends the jogging
+// stream with an error, e.g., because the part is held by another client or a
+// command is invalid for the part. It Is(ErrJoggingRejected).
+type JoggingRejectedError struct {
+	// Code is the gRPC status code returned by the server.
+	Code codes.Code
+	// Message is the error message returned by the server.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *JoggingRejectedError) Error() string {
+	return fmt.Sprintf("jogging rejected by server (%v): %s", e.Code, e.Message)
+}
+
+// Is returns true if target is ErrJoggingRejected.
+func (e *JoggingRejectedError) Is(target error) bool { return target == ErrJoggingRejected }
+
+// CartesianAxis is a degree of freedom for Cartesian jogging.
+type CartesianAxis int
+
+// Cartesian degrees of freedom. X, Y and Z are translations along the
+// respective axis, RX, RY and RZ are rotations about the respective axis.
+const (
+	X CartesianAxis = iota
+	Y
+	Z
+	RX
+	RY
+	RZ
+)
+
+// JointJogging returns the initial jogging data for joint jogging of a part.
+func JointJogging(part string) *jogpb.InitialJoggingData {
+	return &jogpb.InitialJoggingData{
+		PartName: part,
+		InitialJoggingSpec: &jogpb.InitialJoggingData_InitialJointJoggingSpec{
+			InitialJointJoggingSpec: &jogpb.InitialJoggingData_InitialJointJogging{},
+		},
+	}
+}
+
+// CartesianJoggingInStaticFrame returns the initial jogging data for Cartesian
+// jogging of a part about the given jogging frame in the orientation of the
+// given reference frame. Nil frames default to the robot tip and base frame,
+// respectively.
+func CartesianJoggingInStaticFrame(part string, joggingFrame, referenceFrame *worldrefspb.FrameReference) *jogpb.InitialJoggingData {
+	return &jogpb.InitialJoggingData{
+		PartName: part,
+		InitialJoggingSpec: &jogpb.InitialJoggingData_InitialCartesianJoggingSpec{
+			InitialCartesianJoggingSpec: &jogpb.InitialJoggingData_InitialCartesianJogging{
+				JoggingFrame: &jogpb.InitialJoggingData_InitialCartesianJogging_JogInStaticFrame{
+					JogInStaticFrame: &jogpb.JogInStaticFrame{
+						JoggingFrame:   joggingFrame,
+						ReferenceFrame: referenceFrame,
+					},
+				},
+			},
+		},
+	}
+}
+
+// CartesianJoggingInToolFrame returns the initial jogging data for Cartesian
+// jogging of a part in the coordinate frame of the given tool frame.
+func CartesianJoggingInToolFrame(part string, joggingFrame *worldrefspb.FrameReference) *jogpb.InitialJoggingData {
+	return &jogpb.InitialJoggingData{
+		PartName: part,
+		InitialJoggingSpec: &jogpb.InitialJoggingData_InitialCartesianJoggingSpec{
+			InitialCartesianJoggingSpec: &jogpb.InitialJoggingData_InitialCartesianJogging{
+				JoggingFrame: &jogpb.InitialJoggingData_InitialCartesianJogging_JogInToolFrame{
+					JogInToolFrame: &jogpb.JogInToolFrame{JoggingFrame: joggingFrame},
+				},
+			},
+		},
+	}
+}
+
+// JoggingClient provides access to the ICON jogging service.
+type JoggingClient struct {
+	client                    jogpb.JoggingServiceClient
+	serverInstanceHeaderValue string
+}
+
+// NewJoggingClient creates a JoggingClient from an existing gRPC connection to
+// the ICON server. Only the WithServerInstanceHeaderValue option is used.
+func NewJoggingClient(conn *grpc.ClientConn, opts ...ClientOption) *JoggingClient {
+	copts := clientOptions{}
+	for _, opt := range opts {
+		opt.apply(&copts)
+	}
+	return &JoggingClient{
+		client:                    jogpb.NewJoggingServiceClient(conn),
+		serverInstanceHeaderValue: copts.serverInstanceHeaderValue,
+	}
+}
+
+// AvailableParts lists the parts that support jogging and their capabilities.
+func (c *JoggingClient) AvailableParts(ctx context.Context) ([]*jogpb.PartJoggingInfo, error) {
+	ctx = SetResourceInstanceHeaderOutgoingMetadata(ctx, c.serverInstanceHeaderValue)
+	resp, err := c.client.GetAvailableParts(ctx, &jogpb.AvailablePartsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetParts(), nil
+}
+
+// joggerOptions configure a StartJogging call.
+type joggerOptions struct {
+	clientName        string
+	heartbeatInterval time.Duration
+	deadmanTimeout    time.Duration
+}
+
+// JoggerOption configures a Jogger.
+type JoggerOption func(*joggerOptions)
+
+// WithClientName sets the name under which the jogging client is shown as the
+// holder of the part.
+func WithClientName(name string) JoggerOption {
+	return func(o *joggerOptions) {
+		o.clientName = name
+	}
+}
+
+// WithHeartbeatInterval sets the interval at which the current jogging command
+// is repeated. It must be shorter than the stop timeout of the part. Defaults
+// to half of the stop timeout reported by the server.
+func WithHeartbeatInterval(d time.Duration) JoggerOption {
+	return func(o *joggerOptions) {
+		o.heartbeatInterval = d
+	}
+}
+
+// WithDeadmanTimeout sets the time for which a jogging command is repeated
+// after it was last given. If the caller does not renew the command within
+// this time (e.g., because the user released the jog button or the caller got
+// stuck), the Jogger stops the robot.
+func WithDeadmanTimeout(d time.Duration) JoggerOption {
+	return func(o *joggerOptions) {
+		o.deadmanTimeout = d
+	}
+}
+
+// Jogger manages a jogging session on the ICON server, which holds the jogged
+// part until the Jogger is closed.
+//
+// Jogging commands must be given repeatedly (e.g., while a jog button is
+// held), at least once per deadman timeout. In between, the Jogger repeats
+// the last command to the server in the background. The robot stops when
+// Stop is called, when commands are not renewed, or when the context passed
+// to StartJogging is canceled.
+//
+// Methods of a Jogger are safe for concurrent use.
+type Jogger struct {
+	stream    jogpb.JoggingService_JogRobotClient
+	cancel    context.CancelFunc
+	cartesian bool
+	opts      joggerOptions
+
+	mu        sync.Mutex
+	command   *jogpb.JoggingCommand
+	renewedAt time.Time
+	err       error
+	wake      chan struct{}
+	done      chan struct{}
+	wg        sync.WaitGroup
+}
+
+// stopTimeout returns the stop timeout of the given part or zero if unknown.
+func (c *JoggingClient) stopTimeout(ctx context.Context, part string) time.Duration {
+	parts, err := c.AvailableParts(ctx)
+	if err != nil {
+		log.WarningContextf(ctx, "Unable to get stop timeout of part %q: %v", part, err)
+		return 0
+	}
+	for _, p := range parts {
+		if p.GetPartName() == part {
+			return p.GetStopTimeout().AsDuration()
+		}
+	}
+	return 0
+}
+
+// StartJogging opens a jogging session with the given initial jogging data
+// (see JointJogging, CartesianJoggingInStaticFrame and
+// CartesianJoggingInToolFrame). The session ends when the Jogger is closed or
+// ctx is canceled.
+func (c *JoggingClient) StartJogging(ctx context.Context, initial *jogpb.InitialJoggingData, opts ...JoggerOption) (*Jogger, error) {
+	o := joggerOptions{deadmanTimeout: defaultDeadmanTimeout}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.heartbeatInterval <= 0 {
+		o.heartbeatInterval = defaultHeartbeatInterval
+		if t := c.stopTimeout(ctx, initial.GetPartName()); t > 0 {
+			o.heartbeatInterval = t / 2
+		}
+	}
+	if o.clientName != "" {
+		// Do not modify the caller's message, which may be reused.
+		initial = proto.Clone(initial).(*jogpb.InitialJoggingData)
+		initial.ClientName = o.clientName
+	}
+
+	ctx = SetResourceInstanceHeaderOutgoingMetadata(ctx, c.serverInstanceHeaderValue)
+	streamCtx, cancel := context.WithCancel(ctx)
+	stream, err := c.client.JogRobot(streamCtx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if err := stream.Send(&jogpb.JoggingRequest{
+		Request: &jogpb.JoggingRequest_InitialJoggingData{InitialJoggingData: initial},
+	}); err != nil {
+		if err == io.EOF {
+			// The stream was ended by the server, Recv returns the actual error.
+			_, err = stream.Recv()
+		}
+		cancel()
+		return nil, rejectionFromStatus(err)
+	}
+
+	j := &Jogger{
+		stream:    stream,
+		cancel:    cancel,
+		cartesian: initial.GetInitialCartesianJoggingSpec() != nil,
+		opts:      o,
+		wake:      make(chan struct{}, 1),
+		done:      make(chan struct{}),
+	}
+	j.wg.Add(2)
+	go j.receive(streamCtx)
+	go j.heartbeat(streamCtx)
+	return j, nil
+}
+
+// rejectionFromStatus converts a gRPC status error from the jogging stream to
+// a JoggingRejectedError. Cancellation is reported as is.
+func rejectionFromStatus(err error) error {
+	s, ok := status.FromError(err)
+	if !ok || s.Code() == codes.Canceled {
+		return err
+	}
+	return &JoggingRejectedError{Code: s.Code(), Message: s.Message()}
+}
+
+// finish records the error that ended the session unless one has already
+// been recorded.
+func (j *Jogger) finish(err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.err != nil {
+		return
+	}
+	j.err = err
+	j.command = nil
+	close(j.done)
+}
+
+// receive drains the responses of the server until the stream ends.
+func (j *Jogger) receive(ctx context.Context) {
+	defer j.wg.Done()
+	for {
+		if _, err := j.stream.Recv(); err != nil {
+			switch {
+			case ctx.Err() != nil:
+				err = ctx.Err()
+			case err == io.EOF:
+				err = ErrJoggerClosed
+			default:
+				err = rejectionFromStatus(err)
+			}
+			j.finish(err)
+			j.cancel()
+			return
+		}
+	}
+}
+
+// heartbeat sends the current command whenever it changes and repeats it at
+// the heartbeat interval until it expires. It wakes up when the deadman
+// timeout of the current command expires, even if that is before the next
+// heartbeat. It is the only goroutine that sends on the stream after
+// StartJogging returns.
+func (j *Jogger) heartbeat(ctx context.Context) {
+	defer j.wg.Done()
+	timer := time.NewTimer(j.opts.heartbeatInterval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			// The canceled stream ends the session on the server, which stops the
+			// robot.
+			j.finish(ctx.Err())
+			return
+		case <-j.done:
+			return
+		case <-timer.C:
+		case <-j.wake:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		}
+
+		wait := j.opts.heartbeatInterval
+		j.mu.Lock()
+		cmd := j.command
+		if cmd != nil {
+			if expiry := time.Until(j.renewedAt.Add(j.opts.deadmanTimeout)); expiry <= 0 {
+				// Send a final stop command and then stay silent until the next
+				// command.
+				cmd = stopCommand(cmd)
+				j.command = nil
+			} else if expiry < wait {
+				wait = expiry
+			}
+		}
+		j.mu.Unlock()
+		timer.Reset(wait)
+		if cmd == nil {
+			continue
+		}
+		if err := j.stream.Send(&jogpb.JoggingRequest{
+			Request: &jogpb.JoggingRequest_JoggingCommand{JoggingCommand: cmd},
+		}); err != nil {
+			// The actual error is returned by Recv.
+			log.WarningContextf(ctx, "Failed to send jogging command: %v", err)
+			return
+		}
+	}
+}
+
+// stopCommand returns a command with zero velocity in the same mode as cmd.
+func stopCommand(cmd *jogpb.JoggingCommand) *jogpb.JoggingCommand {
+	if joint := cmd.GetJointJoggingCommand(); joint != nil {
+		return &jogpb.JoggingCommand{
+			JoggingCommand: &jogpb.JoggingCommand_JointJoggingCommand{
+				JointJoggingCommand: &jogpb.JointJoggingCommand{JointIndex: joint.GetJointIndex()},
+			},
+		}
+	}
+	return cartesianCommand(X, 0)
+}
+
+func cartesianCommand(axis CartesianAxis, v float64) *jogpb.JoggingCommand {
+	velocity := &jogpb.CartesianNormalizedVelocity{}
+	switch axis {
+	case X:
+		velocity.Vector = &jogpb.CartesianNormalizedVelocity_X{X: v}
+	case Y:
+		velocity.Vector = &jogpb.CartesianNormalizedVelocity_Y{Y: v}
+	case Z:
+		velocity.Vector = &jogpb.CartesianNormalizedVelocity_Z{Z: v}
+	case RX:
+		velocity.Vector = &jogpb.CartesianNormalizedVelocity_Rx{Rx: v}
+	case RY:
+		velocity.Vector = &jogpb.CartesianNormalizedVelocity_Ry{Ry: v}
+	case RZ:
+		velocity.Vector = &jogpb.CartesianNormalizedVelocity_Rz{Rz: v}
+	}
+	return &jogpb.JoggingCommand{
+		JoggingCommand: &jogpb.JoggingCommand_CartesianJoggingCommand{
+			CartesianJoggingCommand: &jogpb.CartesianJoggingCommand{NormalizedVelocity: velocity},
+		},
+	}
+}
+
+// setCommand makes cmd the current command and wakes up the heartbeat to send
+// it immediately.
+func (j *Jogger) setCommand(cmd *jogpb.JoggingCommand) error {
+	j.mu.Lock()
+	if j.err != nil {
+		err := j.err
+		j.mu.Unlock()
+		return err
+	}
+	j.command = cmd
+	j.renewedAt = time.Now()
+	j.mu.Unlock()
+	select {
+	case j.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func checkNormalizedVelocity(v float64) error {
+	if v < -1 || v > 1 {
+		return fmt.Errorf("%w: normalized velocity %v is not in [-1, 1]", ErrInvalidJoggingCommand, v)
+	}
+	return nil
+}
+
+// JogJoint jogs the joint with the given index at the given normalized
+// velocity in [-1, 1]. The command must be renewed within the deadman timeout.
+// Returns the error that ended the session if it has ended.
+func (j *Jogger) JogJoint(jointIndex int, normalizedVelocity float64) error {
+	if j.cartesian {
+		return fmt.Errorf("%w: joint jogging command in Cartesian jogging session", ErrInvalidJoggingCommand)
+	}
+	if jointIndex < 0 {
+		return fmt.Errorf("%w: negative joint index %d", ErrInvalidJoggingCommand, jointIndex)
+	}
+	if err := checkNormalizedVelocity(normalizedVelocity); err != nil {
+		return err
+	}
+	return j.setCommand(&jogpb.JoggingCommand{
+		JoggingCommand: &jogpb.JoggingCommand_JointJoggingCommand{
+			JointJoggingCommand: &jogpb.JointJoggingCommand{
+				JointIndex:         int64(jointIndex),
+				NormalizedVelocity: normalizedVelocity,
+			},
+		},
+	})
+}
+
+// JogCartesian jogs along or about the given axis at the given normalized
+// velocity in [-1, 1]. The command must be renewed within the deadman timeout.
+// Returns the error that ended the session if it has ended.
+func (j *Jogger) JogCartesian(axis CartesianAxis, normalizedVelocity float64) error {
+	if !j.cartesian {
+		return fmt.Errorf("%w: Cartesian jogging command in joint jogging session", ErrInvalidJoggingCommand)
+	}
+	if axis < X || axis > RZ {
+		return fmt.Errorf("%w: unknown Cartesian axis %d", ErrInvalidJoggingCommand, axis)
+	}
+	if err := checkNormalizedVelocity(normalizedVelocity); err != nil {
+		return err
+	}
+	return j.setCommand(cartesianCommand(axis, normalizedVelocity))
+}
+
+// Stop stops the robot but keeps the jogging session open, so that jogging
+// can be resumed with the next command.
+func (j *Jogger) Stop() error {
+	j.mu.Lock()
+	cmd := j.command
+	j.mu.Unlock()
+	if cmd == nil {
+		return j.Err()
+	}
+	return j.setCommand(stopCommand(cmd))
+}
+
+// Done returns a channel that is closed when the jogging session has ended.
+func (j *Jogger) Done() <-chan struct{} {
+	return j.done
+}
+
+// Err returns the error that ended the jogging session, or nil if the session
+// is still active. Rejections by the server are returned as
+// *JoggingRejectedError.
+func (j *Jogger) Err() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.err
+}
+
+// Close stops the robot, ends the jogging session and releases the part.
+// Returns an error if the server rejected the session or one of its commands.
+func (j *Jogger) Close() error {
+	j.mu.Lock()
+	active := j.err == nil
+	j.mu.Unlock()
+	if active {
+		// Cancel the stream instead of waiting for the server to acknowledge the
+		// end of the stream. This ends the session and stops the robot.
+		j.finish(ErrJoggerClosed)
+	}
+	j.cancel()
+	j.wg.Wait()
+	if err := j.Err(); err != ErrJoggerClosed && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
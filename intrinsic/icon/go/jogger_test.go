@@ -0,0 +1,225 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package icon
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	jogpb "intrinsic/icon/proto/v1/jogging_service_go_proto"
+
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+)
+
+const joggingPart = "arm"
+
+// fakeJoggingService records the jogging commands it receives. It rejects
+// every jogging session with reject, if set.
+type fakeJoggingService struct {
+	jogpb.UnimplementedJoggingServiceServer
+	reject   error
+	commands chan *jogpb.JoggingCommand
+	// ended receives the error that ended a jogging stream.
+	ended chan error
+}
+
+func (s *fakeJoggingService) GetAvailableParts(context.Context, *jogpb.AvailablePartsRequest) (*jogpb.AvailablePartsResponse, error) {
+	return &jogpb.AvailablePartsResponse{
+		Parts: []*jogpb.PartJoggingInfo{{PartName: joggingPart, StopTimeout: durationpb.New(100 * time.Millisecond)}},
+	}, nil
+}
+
+func (s *fakeJoggingService) JogRobot(stream jogpb.JoggingService_JogRobotServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if req.GetInitialJoggingData().GetPartName() != joggingPart {
+		return status.Errorf(codes.InvalidArgument, "unknown part %q", req.GetInitialJoggingData().GetPartName())
+	}
+	if s.reject != nil {
+		return s.reject
+	}
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			s.ended <- err
+			return nil
+		}
+		s.commands <- req.GetJoggingCommand()
+	}
+}
+
+func newFakeJoggingClient(t *testing.T, svc *fakeJoggingService) *JoggingClient {
+	t.Helper()
+	svc.commands = make(chan *jogpb.JoggingCommand, 100)
+	svc.ended = make(chan error, 1)
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	jogpb.RegisterJoggingServiceServer(server, svc)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.DialContext() failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return NewJoggingClient(conn)
+}
+
+func jointCommand(jointIndex int64, normalizedVelocity float64) *jogpb.JoggingCommand {
+	return &jogpb.JoggingCommand{
+		JoggingCommand: &jogpb.JoggingCommand_JointJoggingCommand{
+			JointJoggingCommand: &jogpb.JointJoggingCommand{JointIndex: jointIndex, NormalizedVelocity: normalizedVelocity},
+		},
+	}
+}
+
+func nextCommand(t *testing.T, svc *fakeJoggingService) *jogpb.JoggingCommand {
+	t.Helper()
+	select {
+	case cmd := <-svc.commands:
+		return cmd
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a jogging command")
+		return nil
+	}
+}
+
+func waitForEnd(t *testing.T, svc *fakeJoggingService) {
+	t.Helper()
+	select {
+	case <-svc.ended:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the jogging stream to end")
+	}
+}
+
+func TestJoggerRejected(t *testing.T) {
+	svc := &fakeJoggingService{reject: status.Error(codes.FailedPrecondition, "part is held by another client")}
+	client := newFakeJoggingClient(t, svc)
+
+	// Depending on timing, the rejection is returned by StartJogging or ends
+	// the session right after it started.
+	j, err := client.StartJogging(context.Background(), JointJogging(joggingPart))
+	if err == nil {
+		<-j.Done()
+		err = j.Err()
+		if closeErr := j.Close(); !errors.Is(closeErr, ErrJoggingRejected) {
+			t.Errorf("Close() returned %v, want %v", closeErr, ErrJoggingRejected)
+		}
+	}
+	var rejected *JoggingRejectedError
+	if !errors.As(err, &rejected) || rejected.Code != codes.FailedPrecondition {
+		t.Errorf("StartJogging() returned %v, want a JoggingRejectedError with code %v", err, codes.FailedPrecondition)
+	}
+}
+
+func TestJoggerDeadmanStop(t *testing.T) {
+	svc := &fakeJoggingService{}
+	client := newFakeJoggingClient(t, svc)
+	// The heartbeat is much longer than the deadman timeout, the robot must
+	// nevertheless be stopped once the deadman timeout expires.
+	j, err := client.StartJogging(context.Background(), JointJogging(joggingPart),
+		WithHeartbeatInterval(time.Hour), WithDeadmanTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("StartJogging() failed: %v", err)
+	}
+	defer j.Close()
+
+	start := time.Now()
+	if err := j.JogJoint(1, 0.5); err != nil {
+		t.Fatalf("JogJoint() failed: %v", err)
+	}
+	if got, want := nextCommand(t, svc), jointCommand(1, 0.5); !proto.Equal(got, want) {
+		t.Errorf("Received command %v, want %v", got, want)
+	}
+	if got, want := nextCommand(t, svc), jointCommand(1, 0); !proto.Equal(got, want) {
+		t.Errorf("Received command %v after the deadman timeout, want %v", got, want)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Robot was stopped after %v, want about the deadman timeout", elapsed)
+	}
+	if err := j.Err(); err != nil {
+		t.Errorf("Err() = %v after the deadman timeout, want the session to stay open", err)
+	}
+}
+
+func TestJoggerContextCanceled(t *testing.T) {
+	svc := &fakeJoggingService{}
+	client := newFakeJoggingClient(t, svc)
+	ctx, cancel := context.WithCancel(context.Background())
+	j, err := client.StartJogging(ctx, JointJogging(joggingPart))
+	if err != nil {
+		t.Fatalf("StartJogging() failed: %v", err)
+	}
+	if err := j.JogJoint(0, 1); err != nil {
+		t.Fatalf("JogJoint() failed: %v", err)
+	}
+	nextCommand(t, svc)
+
+	cancel()
+	<-j.Done()
+	waitForEnd(t, svc)
+	if err := j.Err(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Err() = %v, want %v", err, context.Canceled)
+	}
+	if err := j.JogJoint(0, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("JogJoint() after cancellation returned %v, want %v", err, context.Canceled)
+	}
+	if err := j.Close(); err != nil {
+		t.Errorf("Close() failed: %v", err)
+	}
+}
+
+func TestJoggerClose(t *testing.T) {
+	svc := &fakeJoggingService{}
+	client := newFakeJoggingClient(t, svc)
+	j, err := client.StartJogging(context.Background(), JointJogging(joggingPart))
+	if err != nil {
+		t.Fatalf("StartJogging() failed: %v", err)
+	}
+	if err := j.JogJoint(0, 1); err != nil {
+		t.Fatalf("JogJoint() failed: %v", err)
+	}
+	nextCommand(t, svc)
+
+	if err := j.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+	waitForEnd(t, svc)
+	select {
+	case <-j.Done():
+	default:
+		t.Errorf("Done() is not closed after Close()")
+	}
+	if err := j.JogJoint(0, 1); !errors.Is(err, ErrJoggerClosed) {
+		t.Errorf("JogJoint() after Close() returned %v, want %v", err, ErrJoggerClosed)
+	}
+}
@@ -0,0 +1,393 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package limits fetches the joint and Cartesian limits of ICON parts and
+// checks motion parameters against them before they are sent to ICON.
+//
+// Without these checks, parameters that exceed the limits of a part are only
+// reported as failures of the action after the session has started it:
+//
+//	l, err := limits.ForPart(ctx, client, "arm")
+//	if err != nil {
+//		// error handling
+//	}
+//	p := actions.PointToPointMoveParams{GoalPosition: goal}
+//	if p.JointLimits, err = l.ScaledVelocity(0.5); err != nil {
+//		// error handling
+//	}
+//	if err := l.CheckPointToPointMove(p); err != nil {
+//		// error handling
+//	}
+//	move, err := b.PointToPointMove(ctx, "arm", p)
+package limits
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"intrinsic/icon/go/actions"
+	"intrinsic/icon/go/icon"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	cartspacepb "intrinsic/icon/proto/cart_space_go_proto"
+	jointspacepb "intrinsic/icon/proto/joint_space_go_proto"
+	limitgrpcpb "intrinsic/icon/proto/limit_provider_service_go_proto"
+	limitpb "intrinsic/icon/proto/limit_provider_service_go_proto"
+	typespb "intrinsic/icon/proto/v1/types_go_proto"
+	jointlimitspb "intrinsic/kinematics/types/joint_limits_go_proto"
+)
+
+var (
+	// ErrLimitViolation occurs when motion parameters exceed the limits of a
+	// part.
+	ErrLimitViolation = errors.New("limit violation")
+	// ErrInvalidLimits occurs when limits are malformed, e.g., when they have a
+	// different number of joints than the limits they update.
+	ErrInvalidLimits = errors.New("invalid limits")
+)
+
+// Client fetches the allowed limits from a LimitProviderService, which may be
+// implemented by the hardware module of a robot.
+type Client struct {
+	client                    limitgrpcpb.LimitProviderServiceClient
+	serverInstanceHeaderValue string
+}
+
+// ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// WithServerInstanceHeaderValue sets the resource instance name to send in
+// the metadata of all requests, see icon.WithServerInstanceHeaderValue.
+func WithServerInstanceHeaderValue(name string) ClientOption {
+	return func(c *Client) {
+		c.serverInstanceHeaderValue = name
+	}
+}
+
+// NewClient creates a client of the LimitProviderService at conn.
+func NewClient(conn grpc.ClientConnInterface, opts ...ClientOption) *Client {
+	c := &Client{client: limitgrpcpb.NewLimitProviderServiceClient(conn)}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// AllowedLimitsOption configures a request of Client.AllowedLimits.
+type AllowedLimitsOption func(*limitpb.GetAllowedLimitsRequest)
+
+// WithPayloadMass requests the limits for the given payload mass instead of
+// the maximum payload mass. The payload of the robot must be set accordingly
+// before the limits are used.
+func WithPayloadMass(kg float64) AllowedLimitsOption {
+	return func(req *limitpb.GetAllowedLimitsRequest) {
+		req.PayloadMassKg = proto.Float64(kg)
+	}
+}
+
+// WithMaxTCPSpeed requests the limits for the given maximum TCP speed instead
+// of the default one. This is experimental and not supported by all hardware
+// modules.
+func WithMaxTCPSpeed(metersPerSecond float64) AllowedLimitsOption {
+	return func(req *limitpb.GetAllowedLimitsRequest) {
+		req.MaxTcpSpeed = proto.Float64(metersPerSecond)
+	}
+}
+
+// AllowedLimits are the limits that a LimitProviderService allows for a
+// payload and TCP speed.
+type AllowedLimits struct {
+	// System updates the system limits of the robot.
+	System *jointlimitspb.JointLimitsUpdate
+	// Application updates the application limits of the robot.
+	Application *jointlimitspb.JointLimitsUpdate
+	// MaxTCPSpeed is the TCP speed in m/s that the limits were computed for.
+	MaxTCPSpeed float64
+	// PayloadMassKg is the payload mass that the limits were computed for.
+	PayloadMassKg float64
+}
+
+// AllowedLimits returns the maximum allowed limits of the robot.
+func (c *Client) AllowedLimits(ctx context.Context, opts ...AllowedLimitsOption) (*AllowedLimits, error) {
+	req := &limitpb.GetAllowedLimitsRequest{}
+	for _, opt := range opts {
+		opt(req)
+	}
+	ctx = icon.SetResourceInstanceHeaderOutgoingMetadata(ctx, c.serverInstanceHeaderValue)
+	resp, err := c.client.GetAllowedLimits(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allowed limits: %w", err)
+	}
+	return &AllowedLimits{
+		System:        resp.GetSystemLimits(),
+		Application:   resp.GetApplicationLimits(),
+		MaxTCPSpeed:   resp.GetMaxTcpSpeed(),
+		PayloadMassKg: resp.GetPayloadMassKg(),
+	}, nil
+}
+
+// PartLimits are the limits of a part. Fields and values that are not set are
+// not limited.
+type PartLimits struct {
+	// Application are the joint limits that motions must respect.
+	Application *jointlimitspb.JointLimits
+	// System are the joint limits that ICON never violates. They are purely
+	// informative.
+	System *jointlimitspb.JointLimits
+	// Cartesian are the default Cartesian limits of the part.
+	Cartesian *cartspacepb.CartesianLimits
+}
+
+// FromPartConfig returns the limits in the generic config of a part.
+func FromPartConfig(pc *typespb.PartConfig) *PartLimits {
+	gc := pc.GetGenericConfig()
+	return &PartLimits{
+		Application: gc.GetJointLimitsConfig().GetApplicationLimits(),
+		System:      gc.GetJointLimitsConfig().GetSystemLimits(),
+		Cartesian:   gc.GetCartesianLimitsConfig().GetDefaultCartesianLimits(),
+	}
+}
+
+// ForPart returns the limits of a part, as reported by the ICON server.
+func ForPart(ctx context.Context, client icon.Client, part string) (*PartLimits, error) {
+	parts, _, err := client.Config(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	config, err := icon.ServerConfigFromProto(parts)
+	if err != nil {
+		return nil, err
+	}
+	pc, err := config.PartConfig(part)
+	if err != nil {
+		return nil, err
+	}
+	return FromPartConfig(pc), nil
+}
+
+// WithAllowedLimits returns a copy of l with the updates of a applied to its
+// joint limits.
+func (l *PartLimits) WithAllowedLimits(a *AllowedLimits) (*PartLimits, error) {
+	application, err := ApplyUpdate(l.Application, a.Application)
+	if err != nil {
+		return nil, fmt.Errorf("application limits: %w", err)
+	}
+	system, err := ApplyUpdate(l.System, a.System)
+	if err != nil {
+		return nil, fmt.Errorf("system limits: %w", err)
+	}
+	res := &PartLimits{Application: application, System: system}
+	if l.Cartesian != nil {
+		res.Cartesian = proto.Clone(l.Cartesian).(*cartspacepb.CartesianLimits)
+	}
+	return res, nil
+}
+
+// ApplyUpdate returns a copy of limits where every field that is set in update
+// is replaced. A replaced field must have the same number of joints as before,
+// unless it was empty.
+func ApplyUpdate(limits *jointlimitspb.JointLimits, update *jointlimitspb.JointLimitsUpdate) (*jointlimitspb.JointLimits, error) {
+	res := &jointlimitspb.JointLimits{}
+	if limits != nil {
+		res = proto.Clone(limits).(*jointlimitspb.JointLimits)
+	}
+	fields := []struct {
+		name   string
+		dest   **jointlimitspb.RepeatedDouble
+		update *jointlimitspb.RepeatedDouble
+	}{
+		{"min_position", &res.MinPosition, update.GetMinPosition()},
+		{"max_position", &res.MaxPosition, update.GetMaxPosition()},
+		{"max_velocity", &res.MaxVelocity, update.GetMaxVelocity()},
+		{"max_acceleration", &res.MaxAcceleration, update.GetMaxAcceleration()},
+		{"max_jerk", &res.MaxJerk, update.GetMaxJerk()},
+		{"max_effort", &res.MaxEffort, update.GetMaxEffort()},
+	}
+	for _, f := range fields {
+		if len(f.update.GetValues()) == 0 {
+			continue
+		}
+		if n := len((*f.dest).GetValues()); n != 0 && n != len(f.update.GetValues()) {
+			return nil, fmt.Errorf("update of %s has %d joints, limits have %d: %w", f.name, len(f.update.GetValues()), n, ErrInvalidLimits)
+		}
+		*f.dest = proto.Clone(f.update).(*jointlimitspb.RepeatedDouble)
+	}
+	return res, nil
+}
+
+// ScaledVelocity returns a copy of the application limits with the maximum
+// joint velocities multiplied by factor, which must be in (0, 1]. The result
+// can be used to slow down a motion, e.g., as
+// actions.PointToPointMoveParams.JointLimits.
+func (l *PartLimits) ScaledVelocity(factor float64) (*jointlimitspb.JointLimits, error) {
+	if !(factor > 0 && factor <= 1) {
+		return nil, fmt.Errorf("velocity scaling %v is not in (0, 1]: %w", factor, actions.ErrInvalidParams)
+	}
+	if len(l.Application.GetMaxVelocity().GetValues()) == 0 {
+		return nil, fmt.Errorf("cannot scale velocity without maximum velocity limits: %w", ErrInvalidLimits)
+	}
+	res := proto.Clone(l.Application).(*jointlimitspb.JointLimits)
+	for i := range res.MaxVelocity.Values {
+		res.MaxVelocity.Values[i] *= factor
+	}
+	return res, nil
+}
+
+// checkRange checks that values are within [lower, upper] per joint. Empty
+// values and bounds are not checked.
+func checkRange(quantity string, values []float64, lower, upper *jointlimitspb.RepeatedDouble) error {
+	if len(values) == 0 {
+		return nil
+	}
+	var errs []error
+	for _, bound := range []*jointlimitspb.RepeatedDouble{lower, upper} {
+		if n := len(bound.GetValues()); n != 0 && n != len(values) {
+			return fmt.Errorf("%s has %d joints, limits have %d: %w", quantity, len(values), n, actions.ErrInvalidParams)
+		}
+	}
+	for i, v := range values {
+		if lo := lower.GetValues(); len(lo) != 0 && v < lo[i] {
+			errs = append(errs, fmt.Errorf("joint %d: %s %v is below the minimum of %v: %w", i, quantity, v, lo[i], ErrLimitViolation))
+		}
+		if hi := upper.GetValues(); len(hi) != 0 && v > hi[i] {
+			errs = append(errs, fmt.Errorf("joint %d: %s %v exceeds the maximum of %v: %w", i, quantity, v, hi[i], ErrLimitViolation))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkMagnitude checks that the magnitudes of values are within the
+// symmetric per-joint limits upper. Empty limits are not checked.
+func checkMagnitude(quantity string, values []float64, upper *jointlimitspb.RepeatedDouble) error {
+	if len(upper.GetValues()) == 0 {
+		return nil
+	}
+	abs := make([]float64, len(values))
+	for i, v := range values {
+		abs[i] = math.Abs(v)
+	}
+	return checkRange("magnitude of "+quantity, abs, nil, upper)
+}
+
+// CheckJointPosition checks that a joint position is within the application
+// position limits.
+func (l *PartLimits) CheckJointPosition(position []float64) error {
+	return checkRange("position", position, l.Application.GetMinPosition(), l.Application.GetMaxPosition())
+}
+
+// CheckJointVelocity checks that a joint velocity is within the application
+// velocity limits.
+func (l *PartLimits) CheckJointVelocity(velocity []float64) error {
+	return checkMagnitude("velocity", velocity, l.Application.GetMaxVelocity())
+}
+
+// CheckJointLimits checks that custom joint limits of an action are within
+// the application limits, which ICON requires.
+func (l *PartLimits) CheckJointLimits(custom *jointlimitspb.JointLimits) error {
+	app := l.Application
+	return errors.Join(
+		checkRange("minimum position", custom.GetMinPosition().GetValues(), app.GetMinPosition(), app.GetMaxPosition()),
+		checkRange("maximum position", custom.GetMaxPosition().GetValues(), app.GetMinPosition(), app.GetMaxPosition()),
+		checkMagnitude("maximum velocity", custom.GetMaxVelocity().GetValues(), app.GetMaxVelocity()),
+		checkMagnitude("maximum acceleration", custom.GetMaxAcceleration().GetValues(), app.GetMaxAcceleration()),
+		checkMagnitude("maximum jerk", custom.GetMaxJerk().GetValues(), app.GetMaxJerk()),
+		checkMagnitude("maximum effort", custom.GetMaxEffort().GetValues(), app.GetMaxEffort()),
+	)
+}
+
+// CheckPointToPointMove checks the parameters of a point-to-point move. The
+// goal must be within the custom joint limits of the move, if any, and those
+// must be within the application limits.
+func (l *PartLimits) CheckPointToPointMove(p actions.PointToPointMoveParams) error {
+	effective := l
+	if p.JointLimits != nil {
+		if err := l.CheckJointLimits(p.JointLimits); err != nil {
+			return err
+		}
+		effective = &PartLimits{Application: mergeLimits(l.Application, p.JointLimits)}
+	}
+	return errors.Join(
+		effective.CheckJointPosition(p.GoalPosition),
+		effective.CheckJointVelocity(p.GoalVelocity),
+	)
+}
+
+// mergeLimits returns base with the fields that are set in custom replaced.
+func mergeLimits(base, custom *jointlimitspb.JointLimits) *jointlimitspb.JointLimits {
+	res := &jointlimitspb.JointLimits{}
+	if base != nil {
+		res = proto.Clone(base).(*jointlimitspb.JointLimits)
+	}
+	for _, f := range []struct {
+		dest   **jointlimitspb.RepeatedDouble
+		custom *jointlimitspb.RepeatedDouble
+	}{
+		{&res.MinPosition, custom.GetMinPosition()},
+		{&res.MaxPosition, custom.GetMaxPosition()},
+		{&res.MaxVelocity, custom.GetMaxVelocity()},
+		{&res.MaxAcceleration, custom.GetMaxAcceleration()},
+		{&res.MaxJerk, custom.GetMaxJerk()},
+		{&res.MaxEffort, custom.GetMaxEffort()},
+	} {
+		if len(f.custom.GetValues()) != 0 {
+			*f.dest = f.custom
+		}
+	}
+	return res
+}
+
+// CheckTrajectory checks that all states of a joint trajectory are within the
+// application position, velocity and acceleration limits.
+func (l *PartLimits) CheckTrajectory(trajectory *jointspacepb.JointTrajectoryPVA) error {
+	var errs []error
+	for i, s := range trajectory.GetState() {
+		if err := errors.Join(
+			l.CheckJointPosition(s.GetPosition()),
+			l.CheckJointVelocity(s.GetVelocity()),
+			checkMagnitude("acceleration", s.GetAcceleration(), l.Application.GetMaxAcceleration()),
+		); err != nil {
+			errs = append(errs, fmt.Errorf("state %d: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CheckTwist checks that a Cartesian twist, e.g., a jogging command, is within
+// the translational velocity limits per axis and the maximum rotational
+// velocity of the Cartesian limits.
+func (l *PartLimits) CheckTwist(twist *cartspacepb.Twist) error {
+	c := l.Cartesian
+	if c == nil {
+		return nil
+	}
+	var errs []error
+	translation := []float64{twist.GetX(), twist.GetY(), twist.GetZ()}
+	for i, axis := range []string{"x", "y", "z"} {
+		if lo := c.GetMinTranslationalVelocity(); len(lo) > i && translation[i] < lo[i] {
+			errs = append(errs, fmt.Errorf("translational velocity %v along %s is below the minimum of %v: %w", translation[i], axis, lo[i], ErrLimitViolation))
+		}
+		if hi := c.GetMaxTranslationalVelocity(); len(hi) > i && translation[i] > hi[i] {
+			errs = append(errs, fmt.Errorf("translational velocity %v along %s exceeds the maximum of %v: %w", translation[i], axis, hi[i], ErrLimitViolation))
+		}
+	}
+	rotation := math.Sqrt(twist.GetRx()*twist.GetRx() + twist.GetRy()*twist.GetRy() + twist.GetRz()*twist.GetRz())
+	if hi := c.GetMaxRotationalVelocity(); hi > 0 && rotation > hi {
+		errs = append(errs, fmt.Errorf("rotational velocity %v exceeds the maximum of %v: %w", rotation, hi, ErrLimitViolation))
+	}
+	return errors.Join(errs...)
+}
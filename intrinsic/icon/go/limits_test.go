@@ -0,0 +1,261 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package limits
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"intrinsic/icon/go/actions"
+	"intrinsic/icon/go/icontest"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	cartspacepb "intrinsic/icon/proto/cart_space_go_proto"
+	genericpb "intrinsic/icon/proto/generic_part_config_go_proto"
+	jointspacepb "intrinsic/icon/proto/joint_space_go_proto"
+	limitgrpcpb "intrinsic/icon/proto/limit_provider_service_go_proto"
+	limitpb "intrinsic/icon/proto/limit_provider_service_go_proto"
+	typespb "intrinsic/icon/proto/v1/types_go_proto"
+	jointlimitspb "intrinsic/kinematics/types/joint_limits_go_proto"
+)
+
+func values(v ...float64) *jointlimitspb.RepeatedDouble {
+	return &jointlimitspb.RepeatedDouble{Values: v}
+}
+
+func testLimits() *PartLimits {
+	return &PartLimits{
+		Application: &jointlimitspb.JointLimits{
+			MinPosition:     values(-1, -2),
+			MaxPosition:     values(1, 2),
+			MaxVelocity:     values(0.5, 1),
+			MaxAcceleration: values(2, 2),
+		},
+		Cartesian: &cartspacepb.CartesianLimits{
+			MinTranslationalVelocity: []float64{-0.1, -0.1, -0.1},
+			MaxTranslationalVelocity: []float64{0.1, 0.1, 0.1},
+			MaxRotationalVelocity:    0.5,
+		},
+	}
+}
+
+func TestCheckPointToPointMove(t *testing.T) {
+	l := testLimits()
+	scaled, err := l.ScaledVelocity(0.5)
+	if err != nil {
+		t.Fatalf("ScaledVelocity() failed: %v", err)
+	}
+	tests := []struct {
+		name    string
+		p       actions.PointToPointMoveParams
+		wantErr error
+	}{
+		{name: "within limits", p: actions.PointToPointMoveParams{GoalPosition: []float64{0.5, -1.5}}},
+		{name: "with goal velocity", p: actions.PointToPointMoveParams{GoalPosition: []float64{0, 0}, GoalVelocity: []float64{-0.5, 1}}},
+		{name: "position above maximum", p: actions.PointToPointMoveParams{GoalPosition: []float64{1.5, 0}}, wantErr: ErrLimitViolation},
+		{name: "position below minimum", p: actions.PointToPointMoveParams{GoalPosition: []float64{0, -3}}, wantErr: ErrLimitViolation},
+		{name: "velocity too high", p: actions.PointToPointMoveParams{GoalPosition: []float64{0, 0}, GoalVelocity: []float64{-0.6, 0}}, wantErr: ErrLimitViolation},
+		{name: "velocity within scaled limits", p: actions.PointToPointMoveParams{GoalPosition: []float64{0, 0}, GoalVelocity: []float64{0.25, 0}, JointLimits: scaled}},
+		{name: "velocity above scaled limits", p: actions.PointToPointMoveParams{GoalPosition: []float64{0, 0}, GoalVelocity: []float64{0.3, 0}, JointLimits: scaled}, wantErr: ErrLimitViolation},
+		{
+			name:    "custom limits above application limits",
+			p:       actions.PointToPointMoveParams{GoalPosition: []float64{0, 0}, JointLimits: &jointlimitspb.JointLimits{MaxVelocity: values(1, 1)}},
+			wantErr: ErrLimitViolation,
+		},
+		{
+			name:    "position outside custom limits",
+			p:       actions.PointToPointMoveParams{GoalPosition: []float64{0.6, 0}, JointLimits: &jointlimitspb.JointLimits{MaxPosition: values(0.5, 2)}},
+			wantErr: ErrLimitViolation,
+		},
+		{name: "wrong number of joints", p: actions.PointToPointMoveParams{GoalPosition: []float64{0}}, wantErr: actions.ErrInvalidParams},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := l.CheckPointToPointMove(tc.p); !errors.Is(err, tc.wantErr) {
+				t.Errorf("CheckPointToPointMove(%v) = %v, want %v", tc.p, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestScaledVelocity(t *testing.T) {
+	l := testLimits()
+	got, err := l.ScaledVelocity(0.1)
+	if err != nil {
+		t.Fatalf("ScaledVelocity() failed: %v", err)
+	}
+	if diff := cmp.Diff([]float64{0.05, 0.1}, got.GetMaxVelocity().GetValues(), cmp.Comparer(func(a, b float64) bool { return a-b < 1e-9 && b-a < 1e-9 })); diff != "" {
+		t.Errorf("ScaledVelocity() returned unexpected velocities (-want +got):\n%s", diff)
+	}
+	if l.Application.GetMaxVelocity().GetValues()[0] != 0.5 {
+		t.Errorf("ScaledVelocity() modified the application limits")
+	}
+	for _, factor := range []float64{0, -0.5, 1.5} {
+		if _, err := l.ScaledVelocity(factor); !errors.Is(err, actions.ErrInvalidParams) {
+			t.Errorf("ScaledVelocity(%v) = %v, want %v", factor, err, actions.ErrInvalidParams)
+		}
+	}
+}
+
+func TestCheckTrajectory(t *testing.T) {
+	l := testLimits()
+	trajectory := &jointspacepb.JointTrajectoryPVA{
+		State: []*jointspacepb.JointStatePVA{
+			{Position: []float64{0, 0}, Velocity: []float64{0, 0}, Acceleration: []float64{1, 1}},
+			{Position: []float64{0.5, 0.5}, Velocity: []float64{0.2, 0.2}, Acceleration: []float64{3, 0}},
+		},
+	}
+	if err := l.CheckTrajectory(trajectory); !errors.Is(err, ErrLimitViolation) {
+		t.Errorf("CheckTrajectory() = %v, want %v", err, ErrLimitViolation)
+	}
+	trajectory.GetState()[1].Acceleration = []float64{0, 0}
+	if err := l.CheckTrajectory(trajectory); err != nil {
+		t.Errorf("CheckTrajectory() failed: %v", err)
+	}
+}
+
+func TestCheckTwist(t *testing.T) {
+	l := testLimits()
+	tests := []struct {
+		name    string
+		twist   *cartspacepb.Twist
+		wantErr error
+	}{
+		{name: "within limits", twist: &cartspacepb.Twist{X: 0.1, Z: -0.05, Rx: 0.3, Ry: 0.3}},
+		{name: "translation too fast", twist: &cartspacepb.Twist{Y: -0.2}, wantErr: ErrLimitViolation},
+		{name: "rotation too fast", twist: &cartspacepb.Twist{Rx: 0.4, Rz: 0.4}, wantErr: ErrLimitViolation},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := l.CheckTwist(tc.twist); !errors.Is(err, tc.wantErr) {
+				t.Errorf("CheckTwist(%v) = %v, want %v", tc.twist, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyUpdate(t *testing.T) {
+	limits := &jointlimitspb.JointLimits{
+		MinPosition: values(-1, -1),
+		MaxPosition: values(1, 1),
+	}
+	got, err := ApplyUpdate(limits, &jointlimitspb.JointLimitsUpdate{
+		MaxPosition: values(0.5, 0.5),
+		MaxVelocity: values(2, 2),
+	})
+	if err != nil {
+		t.Fatalf("ApplyUpdate() failed: %v", err)
+	}
+	want := &jointlimitspb.JointLimits{
+		MinPosition: values(-1, -1),
+		MaxPosition: values(0.5, 0.5),
+		MaxVelocity: values(2, 2),
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("ApplyUpdate() returned unexpected limits (-want +got):\n%s", diff)
+	}
+	if _, err := ApplyUpdate(limits, &jointlimitspb.JointLimitsUpdate{MinPosition: values(0)}); !errors.Is(err, ErrInvalidLimits) {
+		t.Errorf("ApplyUpdate() with the wrong number of joints = %v, want %v", err, ErrInvalidLimits)
+	}
+}
+
+type fakeLimitProvider struct {
+	limitgrpcpb.UnimplementedLimitProviderServiceServer
+
+	requests []*limitpb.GetAllowedLimitsRequest
+}
+
+func (f *fakeLimitProvider) GetAllowedLimits(ctx context.Context, req *limitpb.GetAllowedLimitsRequest) (*limitpb.GetAllowedLimitsResponse, error) {
+	f.requests = append(f.requests, req)
+	return &limitpb.GetAllowedLimitsResponse{
+		ApplicationLimits: &jointlimitspb.JointLimitsUpdate{MaxVelocity: values(0.2, 0.2)},
+		PayloadMassKg:     req.GetPayloadMassKg(),
+		MaxTcpSpeed:       1,
+	}, nil
+}
+
+func TestAllowedLimits(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeLimitProvider{}
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	limitgrpcpb.RegisterLimitProviderServiceServer(s, fake)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	allowed, err := NewClient(conn).AllowedLimits(ctx, WithPayloadMass(3))
+	if err != nil {
+		t.Fatalf("AllowedLimits() failed: %v", err)
+	}
+	if allowed.PayloadMassKg != 3 || allowed.MaxTCPSpeed != 1 {
+		t.Errorf("AllowedLimits() = %+v, want payload mass 3 and TCP speed 1", allowed)
+	}
+	if len(fake.requests) != 1 || fake.requests[0].MaxTcpSpeed != nil {
+		t.Errorf("AllowedLimits() sent requests %v, want one without TCP speed", fake.requests)
+	}
+
+	l, err := testLimits().WithAllowedLimits(allowed)
+	if err != nil {
+		t.Fatalf("WithAllowedLimits() failed: %v", err)
+	}
+	if err := l.CheckJointVelocity([]float64{0.3, 0}); !errors.Is(err, ErrLimitViolation) {
+		t.Errorf("CheckJointVelocity() with allowed limits = %v, want %v", err, ErrLimitViolation)
+	}
+}
+
+func TestForPart(t *testing.T) {
+	ctx := context.Background()
+	srv := icontest.NewServer(icontest.WithParts(&typespb.PartConfig{
+		Name: "arm",
+		GenericConfig: &genericpb.GenericPartConfig{
+			JointLimitsConfig: &genericpb.GenericJointLimitsConfig{
+				ApplicationLimits: testLimits().Application,
+			},
+		},
+	}))
+	t.Cleanup(srv.Close)
+	client, err := srv.NewClient(ctx)
+	if err != nil {
+		t.Fatalf("NewClient() failed: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	l, err := ForPart(ctx, client, "arm")
+	if err != nil {
+		t.Fatalf("ForPart() failed: %v", err)
+	}
+	if diff := cmp.Diff(testLimits().Application, l.Application, protocmp.Transform()); diff != "" {
+		t.Errorf("ForPart() returned unexpected application limits (-want +got):\n%s", diff)
+	}
+	if _, err := ForPart(ctx, client, "unknown"); err == nil {
+		t.Errorf("ForPart() of an unknown part succeeded, want error")
+	}
+}
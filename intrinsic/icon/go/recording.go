@@ -0,0 +1,144 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package icon
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protodelim"
+
+	servicepb "intrinsic/icon/proto/v1/service_go_proto"
+	recordingpb "intrinsic/icon/proto/v1/session_recording_go_proto"
+
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrRecording occurs when starting to record a session that is already being
+// recorded or that already sent requests, when stopping a recording that was
+// not started, or when stopping a recording that ended because its supervised
+// session was re-opened.
+var ErrRecording = errors.New("invalid recording state")
+
+// sessionRecorder writes session record entries to a writer in the format
+// described in session_recording.proto.
+type sessionRecorder struct {
+	mu  sync.Mutex
+	w   io.Writer
+	err error // First error that occurred while writing.
+}
+
+func (r *sessionRecorder) write(e *recordingpb.SessionRecordEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.writeLocked(e)
+}
+
+// fail ends the recording with err: later entries are no longer written.
+func (r *sessionRecorder) fail(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+func (r *sessionRecorder) writeLocked(e *recordingpb.SessionRecordEntry) {
+	if r.err != nil {
+		return
+	}
+	if _, err := protodelim.MarshalTo(r.w, e); err != nil {
+		r.err = err
+	}
+}
+
+func requestEntry(req *servicepb.OpenSessionRequest) *recordingpb.SessionRecordEntry {
+	return &recordingpb.SessionRecordEntry{
+		Timestamp: tspb.Now(),
+		Entry:     &recordingpb.SessionRecordEntry_Request{Request: req},
+	}
+}
+
+func responseEntry(resp *servicepb.OpenSessionResponse) *recordingpb.SessionRecordEntry {
+	return &recordingpb.SessionRecordEntry{
+		Timestamp: tspb.Now(),
+		Entry:     &recordingpb.SessionRecordEntry_Response{Response: resp},
+	}
+}
+
+func reactionEntry(resp *servicepb.WatchReactionsResponse) *recordingpb.SessionRecordEntry {
+	return &recordingpb.SessionRecordEntry{
+		Timestamp: tspb.Now(),
+		Entry:     &recordingpb.SessionRecordEntry_Reaction{Reaction: resp},
+	}
+}
+
+// StartRecording writes every request that s sends to the server, every
+// response it receives and every reaction event it receives to w, together
+// with timestamps, until StopRecording is called. The initial request and
+// response of the session are written first, even if they occurred before
+// StartRecording was called. The format of the recording is described in
+// intrinsic/icon/proto/v1/session_recording.proto.
+//
+// A recording can only be replayed if it contains every action and reaction
+// that its requests refer to. StartRecording therefore returns ErrRecording if
+// s already sent a request after the initial one, e.g. to add actions. Start
+// recording right after starting the session.
+//
+// A recording covers a single session on the server. If a supervised session
+// is re-opened (see WithReopen), its recording ends and StopRecording returns
+// an error wrapping ErrRecording.
+//
+// Writes happen synchronously, so w should be buffered if it is slow.
+func (s *Session) StartRecording(w io.Writer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sentRequests {
+		return fmt.Errorf("%w: session %d already sent requests, start recording before adding actions", ErrRecording, s.id)
+	}
+	r := &sessionRecorder{w: w}
+	// Hold the lock until the initial entries are written, so that they come
+	// before any concurrently recorded reaction events.
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !s.recorder.CompareAndSwap(nil, r) {
+		return fmt.Errorf("%w: session %d is already being recorded", ErrRecording, s.id)
+	}
+	for _, e := range s.initialEntries {
+		r.writeLocked(e)
+	}
+	return r.err
+}
+
+// StopRecording stops a recording started with StartRecording. It returns the
+// first error that occurred while writing the recording.
+func (s *Session) StopRecording() error {
+	r := s.recorder.Swap(nil)
+	if r == nil {
+		return fmt.Errorf("%w: session %d is not being recorded", ErrRecording, s.ID())
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// record writes e to the recording of s, if any.
+func (s *Session) record(e *recordingpb.SessionRecordEntry) {
+	if r := s.recorder.Load(); r != nil {
+		r.write(e)
+	}
+}
@@ -18,6 +18,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
+	"sync"
+	"sync/atomic"
 
 	"intrinsic/icon/go/intsequence"
 
@@ -26,6 +29,7 @@ import (
 
 	grpcpb "intrinsic/icon/proto/v1/service_go_proto"
 	servicepb "intrinsic/icon/proto/v1/service_go_proto"
+	recordingpb "intrinsic/icon/proto/v1/session_recording_go_proto"
 	typespb "intrinsic/icon/proto/v1/types_go_proto"
 	contextpb "intrinsic/logging/proto/context_go_proto"
 )
@@ -41,6 +45,9 @@ var (
 	ErrCanceled = errors.New("operation canceled")
 	// ErrSessionEnded occurs when the session is already ended.
 	ErrSessionEnded = errors.New("session already ended")
+	// ErrSessionLost occurs when a supervised session lost its connection to
+	// the server and could not or should not be re-opened.
+	ErrSessionLost = errors.New("session lost")
 )
 
 // internalError is an error that wraps Err and Is(ErrInternal).
@@ -61,10 +68,14 @@ func (e internalError) Unwrap() error { return e.err }
 // the ability to manipulate those parts by adding actions and/or reactions, and
 // bounds the lifetime of these server-side objects.
 type Session struct {
+	// mu guards id, ended and session, which change when a supervised session is
+	// re-opened, and serializes requests on session.
+	mu                     sync.Mutex
 	id                     int64 // Session ID
 	client                 *grpcClient
 	ended                  bool
 	session                grpcpb.IconApi_OpenSessionClient
+	supervision            *supervision // nil unless the session is supervised.
 	reactData              *reactionData
 	events                 chan Event
 	watchReactionsError    chan error
@@ -72,13 +83,16 @@ type Session struct {
 	reactionIDs            intsequence.Generator
 	eventSignalUniqueValue intsequence.Generator
 	writeStreams           map[*WriteStream]bool
+	initialEntries         []*recordingpb.SessionRecordEntry // Initial request and response, for recordings.
+	recorder               atomic.Pointer[sessionRecorder]
+	sentRequests           bool // Whether requests were sent after the initial one.
 }
 
 // watchReactions receives reaction updates from the server, converts them to
 // Event objects, and sends them to the events channel. This runs until ctx is
-// canceled or client.Recv() returns an error, whichever occurs earlier.
-func watchReactions(ctx context.Context, client grpcpb.IconApi_WatchReactionsClient, reactData *reactionData, events chan<- Event) error {
-	defer close(events)
+// canceled or client.Recv() returns an error, whichever occurs earlier. Each
+// reaction event is passed to record before it is converted.
+func watchReactions(ctx context.Context, client grpcpb.IconApi_WatchReactionsClient, reactData *reactionData, events chan<- Event, record func(*recordingpb.SessionRecordEntry)) error {
 	for {
 		resp, err := client.Recv()
 		if err != nil {
@@ -90,6 +104,7 @@ func watchReactions(ctx context.Context, client grpcpb.IconApi_WatchReactionsCli
 		if resp.ReactionEvent.ReactionId == 0 { // Ignore non-reaction events.
 			continue
 		}
+		record(reactionEntry(resp))
 		signals := reactData.ListSignals(ReactionID(resp.ReactionEvent.ReactionId))
 		for _, s := range signals {
 			select {
@@ -101,9 +116,10 @@ func watchReactions(ctx context.Context, client grpcpb.IconApi_WatchReactionsCli
 	}
 }
 
-// newSession creates a new Session for a provided list of parts. The ICON logs
-// are tagged with the provided `logContext`, which can be nil.
-func newSession(ctx context.Context, c *grpcClient, parts []string, logContext *contextpb.Context) (*Session, error) {
+// openSessionStream opens an OpenSession stream for a provided list of parts
+// and returns it together with the session ID and the recorded initial request
+// and response.
+func openSessionStream(ctx context.Context, c *grpcClient, parts []string, logContext *contextpb.Context) (grpcpb.IconApi_OpenSessionClient, int64, []*recordingpb.SessionRecordEntry, error) {
 	// OpenSession creates a handle to an OpenSessionClient
 	// which allows sending/receiving messages from the ICON server.
 	req := &servicepb.OpenSessionRequest{
@@ -114,30 +130,44 @@ func newSession(ctx context.Context, c *grpcClient, parts []string, logContext *
 	}
 	session, err := c.client.OpenSession(ctx)
 	if err != nil {
-		return nil, err
+		return nil, 0, nil, err
 	}
+	reqEntry := requestEntry(req)
 	if err := session.Send(req); err != nil {
-		return nil, err
+		return nil, 0, nil, err
 	}
 	resp, err := session.Recv()
 	if err != nil {
-		return nil, err
+		return nil, 0, nil, err
 	}
 	if resp.Status.Code != int32(codespb.OK) {
-		return nil, fmt.Errorf("session gRPC failed: %v", resp.Status.Message)
+		return nil, 0, nil, fmt.Errorf("session gRPC failed: %v", resp.Status.Message)
 	}
 	if resp.InitialSessionData == nil {
-		return nil, fmt.Errorf("missing initial session data")
+		return nil, 0, nil, fmt.Errorf("missing initial session data")
+	}
+	return session, resp.InitialSessionData.SessionId, []*recordingpb.SessionRecordEntry{reqEntry, responseEntry(resp)}, nil
+}
+
+// newSession creates a new Session for a provided list of parts. The ICON logs
+// are tagged with the provided `logContext`, which can be nil. If sup is not
+// nil, the session is supervised.
+func newSession(ctx context.Context, c *grpcClient, parts []string, logContext *contextpb.Context, sup *supervision) (*Session, error) {
+	session, id, initialEntries, err := openSessionStream(ctx, c, parts, logContext)
+	if err != nil {
+		return nil, err
 	}
 	s := &Session{
-		id:                  resp.InitialSessionData.SessionId,
+		id:                  id,
 		client:              c,
 		ended:               false,
 		session:             session,
+		supervision:         sup,
 		reactData:           newReactionData(),
 		watchReactionsError: make(chan error),
 		events:              make(chan Event),
 		writeStreams:        make(map[*WriteStream]bool),
+		initialEntries:      initialEntries,
 	}
 	// Start watchReactions in a goroutine.
 	watchClient, err := c.client.WatchReactions(ctx, &servicepb.WatchReactionsRequest{SessionId: s.id})
@@ -149,8 +179,15 @@ func newSession(ctx context.Context, c *grpcClient, parts []string, logContext *
 	go func() {
 		// Errors from watchReactions are communicated to the user when
 		// they call s.NextEvent.
-		defer close(s.watchReactionsError)
-		s.watchReactionsError <- watchReactions(ctx, watchClient, s.reactData, s.events)
+		var err error
+		if sup != nil {
+			err = s.supervise(ctx, watchClient, parts, logContext)
+		} else {
+			err = watchReactions(ctx, watchClient, s.reactData, s.events, s.record)
+		}
+		close(s.events)
+		s.watchReactionsError <- err
+		close(s.watchReactionsError)
 	}()
 	log.InfoContextf(ctx, "Session started with log context: %v", logContext)
 	return s, nil
@@ -165,6 +202,8 @@ func (s *Session) End() error {
 		delete(s.writeStreams, ws)
 	}
 	// Close the session.
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if err := s.session.CloseSend(); err != nil {
 		return err
 	}
@@ -172,6 +211,22 @@ func (s *Session) End() error {
 	return nil
 }
 
+// isEnded returns true if End was called.
+func (s *Session) isEnded() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ended
+}
+
+// ID returns the ID of the session on the server, e.g., for use with
+// Client.GetLatestStreamingOutput and Client.GetPlannedTrajectory. The ID of a
+// supervised session changes when it is re-opened.
+func (s *Session) ID() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.id
+}
+
 // MakeActionHandle returns a unique ActionHandle. The handle's ID will be
 // unique among action handles created with this method with the same receiver
 // s. IDs will be assigned sequentially starting from 1. This is safe to call
@@ -204,7 +259,7 @@ func (s *Session) AddAction(ad *ActionDescription) (ActionHandle, error) {
 // This returns a list of handles to the added actions, in the same order as
 // the actions appear in ads.
 func (s *Session) AddActions(ads ...*ActionDescription) ([]ActionHandle, error) {
-	if s.ended {
+	if s.isEnded() {
 		return nil, ErrSessionEnded
 	}
 	actionProtos := make([]*typespb.ActionInstance, len(ads))
@@ -274,7 +329,7 @@ func (s *Session) AddActions(ads ...*ActionDescription) ([]ActionHandle, error)
 // Free-standing reactions are not associated with any action and are active as long as the session
 // is active. Returns the error if any occurred.
 func (s *Session) AddFreestandingReactions(reactions ...*Reaction) error {
-	if s.ended {
+	if s.isEnded() {
 		return ErrSessionEnded
 	}
 	var reactionProtos []*typespb.Reaction
@@ -324,7 +379,7 @@ func (s *Session) AddFreestandingReactions(reactions ...*Reaction) error {
 
 // RemoveActions removes a list of Actions from the session.
 func (s *Session) RemoveActions(ahs ...ActionHandle) error {
-	if s.ended {
+	if s.isEnded() {
 		return ErrSessionEnded
 	}
 	ids := make([]int64, len(ahs))
@@ -345,7 +400,7 @@ func (s *Session) RemoveActions(ahs ...ActionHandle) error {
 
 // ClearAllActionsAndReactions removes all Actions and Reactions from the session.
 func (s *Session) ClearAllActionsAndReactions() error {
-	if s.ended {
+	if s.isEnded() {
 		return ErrSessionEnded
 	}
 	req := &servicepb.OpenSessionRequest{
@@ -359,7 +414,7 @@ func (s *Session) ClearAllActionsAndReactions() error {
 
 // StartAction starts an Action and stops all active actions.
 func (s *Session) StartAction(ah ActionHandle) error {
-	if s.ended {
+	if s.isEnded() {
 		return ErrSessionEnded
 	}
 	if ah.IsZero() {
@@ -378,7 +433,7 @@ func (s *Session) StartAction(ah ActionHandle) error {
 // StartParallelAction starts an Action in parallel to active actions.
 // It will preempt all active actions with an overlapping part set.
 func (s *Session) StartParallelAction(ah ActionHandle) error {
-	if s.ended {
+	if s.isEnded() {
 		return ErrSessionEnded
 	}
 	if ah.IsZero() {
@@ -397,7 +452,7 @@ func (s *Session) StartParallelAction(ah ActionHandle) error {
 // StartActions starts multiple actions specified in `ahs`. Depending on `stop_active_actions`,
 // all active actions remain active (if no new action has an overlapping part set) or will be stopped.
 func (s *Session) StartActions(ahs []ActionHandle, stopActiveActions bool) error {
-	if s.ended {
+	if s.isEnded() {
 		return ErrSessionEnded
 	}
 	if len(ahs) == 0 {
@@ -422,7 +477,7 @@ func (s *Session) StartActions(ahs []ActionHandle, stopActiveActions bool) error
 
 // OpenWriteStream opens a new stream to send messages to a running action.
 func (s *Session) OpenWriteStream(ctx context.Context, ah ActionHandle, field string) (*WriteStream, error) {
-	if s.ended {
+	if s.isEnded() {
 		return nil, ErrSessionEnded
 	}
 	ctx = s.client.addOutgoingMetadata(ctx)
@@ -432,7 +487,7 @@ func (s *Session) OpenWriteStream(ctx context.Context, ah ActionHandle, field st
 	}
 	req := &servicepb.OpenWriteStreamRequest{
 		AddWriteStream: &servicepb.AddStreamRequest{ActionId: uint64(ah.ID()), FieldName: field},
-		SessionId:      s.id,
+		SessionId:      s.ID(),
 	}
 	if err = sc.Send(req); err != nil {
 		return nil, err
@@ -445,7 +500,7 @@ func (s *Session) OpenWriteStream(ctx context.Context, ah ActionHandle, field st
 		return nil, fmt.Errorf("AddStream failed with code: %v", resp.GetAddStreamResponse().GetStatus().GetCode())
 	}
 	ws := &WriteStream{
-		sessionID: s.id,
+		sessionID: req.SessionId,
 		action:    ah,
 		client:    sc,
 	}
@@ -455,11 +510,11 @@ func (s *Session) OpenWriteStream(ctx context.Context, ah ActionHandle, field st
 
 // OpenReadStream opens a new stream to receive messages from a running action.
 func (s *Session) OpenReadStream(ah ActionHandle) (*ReadStream, error) {
-	if s.ended {
+	if s.isEnded() {
 		return nil, ErrSessionEnded
 	}
 	return &ReadStream{
-		sessionID: s.id,
+		sessionID: s.ID(),
 		action:    ah,
 		client:    s.client,
 	}, nil
@@ -469,7 +524,7 @@ func (s *Session) OpenReadStream(ah ActionHandle) (*ReadStream, error) {
 // until either (i) an event is received, OR (ii) if cancel is non-nil, the
 // cancel channel is written to or closed, OR (iii) the session ends.
 func (s *Session) NextEvent(cancel <-chan struct{}) (Event, error) {
-	if s.ended {
+	if s.isEnded() {
 		return nil, ErrSessionEnded
 	}
 	select {
@@ -486,7 +541,70 @@ func (s *Session) NextEvent(cancel <-chan struct{}) (Event, error) {
 	}
 }
 
+// Events returns an iterator over the session's events, which ends with a
+// non-nil error when NextEvent fails or ctx is done. Like NextEvent, it should
+// not be used concurrently with other consumers of the session's events.
+func (s *Session) Events(ctx context.Context) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		for {
+			event, err := s.NextEvent(ctx.Done())
+			if errors.Is(err, ErrCanceled) {
+				err = fmt.Errorf("%w: %w", ErrCanceled, ctx.Err())
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}
+}
+
+// EventResult is an event or the error that ended the events of a session.
+type EventResult struct {
+	Event Event
+	Err   error
+}
+
+// SubscribeEvents returns a channel that receives the session's events, for
+// use in select statements. The last value received has a non-nil Err, after
+// which the channel is closed. Canceling ctx ends the subscription. Like
+// NextEvent, it should not be used concurrently with other consumers of the
+// session's events.
+func (s *Session) SubscribeEvents(ctx context.Context) <-chan EventResult {
+	ch := make(chan EventResult)
+	go func() {
+		defer close(ch)
+		for event, err := range s.Events(ctx) {
+			select {
+			case ch <- EventResult{Event: event, Err: err}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
 func (s *Session) checkSendAndRecv(req *servicepb.OpenSessionRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.sendAndRecvLocked(req); err != nil {
+		return err
+	}
+	if s.supervision != nil {
+		s.supervision.track(req)
+	}
+	return nil
+}
+
+// sendAndRecvLocked sends req on the session stream and checks the response.
+// Requires s.mu.
+func (s *Session) sendAndRecvLocked(req *servicepb.OpenSessionRequest) error {
+	s.record(requestEntry(req))
+	s.sentRequests = true
 	if err := s.session.Send(req); err != nil {
 		return err
 	}
@@ -494,6 +612,7 @@ func (s *Session) checkSendAndRecv(req *servicepb.OpenSessionRequest) error {
 	if err != nil {
 		return err
 	}
+	s.record(responseEntry(resp))
 	if resp.Status.Code != int32(codespb.OK) {
 		return fmt.Errorf("session gRPC failed: %v", resp.Status.Message)
 	}
@@ -0,0 +1,263 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sessionreplay replays ICON session recordings, as written by
+// icon.Session.StartRecording, against an ICON server and compares the
+// resulting reaction events with the recorded ones.
+package sessionreplay
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+
+	"intrinsic/icon/go/icon"
+
+	servicepb "intrinsic/icon/proto/v1/service_go_proto"
+	recordingpb "intrinsic/icon/proto/v1/session_recording_go_proto"
+	typespb "intrinsic/icon/proto/v1/types_go_proto"
+)
+
+const defaultEventTimeout = 5 * time.Second
+
+// ErrInvalidRecording occurs when a recording does not have the structure
+// described in session_recording.proto.
+var ErrInvalidRecording = errors.New("invalid session recording")
+
+// ReadRecording reads all entries of a session recording from r.
+func ReadRecording(r io.Reader) ([]*recordingpb.SessionRecordEntry, error) {
+	br := bufio.NewReader(r)
+	var entries []*recordingpb.SessionRecordEntry
+	for {
+		e := &recordingpb.SessionRecordEntry{}
+		if err := protodelim.UnmarshalFrom(br, e); err != nil {
+			if errors.Is(err, io.EOF) {
+				return entries, nil
+			}
+			return nil, fmt.Errorf("%w: entry %d: %v", ErrInvalidRecording, len(entries), err)
+		}
+		entries = append(entries, e)
+	}
+}
+
+// Option configures Replay.
+type Option func(*options)
+
+type options struct {
+	pace             bool
+	eventTimeout     time.Duration
+	serverInstanceID string
+}
+
+// WithoutPacing makes Replay send requests as fast as possible, instead of
+// reproducing the time between requests in the recording.
+func WithoutPacing() Option {
+	return func(o *options) {
+		o.pace = false
+	}
+}
+
+// WithEventTimeout sets how long Replay collects reaction events after the last
+// request. Defaults to 5s. Replay always waits for the whole timeout, unless the
+// server closes the reaction stream, so that reaction events beyond the
+// recorded ones are reported as well.
+func WithEventTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.eventTimeout = d
+	}
+}
+
+// WithServerInstanceHeaderValue sets the resource instance header of all
+// requests, see icon.WithServerInstanceHeaderValue.
+func WithServerInstanceHeaderValue(name string) Option {
+	return func(o *options) {
+		o.serverInstanceID = name
+	}
+}
+
+// Mismatch describes a difference between a recording and its replay.
+type Mismatch struct {
+	// Entry is the index of the recorded entry that the replay differs from, or
+	// -1 for events that the replay produced in addition to the recorded ones.
+	Entry int
+	// Description describes the difference.
+	Description string
+}
+
+func (m Mismatch) String() string {
+	if m.Entry < 0 {
+		return m.Description
+	}
+	return fmt.Sprintf("entry %d: %s", m.Entry, m.Description)
+}
+
+// Result is the result of replaying a session recording.
+type Result struct {
+	// SessionID is the ID of the replayed session on the server.
+	SessionID int64
+	// Responses are the responses of the server to the replayed requests, in
+	// the order of the requests.
+	Responses []*servicepb.OpenSessionResponse
+	// Events are the reaction events that the server sent during the replay.
+	Events []*typespb.ReactionEvent
+	// Mismatches lists the differences to the recording. It is empty if the
+	// replay reproduced the recorded response statuses and reaction events.
+	Mismatches []Mismatch
+}
+
+// recordedReaction is a reaction event of a recording and its entry index.
+type recordedReaction struct {
+	entry int
+	event *typespb.ReactionEvent
+}
+
+// Replay opens a new session on the ICON server at conn and sends it the
+// requests of a recording, e.g. as returned by ReadRecording. Afterwards, it
+// compares the response statuses and the sequence of reaction events with the
+// recorded ones.
+//
+// Action, reaction and signal IDs are chosen by the client, so they are
+// compared as is. Timestamps are only used for pacing and not compared. The
+// returned error is only non-nil if the recording is invalid or the replay
+// could not be carried out, not if it differs from the recording.
+func Replay(ctx context.Context, conn *grpc.ClientConn, recording []*recordingpb.SessionRecordEntry, opts ...Option) (*Result, error) {
+	o := options{pace: true, eventTimeout: defaultEventTimeout}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if len(recording) < 2 || recording[0].GetRequest().GetInitialSessionData() == nil || recording[1].GetResponse() == nil {
+		return nil, fmt.Errorf("%w: must start with the initial request and response", ErrInvalidRecording)
+	}
+	var recordedReactions []recordedReaction
+	for i, e := range recording {
+		if r := e.GetReaction(); r != nil {
+			recordedReactions = append(recordedReactions, recordedReaction{entry: i, event: r.GetReactionEvent()})
+		}
+	}
+
+	ctx, cancel := context.WithCancel(icon.SetResourceInstanceHeaderOutgoingMetadata(ctx, o.serverInstanceID))
+	defer cancel()
+	client := servicepb.NewIconApiClient(conn)
+	stream, err := client.OpenSession(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open session: %w", err)
+	}
+	defer stream.CloseSend()
+
+	res := &Result{}
+	var lastRequest time.Time
+	var watch servicepb.IconApi_WatchReactionsClient
+	for i := 0; i < len(recording); i++ {
+		req := recording[i].GetRequest()
+		if req == nil {
+			continue
+		}
+		if i+1 == len(recording) || recording[i+1].GetResponse() == nil {
+			return nil, fmt.Errorf("%w: entry %d: request is not followed by a response", ErrInvalidRecording, i)
+		}
+		if sent := recording[i].GetTimestamp().AsTime(); o.pace && !lastRequest.IsZero() {
+			select {
+			case <-time.After(sent.Sub(lastRequest)):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		lastRequest = recording[i].GetTimestamp().AsTime()
+
+		if err := stream.Send(req); err != nil {
+			return nil, fmt.Errorf("failed to send request of entry %d: %w", i, err)
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return nil, fmt.Errorf("failed to receive response to entry %d: %w", i, err)
+		}
+		res.Responses = append(res.Responses, resp)
+		i++
+		if got, want := resp.GetStatus().GetCode(), recording[i].GetResponse().GetStatus().GetCode(); got != want {
+			res.Mismatches = append(res.Mismatches, Mismatch{
+				Entry:       i,
+				Description: fmt.Sprintf("response has status code %d (%q), recorded %d", got, resp.GetStatus().GetMessage(), want),
+			})
+		}
+
+		if watch == nil {
+			if resp.GetInitialSessionData() == nil {
+				return nil, fmt.Errorf("initial response has no session data: %v", resp.GetStatus().GetMessage())
+			}
+			res.SessionID = resp.GetInitialSessionData().GetSessionId()
+			if watch, err = client.WatchReactions(ctx, &servicepb.WatchReactionsRequest{SessionId: res.SessionID}); err != nil {
+				return nil, fmt.Errorf("unable to watch for reactions: %w", err)
+			}
+			// Wait for the first, empty message so that no reactions are missed.
+			if _, err := watch.Recv(); err != nil {
+				return nil, fmt.Errorf("unable to watch for reactions: %w", err)
+			}
+		}
+	}
+
+	events := make(chan *typespb.ReactionEvent)
+	go func() {
+		defer close(events)
+		for {
+			resp, err := watch.Recv()
+			if err != nil {
+				return
+			}
+			if resp.GetReactionEvent().GetReactionId() == 0 {
+				continue
+			}
+			select {
+			case events <- resp.GetReactionEvent():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	timeout := time.After(o.eventTimeout)
+collect:
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				break collect
+			}
+			res.Events = append(res.Events, e)
+		case <-timeout:
+			break collect
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	for i, r := range recordedReactions {
+		if i >= len(res.Events) {
+			res.Mismatches = append(res.Mismatches, Mismatch{Entry: r.entry, Description: fmt.Sprintf("missing reaction event %v", r.event)})
+			continue
+		}
+		if !proto.Equal(r.event, res.Events[i]) {
+			res.Mismatches = append(res.Mismatches, Mismatch{Entry: r.entry, Description: fmt.Sprintf("got reaction event %v, recorded %v", res.Events[i], r.event)})
+		}
+	}
+	for _, e := range res.Events[min(len(recordedReactions), len(res.Events)):] {
+		res.Mismatches = append(res.Mismatches, Mismatch{Entry: -1, Description: fmt.Sprintf("unexpected reaction event %v", e)})
+	}
+	return res, nil
+}
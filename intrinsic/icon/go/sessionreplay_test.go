@@ -0,0 +1,161 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sessionreplay
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"intrinsic/icon/go/icon"
+	"intrinsic/icon/go/icontest"
+	"intrinsic/icon/go/sessionutil"
+
+	recordingpb "intrinsic/icon/proto/v1/session_recording_go_proto"
+	typespb "intrinsic/icon/proto/v1/types_go_proto"
+)
+
+const testActionType = "xfa.test_move"
+
+func newTestServer(t *testing.T) *icontest.Server {
+	t.Helper()
+	srv := icontest.NewServer(
+		icontest.WithParts(&typespb.PartConfig{Name: "arm"}),
+		icontest.WithActionSignatures(&typespb.ActionSignature{ActionTypeName: testActionType}),
+	)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// record records a session that starts an action with a reaction to the
+// global state variable "go".
+func record(t *testing.T) []*recordingpb.SessionRecordEntry {
+	t.Helper()
+	srv := newTestServer(t)
+	ctx := context.Background()
+	client, err := srv.NewClient(ctx)
+	if err != nil {
+		t.Fatalf("NewClient() failed: %v", err)
+	}
+	defer client.Close()
+	session, err := client.StartSession(ctx, []string{"arm"}, nil)
+	if err != nil {
+		t.Fatalf("StartSession() failed: %v", err)
+	}
+	defer session.End()
+
+	var buf bytes.Buffer
+	if err := session.StartRecording(&buf); err != nil {
+		t.Fatalf("StartRecording() failed: %v", err)
+	}
+	done := session.MakeEventSignal()
+	action, err := session.AddAction(&icon.ActionDescription{
+		Handle:     session.MakeActionHandle(),
+		ActionType: testActionType,
+		SlotData:   icon.FromPartName("arm"),
+		Reactions:  []*icon.Reaction{icon.NewReaction(icon.IsTrue("go"), icon.EmitEventSignal(done))},
+	})
+	if err != nil {
+		t.Fatalf("AddAction() failed: %v", err)
+	}
+	if err := session.StartAction(action); err != nil {
+		t.Fatalf("StartAction() failed: %v", err)
+	}
+	if err := srv.SetStateVariable("go", true); err != nil {
+		t.Fatalf("SetStateVariable() failed: %v", err)
+	}
+	cancel := make(chan struct{})
+	timer := time.AfterFunc(5*time.Second, func() { close(cancel) })
+	defer timer.Stop()
+	if err := sessionutil.WaitForEventSignal(session, done, cancel); err != nil {
+		t.Fatalf("WaitForEventSignal() failed: %v", err)
+	}
+	if err := session.StopRecording(); err != nil {
+		t.Fatalf("StopRecording() failed: %v", err)
+	}
+
+	entries, err := ReadRecording(&buf)
+	if err != nil {
+		t.Fatalf("ReadRecording() failed: %v", err)
+	}
+	// Initial request and response, AddAction, StartAction and the reaction.
+	if len(entries) != 7 {
+		t.Fatalf("ReadRecording() returned %d entries, want 7: %v", len(entries), entries)
+	}
+	return entries
+}
+
+func replay(t *testing.T, srv *icontest.Server, recording []*recordingpb.SessionRecordEntry) *Result {
+	t.Helper()
+	ctx := context.Background()
+	conn, err := srv.Dial(ctx)
+	if err != nil {
+		t.Fatalf("Dial() failed: %v", err)
+	}
+	defer conn.Close()
+	res, err := Replay(ctx, conn, recording, WithoutPacing(), WithEventTimeout(time.Second))
+	if err != nil {
+		t.Fatalf("Replay() failed: %v", err)
+	}
+	return res
+}
+
+func TestReplay(t *testing.T) {
+	recording := record(t)
+
+	srv := newTestServer(t)
+	if err := srv.SetStateVariable("go", true); err != nil {
+		t.Fatalf("SetStateVariable() failed: %v", err)
+	}
+	if res := replay(t, srv, recording); len(res.Mismatches) != 0 || len(res.Events) != 1 {
+		t.Errorf("Replay() = %+v, want one event and no mismatches", res)
+	}
+
+	// Without the state variable, the reaction does not occur.
+	if res := replay(t, newTestServer(t), recording); len(res.Mismatches) != 1 || res.Mismatches[0].Entry != 6 {
+		t.Errorf("Replay() returned mismatches %v, want a missing event at entry 6", res.Mismatches)
+	}
+}
+
+func TestReplayExtraEvent(t *testing.T) {
+	recording := record(t)
+
+	// Without the recorded reaction event, the event of the replay is
+	// unexpected.
+	srv := newTestServer(t)
+	if err := srv.SetStateVariable("go", true); err != nil {
+		t.Fatalf("SetStateVariable() failed: %v", err)
+	}
+	res := replay(t, srv, recording[:6])
+	if len(res.Events) != 1 || len(res.Mismatches) != 1 || res.Mismatches[0].Entry != -1 {
+		t.Errorf("Replay() = %+v, want one event and an unexpected event mismatch", res)
+	}
+}
+
+func TestReplayInvalidRecording(t *testing.T) {
+	recording := record(t)
+	conn, err := newTestServer(t).Dial(context.Background())
+	if err != nil {
+		t.Fatalf("Dial() failed: %v", err)
+	}
+	defer conn.Close()
+	if _, err := Replay(context.Background(), conn, recording[2:], WithoutPacing()); err == nil {
+		t.Errorf("Replay() of a recording without initial request succeeded, want error")
+	}
+	if _, err := Replay(context.Background(), conn, recording[:3], WithoutPacing()); err == nil {
+		t.Errorf("Replay() of a recording with a request without response succeeded, want error")
+	}
+}
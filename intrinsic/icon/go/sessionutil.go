@@ -16,9 +16,16 @@
 package sessionutil
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"intrinsic/icon/go/icon"
 )
 
+// ErrTimeout occurs when waiting for event signals does not finish in time.
+var ErrTimeout = errors.New("timed out waiting for event signals")
+
 // NextEventProvider is an interface for receiving icon session events.
 // The icon.Session struct implements this interface.
 type NextEventProvider interface {
@@ -44,3 +51,68 @@ func WaitForEventSignal(s NextEventProvider, es icon.EventSignal, cancel <-chan
 		}
 	}
 }
+
+// WaitForAnyEventSignal calls s.NextEvent repeatedly until an event matching
+// one of signals is received and returns that signal. It returns an error
+// wrapping ErrTimeout if no such event is received within timeout, or the
+// error of NextEvent. A timeout <= 0 waits indefinitely.
+func WaitForAnyEventSignal(s NextEventProvider, timeout time.Duration, signals ...icon.EventSignal) (icon.EventSignal, error) {
+	pending := make(map[icon.EventSignal]bool, len(signals))
+	for _, es := range signals {
+		pending[es] = true
+	}
+	var received icon.EventSignal
+	err := waitForEventSignals(s, timeout, func(es icon.EventSignal) bool {
+		if pending[es] {
+			received = es
+			return true
+		}
+		return false
+	})
+	return received, err
+}
+
+// WaitForAllEventSignals calls s.NextEvent repeatedly until events matching
+// all of signals have been received, in any order. It returns an error
+// wrapping ErrTimeout if they are not all received within timeout, or the
+// error of NextEvent. A timeout <= 0 waits indefinitely.
+func WaitForAllEventSignals(s NextEventProvider, timeout time.Duration, signals ...icon.EventSignal) error {
+	pending := make(map[icon.EventSignal]bool, len(signals))
+	for _, es := range signals {
+		pending[es] = true
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	err := waitForEventSignals(s, timeout, func(es icon.EventSignal) bool {
+		delete(pending, es)
+		return len(pending) == 0
+	})
+	if errors.Is(err, ErrTimeout) {
+		return fmt.Errorf("%w: %d of %d signals not received", err, len(pending), len(signals))
+	}
+	return err
+}
+
+// waitForEventSignals calls s.NextEvent until done returns true for the signal
+// of a received reaction event.
+func waitForEventSignals(s NextEventProvider, timeout time.Duration, done func(icon.EventSignal) bool) error {
+	var cancel chan struct{}
+	if timeout > 0 {
+		cancel = make(chan struct{})
+		t := time.AfterFunc(timeout, func() { close(cancel) })
+		defer t.Stop()
+	}
+	for {
+		event, err := s.NextEvent(cancel)
+		if err != nil {
+			if errors.Is(err, icon.ErrCanceled) && cancel != nil {
+				return fmt.Errorf("%w after %v", ErrTimeout, timeout)
+			}
+			return err
+		}
+		if e, ok := event.(*icon.ReactionEvent); ok && done(e.EventSignal) {
+			return nil
+		}
+	}
+}
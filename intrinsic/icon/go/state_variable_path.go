@@ -16,6 +16,9 @@ package icon
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -30,6 +33,49 @@ const (
 	safetyNodeName             = "Safety"
 )
 
+// stateVariableLeaf describes the last node of a part state variable path as
+// generated by the builders in this file. size is the number of valid indices
+// of an indexed leaf, or 0 if the number of indices depends on the part.
+type stateVariableLeaf struct {
+	indexed bool
+	size    uint64
+}
+
+// partStateVariables lists the leaves that the builders in this file generate
+// for each part node, except for the ADIO part, whose paths have an
+// additional block node.
+var partStateVariables = map[string]map[string]stateVariableLeaf{
+	armPartNodeName: {
+		"sensed_position":                  {indexed: true},
+		"sensed_velocity":                  {indexed: true},
+		"sensed_acceleration":              {indexed: true},
+		"sensed_torque":                    {indexed: true},
+		"base_twist_tip_sensed":            {indexed: true, size: 6},
+		"base_linear_velocity_tip_sensed":  {},
+		"base_angular_velocity_tip_sensed": {},
+		"current_control_mode":             {},
+	},
+	ftPartNodeName: {
+		"wrench_at_tip":           {indexed: true, size: 6},
+		"force_magnitude_at_tip":  {},
+		"torque_magnitude_at_tip": {},
+		"wrench_stability_index":  {},
+	},
+	gripperPartNodeName: {
+		"sensed_state":  {},
+		"opening_width": {},
+	},
+	rangefinderPartNodeName: {
+		"distance": {},
+	},
+}
+
+// adioSignalNodeNames are the signal type nodes of ADIO part state variable
+// paths.
+var adioSignalNodeNames = map[string]bool{"di": true, "do": true, "ai": true, "ao": true}
+
+var stateVariablePathNodeRegex = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)(?:\[([0-9]+)\])?$`)
+
 // stateVariablePathNode represents one node of a StateVariablePath consisting of a node name and an optional index.
 type stateVariablePathNode struct {
 	name  string
@@ -262,3 +308,85 @@ func SafetyEnableButtonStatus() string {
 	builder.addNodes(safetyNodeName, "enable_button_status")
 	return builder.build()
 }
+
+// IsStateVariablePath returns whether stateVar refers to a part or safety
+// state variable, as opposed to a state variable of an action.
+func IsStateVariablePath(stateVar string) bool {
+	return strings.HasPrefix(stateVar, stateVariablePathPrefix)
+}
+
+// parseStateVariablePath splits a state variable path into its nodes.
+func parseStateVariablePath(path string) ([]stateVariablePathNode, error) {
+	if !IsStateVariablePath(path) {
+		return nil, fmt.Errorf("state variable path %q does not start with %q", path, stateVariablePathPrefix)
+	}
+	var nodes []stateVariablePathNode
+	for _, s := range strings.Split(strings.TrimPrefix(path, stateVariablePathPrefix), stateVariablePathSeparator) {
+		m := stateVariablePathNodeRegex.FindStringSubmatch(s)
+		if m == nil {
+			return nil, fmt.Errorf("invalid node %q in state variable path %q", s, path)
+		}
+		node := stateVariablePathNode{name: m[1]}
+		if m[2] != "" {
+			index, err := strconv.ParseUint(m[2], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid index in node %q of state variable path %q: %w", s, path, err)
+			}
+			node.index = &index
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// ValidateStateVariablePath checks that path is a state variable path that one
+// of the builders in this file generates, e.g. "@arm.ArmPart.sensed_position[0]".
+// If parts is not nil, the path must also refer to one of the given parts.
+func ValidateStateVariablePath(path string, parts []string) error {
+	nodes, err := parseStateVariablePath(path)
+	if err != nil {
+		return err
+	}
+	if nodes[0].name == safetyNodeName {
+		if len(nodes) != 2 || nodes[0].index != nil || nodes[1].name != "enable_button_status" || nodes[1].index != nil {
+			return fmt.Errorf("unknown safety state variable %q, want %q", path, SafetyEnableButtonStatus())
+		}
+		return nil
+	}
+	if len(nodes) < 3 {
+		return fmt.Errorf("state variable path %q is too short, want @<part>.<part type>.<state variable>", path)
+	}
+	part, partType := nodes[0], nodes[1]
+	if part.index != nil || partType.index != nil {
+		return fmt.Errorf("state variable path %q has an index on its part or part type", path)
+	}
+	if parts != nil && !slices.Contains(parts, part.name) {
+		return fmt.Errorf("state variable path %q refers to unknown part %q", path, part.name)
+	}
+	if partType.name == adioPartNodeName {
+		if len(nodes) != 4 || !adioSignalNodeNames[nodes[2].name] || nodes[2].index != nil || nodes[3].index == nil {
+			return fmt.Errorf("invalid ADIO state variable path %q, want @<part>.%s.<di|do|ai|ao>.<block>[<index>]", path, adioPartNodeName)
+		}
+		return nil
+	}
+	leaves, ok := partStateVariables[partType.name]
+	if !ok {
+		return fmt.Errorf("state variable path %q has unknown part type %q", path, partType.name)
+	}
+	if len(nodes) != 3 {
+		return fmt.Errorf("state variable path %q is too long, want @<part>.%s.<state variable>", path, partType.name)
+	}
+	leaf, ok := leaves[nodes[2].name]
+	if !ok {
+		return fmt.Errorf("unknown %s state variable %q in path %q", partType.name, nodes[2].name, path)
+	}
+	switch {
+	case leaf.indexed && nodes[2].index == nil:
+		return fmt.Errorf("state variable %q in path %q requires an index", nodes[2].name, path)
+	case !leaf.indexed && nodes[2].index != nil:
+		return fmt.Errorf("state variable %q in path %q does not take an index", nodes[2].name, path)
+	case leaf.size > 0 && *nodes[2].index >= leaf.size:
+		return fmt.Errorf("index %d of state variable %q in path %q is out of range [0, %d)", *nodes[2].index, nodes[2].name, path, leaf.size)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package icon
+
+import "testing"
+
+// builtStateVariablePaths returns a path generated by every builder, with the
+// largest valid index for indexed leaves of a fixed size.
+func builtStateVariablePaths() []string {
+	const part = "part"
+	return []string{
+		ArmSensedPosition(part, 0),
+		ArmSensedVelocity(part, 1),
+		ArmSensedAcceleration(part, 2),
+		ArmSensedTorque(part, 3),
+		ArmBaseTwistTipSensed(part, TwistRZ),
+		ArmBaseLinearVelocityTipSensed(part),
+		ArmBaseAngularVelocityTipSensed(part),
+		ArmCurrentControlMode(part),
+		FTWrenchAtTip(part, WrenchRZ),
+		FTForceMagnitudeAtTip(part),
+		FTTorqueMagnitudeAtTip(part),
+		FTWrenchStabilityIndex(part),
+		GripperSensedState(part),
+		GripperOpeningWidth(part),
+		RangefinderDistance(part),
+		ADIODigitalInput(part, "block", 0),
+		ADIODigitalOutput(part, "block", 1),
+		ADIOAnalogInput(part, "block", 2),
+		ADIOAnalogOutput(part, "block", 3),
+		SafetyEnableButtonStatus(),
+	}
+}
+
+func TestValidateStateVariablePathAcceptsBuilders(t *testing.T) {
+	covered := map[string]map[string]bool{}
+	for _, path := range builtStateVariablePaths() {
+		if err := ValidateStateVariablePath(path, []string{"part"}); err != nil {
+			t.Errorf("ValidateStateVariablePath(%q) failed: %v", path, err)
+		}
+		nodes, err := parseStateVariablePath(path)
+		if err != nil || len(nodes) != 3 {
+			continue
+		}
+		if covered[nodes[1].name] == nil {
+			covered[nodes[1].name] = map[string]bool{}
+		}
+		covered[nodes[1].name][nodes[2].name] = true
+	}
+	// Every leaf of the table must be generated by a builder, so that the table
+	// does not accept paths that no builder generates.
+	for partType, leaves := range partStateVariables {
+		for leaf := range leaves {
+			if !covered[partType][leaf] {
+				t.Errorf("partStateVariables lists %s.%s, which no builder generates", partType, leaf)
+			}
+		}
+	}
+}
+
+func TestValidateStateVariablePathRejectsInvalidPaths(t *testing.T) {
+	for _, path := range []string{
+		"part.ArmPart.sensed_position[0]",
+		"@part.ArmPart.sensed_position",
+		"@part.ArmPart.current_control_mode[0]",
+		"@part.ArmPart.base_twist_tip_sensed[6]",
+		"@part.ArmPart.unknown",
+		"@part.UnknownPart.distance",
+		"@other.RangefinderPart.distance",
+		"@part.ADIOPart.xx.block[0]",
+		"@part.ADIOPart.di.block",
+		"@Safety.unknown",
+	} {
+		if err := ValidateStateVariablePath(path, []string{"part"}); err == nil {
+			t.Errorf("ValidateStateVariablePath(%q) succeeded, want error", path)
+		}
+	}
+}
@@ -18,11 +18,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"time"
 
 	codespb "google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/proto"
 
+	streamingoutputpb "intrinsic/icon/proto/streaming_output_go_proto"
 	grpcpb "intrinsic/icon/proto/v1/service_go_proto"
 	servicepb "intrinsic/icon/proto/v1/service_go_proto"
 
@@ -94,16 +96,11 @@ type ReadStream struct {
 // Read gets the timestamp (representing the time since the server started)
 // and payload for the most recent output from a running action.
 func (r *ReadStream) Read(ctx context.Context) (time.Duration, *anypb.Any, error) {
-	ctx = r.client.addOutgoingMetadata(ctx)
-	req := &servicepb.GetLatestStreamingOutputRequest{
-		SessionId: r.sessionID,
-		ActionId:  uint64(r.action.ID()),
-	}
-	resp, err := r.client.client.GetLatestStreamingOutput(ctx, req)
+	out, err := r.client.GetLatestStreamingOutput(ctx, r.sessionID, r.action.ID())
 	if err != nil {
 		return 0, nil, fmt.Errorf("failed to read stream: %v", err)
 	}
-	return time.Duration(resp.Output.TimestampNs), resp.Output.Payload, nil
+	return out.Timestamp, out.Payload, nil
 }
 
 // ReadUnpacked accepts an empty proto message that receives the unmarshalled
@@ -119,3 +116,87 @@ func (r *ReadStream) ReadUnpacked(ctx context.Context, dest proto.Message) (time
 	}
 	return t, nil
 }
+
+// TypedReadStream reads the streaming output of an action, which must be of
+// type T.
+type TypedReadStream[T proto.Message] struct {
+	stream *ReadStream
+}
+
+// NewTypedReadStream returns a stream that unpacks the outputs of r into
+// messages of type T.
+func NewTypedReadStream[T proto.Message](r *ReadStream) *TypedReadStream[T] {
+	return &TypedReadStream[T]{stream: r}
+}
+
+// Read returns the most recent output of the action and its timestamp
+// (representing the time since the server started).
+func (r *TypedReadStream[T]) Read(ctx context.Context) (time.Duration, T, error) {
+	var zero T
+	dest := zero.ProtoReflect().New().Interface().(T)
+	t, err := r.stream.ReadUnpacked(ctx, dest)
+	if err != nil {
+		return 0, zero, err
+	}
+	return t, dest, nil
+}
+
+// All returns an iterator over the outputs of the action. It polls the most
+// recent output every interval and yields it if its timestamp changed. The
+// iteration ends with a non-nil error when reading fails or ctx is done.
+func (r *TypedReadStream[T]) All(ctx context.Context, interval time.Duration) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		var last time.Duration
+		first := true
+		for {
+			t, out, err := r.Read(ctx)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if first || t != last {
+				first, last = false, t
+				if !yield(out, nil) {
+					return
+				}
+			}
+			select {
+			case <-ctx.Done():
+				yield(zero, ctx.Err())
+				return
+			case <-time.After(interval):
+			}
+		}
+	}
+}
+
+// StreamingOutput is an output that a running action wrote to its streaming
+// output.
+type StreamingOutput struct {
+	// Timestamp is the control timestamp of the output, i.e., the time since the
+	// server started.
+	Timestamp time.Duration
+	// WallTime is the wall clock time at which the output was written. Zero if
+	// unknown.
+	WallTime time.Time
+	// Payload is the output value.
+	Payload *anypb.Any
+}
+
+func newStreamingOutput(out *streamingoutputpb.StreamingOutput) *StreamingOutput {
+	o := &StreamingOutput{
+		Timestamp: time.Duration(out.GetTimestampNs()),
+		Payload:   out.GetPayload(),
+	}
+	if ns := out.GetWallClockTimestampNs(); ns != 0 {
+		o.WallTime = time.Unix(0, ns)
+	}
+	return o
+}
+
+// UnmarshalTo unmarshals the payload into dest, which must be of the type of
+// the action's streaming output.
+func (o *StreamingOutput) UnmarshalTo(dest proto.Message) error {
+	return anypb.UnmarshalTo(o.Payload, dest, proto.UnmarshalOptions{})
+}
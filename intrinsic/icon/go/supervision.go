@@ -0,0 +1,206 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package icon
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	log "github.com/golang/glog"
+
+	grpcpb "intrinsic/icon/proto/v1/service_go_proto"
+	servicepb "intrinsic/icon/proto/v1/service_go_proto"
+	typespb "intrinsic/icon/proto/v1/types_go_proto"
+	contextpb "intrinsic/logging/proto/context_go_proto"
+)
+
+// SupervisionOption configures StartSupervisedSession.
+type SupervisionOption func(*supervision)
+
+// WithReopen makes a supervised session re-open itself on the same parts
+// after it was lost. It tries up to attempts times, waiting backoff between
+// attempts, and only while the server is enabled.
+func WithReopen(attempts int, backoff time.Duration) SupervisionOption {
+	return func(s *supervision) {
+		s.reopenAttempts = attempts
+		s.reopenBackoff = backoff
+	}
+}
+
+// supervision holds the state of a supervised session that is needed to
+// re-open it.
+type supervision struct {
+	reopenAttempts int
+	reopenBackoff  time.Duration
+	// registered are the actions and reactions that currently exist in the
+	// session. Guarded by Session.mu.
+	registered *typespb.ActionsAndReactions
+}
+
+// track updates the registered actions and reactions after req succeeded.
+func (sup *supervision) track(req *servicepb.OpenSessionRequest) {
+	switch r := req.GetActionRequest().(type) {
+	case *servicepb.OpenSessionRequest_AddActionsAndReactions:
+		sup.registered.ActionInstances = append(sup.registered.ActionInstances, r.AddActionsAndReactions.GetActionInstances()...)
+		sup.registered.Reactions = append(sup.registered.Reactions, r.AddActionsAndReactions.GetReactions()...)
+	case *servicepb.OpenSessionRequest_RemoveActionAndReactionIds:
+		actionIDs := r.RemoveActionAndReactionIds.GetActionInstanceIds()
+		reactionIDs := r.RemoveActionAndReactionIds.GetReactionIds()
+		sup.registered.ActionInstances = slices.DeleteFunc(sup.registered.ActionInstances, func(a *typespb.ActionInstance) bool {
+			return slices.Contains(actionIDs, a.GetActionInstanceId())
+		})
+		// Reactions of removed actions are removed with them.
+		sup.registered.Reactions = slices.DeleteFunc(sup.registered.Reactions, func(r *typespb.Reaction) bool {
+			return slices.Contains(reactionIDs, r.GetReactionInstanceId()) ||
+				(r.ActionAssociation != nil && slices.Contains(actionIDs, r.GetActionAssociation().GetActionInstanceId()))
+		})
+	case *servicepb.OpenSessionRequest_ClearAllActionsReactions:
+		sup.registered = &typespb.ActionsAndReactions{}
+	}
+}
+
+// StartSupervisedSession starts a session like Client.StartSession, but
+// detects when the session's streams to the server are lost, e.g., because
+// of a network outage. The loss is reported as a SessionLostEvent by
+// NextEvent. With WithReopen, the session then re-opens itself on the same
+// parts, re-adds all actions and reactions that it had, and reports a
+// SessionRestoredEvent. Action handles and event signals stay valid, but
+// write streams need to be opened again.
+//
+// A supervised session never enables the server or clears faults (see
+// Client.Enable and Client.ClearFaults), and it does not restart any action
+// after it was re-opened. While the server is not enabled, attempts to
+// re-open the session fail; the session gives up after the number of attempts
+// passed to WithReopen, so somebody else has to enable the server in time.
+// Re-opening the session ends its recording, see StartRecording.
+//
+// client must have been created with InitClient or InitClientFromConn.
+func StartSupervisedSession(ctx context.Context, client Client, parts []string, logContext *contextpb.Context, opts ...SupervisionOption) (*Session, error) {
+	c, ok := client.(*grpcClient)
+	if !ok {
+		return nil, fmt.Errorf("supervised sessions need a client created with InitClient, got %T", client)
+	}
+	sup := &supervision{registered: &typespb.ActionsAndReactions{}}
+	for _, opt := range opts {
+		opt(sup)
+	}
+	return newSession(c.addOutgoingMetadata(ctx), c, parts, logContext, sup)
+}
+
+// sendEvent sends e to the events channel of s, unless ctx is done first.
+func (s *Session) sendEvent(ctx context.Context, e Event) error {
+	select {
+	case s.events <- e:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// supervise watches reactions of a supervised session and re-opens the session
+// whenever its streams are lost, until it is ended by the caller, ctx is
+// canceled or re-opening fails.
+func (s *Session) supervise(ctx context.Context, watchClient grpcpb.IconApi_WatchReactionsClient, parts []string, logContext *contextpb.Context) error {
+	for {
+		err := watchReactions(ctx, watchClient, s.reactData, s.events, s.record)
+		s.mu.Lock()
+		ended, previousID := s.ended, s.id
+		s.mu.Unlock()
+		if ended || ctx.Err() != nil {
+			return err
+		}
+		log.WarningContextf(ctx, "Lost session %d: %v", previousID, err)
+		reopen := s.supervision.reopenAttempts > 0
+		if err := s.sendEvent(ctx, &SessionLostEvent{Err: err, Reopening: reopen}); err != nil {
+			return err
+		}
+		if !reopen {
+			return fmt.Errorf("%w: %v", ErrSessionLost, err)
+		}
+		if watchClient, err = s.reopen(ctx, parts, logContext); err != nil {
+			return fmt.Errorf("%w: failed to re-open session: %v", ErrSessionLost, err)
+		}
+		if err := s.sendEvent(ctx, &SessionRestoredEvent{PreviousID: previousID, ID: s.ID()}); err != nil {
+			return err
+		}
+	}
+}
+
+// reopen re-opens a lost session, trying up to the configured number of
+// attempts.
+func (s *Session) reopen(ctx context.Context, parts []string, logContext *contextpb.Context) (grpcpb.IconApi_WatchReactionsClient, error) {
+	var err error
+	for attempt := 1; attempt <= s.supervision.reopenAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-time.After(s.supervision.reopenBackoff):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		var watchClient grpcpb.IconApi_WatchReactionsClient
+		if watchClient, err = s.reopenOnce(ctx, parts, logContext); err == nil {
+			return watchClient, nil
+		}
+		log.WarningContextf(ctx, "Failed to re-open session on parts %v (attempt %d of %d): %v", parts, attempt, s.supervision.reopenAttempts, err)
+	}
+	return nil, err
+}
+
+// reopenOnce opens a new session on the server, adds the registered actions
+// and reactions to it and makes it the session of s.
+func (s *Session) reopenOnce(ctx context.Context, parts []string, logContext *contextpb.Context) (grpcpb.IconApi_WatchReactionsClient, error) {
+	// ctx already carries the outgoing metadata of the client.
+	status, err := s.client.client.GetOperationalStatus(ctx, &servicepb.GetOperationalStatusRequest{})
+	if err != nil {
+		return nil, err
+	}
+	// Never enable the server or clear faults on behalf of the caller.
+	if state := status.GetOperationalStatus().GetState(); state != typespb.OperationalState_ENABLED {
+		return nil, fmt.Errorf("server is %v, not re-opening the session until it is enabled", state)
+	}
+	session, id, initialEntries, err := openSessionStream(ctx, s.client, parts, logContext)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		session.CloseSend()
+		return nil, ErrSessionEnded
+	}
+	// A recording must not mix sessions: it could not be replayed.
+	if r := s.recorder.Load(); r != nil {
+		r.fail(fmt.Errorf("%w: session %d was re-opened as session %d", ErrRecording, s.id, id))
+	}
+	s.session, s.id, s.initialEntries = session, id, initialEntries
+	if registered := s.supervision.registered; len(registered.GetActionInstances()) > 0 || len(registered.GetReactions()) > 0 {
+		req := &servicepb.OpenSessionRequest{
+			ActionRequest: &servicepb.OpenSessionRequest_AddActionsAndReactions{AddActionsAndReactions: registered},
+		}
+		if err := s.sendAndRecvLocked(req); err != nil {
+			session.CloseSend()
+			return nil, fmt.Errorf("failed to re-add actions and reactions: %w", err)
+		}
+	}
+	watchClient, err := s.client.client.WatchReactions(ctx, &servicepb.WatchReactionsRequest{SessionId: id})
+	if err != nil {
+		session.CloseSend()
+		return nil, fmt.Errorf("unable to watch for reactions: %w", err)
+	}
+	return watchClient, nil
+}
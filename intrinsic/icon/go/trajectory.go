@@ -0,0 +1,71 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package icon
+
+import (
+	"time"
+
+	jointspacepb "intrinsic/icon/proto/joint_space_go_proto"
+)
+
+// TrajectoryPoint is a single joint state of a planned trajectory.
+type TrajectoryPoint struct {
+	// TimeSinceStart is the time of the point relative to the start of its
+	// trajectory segment.
+	TimeSinceStart time.Duration
+	// Position of each joint.
+	Position []float64
+	// Velocity of each joint.
+	Velocity []float64
+	// Acceleration of each joint.
+	Acceleration []float64
+}
+
+// PlannedTrajectory is the trajectory that an action plans to execute, as
+// returned by Client.GetPlannedTrajectory.
+type PlannedTrajectory struct {
+	// Points of all segments in the order in which they were received.
+	Points []TrajectoryPoint
+	// Segments are the trajectory segments as sent by the server, which
+	// additionally contain e.g. the interpolation type.
+	Segments []*jointspacepb.JointTrajectoryPVA
+}
+
+func (t *PlannedTrajectory) addSegment(segment *jointspacepb.JointTrajectoryPVA) {
+	if segment == nil {
+		return
+	}
+	t.Segments = append(t.Segments, segment)
+	times := segment.GetTimeSinceStart()
+	for i, state := range segment.GetState() {
+		p := TrajectoryPoint{
+			Position:     state.GetPosition(),
+			Velocity:     state.GetVelocity(),
+			Acceleration: state.GetAcceleration(),
+		}
+		if i < len(times) {
+			p.TimeSinceStart = times[i].AsDuration()
+		}
+		t.Points = append(t.Points, p)
+	}
+}
+
+// Duration returns the time of the last point of the trajectory.
+func (t *PlannedTrajectory) Duration() time.Duration {
+	if len(t.Points) == 0 {
+		return 0
+	}
+	return t.Points[len(t.Points)-1].TimeSinceStart
+}
@@ -64,9 +64,19 @@ type KVStore interface {
 	// GetAll invokes the valueCallback for a given key that matches the expression.
 	GetAll(key string, valueCallback func(*anypb.Any), ondoneCallback func(string)) (KVQuery, error)
 
+	// GetAllWithKeys invokes the keyValueCallback with the key and the value of
+	// every key that matches the expression, in a single query. The keys include
+	// the prefix of the store, see KeyPrefix.
+	GetAllWithKeys(key string, keyValueCallback func(string, *anypb.Any), ondoneCallback func(string)) (KVQuery, error)
+
 	// ListAllKeys invokes the keyCallback for a given key that matches the expression.
 	ListAllKeys(key string, keyCallback func(string), ondoneCallback func(string)) (KVQuery, error)
 
+	// KeyPrefix returns the prefix that is added to all keys, e.g., to the keys
+	// passed to Set. A key is stored as KeyPrefix() + "/" + key, without a
+	// duplicate slash if key starts with one.
+	KeyPrefix() string
+
 	// Delete removes a value from the store for the given key.
 	Delete(key string) error
 
@@ -141,4 +151,18 @@ var (
 
 	// ErrAborted is returned if the set operation was aborted due to a write race.
 	ErrAborted = fmt.Errorf("aborted")
+
+	// ErrConflict is returned by Store.CompareAndSet if the current version of
+	// a value is not the expected one, if a concurrent write replaced the
+	// version while it was written, or if the KV store aborted the write
+	// because of a write race.
+	//
+	// The KV store has no server-side compare-and-set. CompareAndSet reads the
+	// current version, writes the new one with high consistency and reads it
+	// back. Conflicts between writers that share a Store are always detected,
+	// because the Store serializes their writes. Conflicts with writers in
+	// other processes are only detected if the KV store aborts one of the
+	// writes or the read back sees the other write; otherwise both writes
+	// succeed and one update is lost.
+	ErrConflict = fmt.Errorf("version conflict")
 )
@@ -0,0 +1,277 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsubfake
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"intrinsic/platform/pubsub/golang/kvstore"
+	"intrinsic/platform/pubsub/golang/pubsubinterface"
+
+	"google.golang.org/protobuf/proto"
+
+	anypb "google.golang.org/protobuf/types/known/anypb"
+
+	workcellinfopb "intrinsic/platform/common/proto/workcell_info_go_proto"
+)
+
+// The key prefixes and names used by the pubsub package.
+const (
+	defaultKeyPrefix           = "kv_store"
+	replicationKeyPrefix       = "kv_store_repl"
+	workcellInfoKey            = "workcell_info"
+	globalReplicationNamespace = "global"
+)
+
+// kvStore is an in-memory implementation of kvstore.KVStore. Values are
+// stored in the PubSub that created it, so that writes notify the KV store
+// subscriptions of that PubSub.
+type kvStore struct {
+	ps        *PubSub
+	keyPrefix string
+}
+
+var _ kvstore.KVStore = new(kvStore)
+
+// KeyPrefix returns the prefix that is added to all keys.
+func (kv *kvStore) KeyPrefix() string {
+	if kv.keyPrefix != "" {
+		return kv.keyPrefix
+	}
+	return defaultKeyPrefix
+}
+
+func (kv *kvStore) addKeyPrefix(key string) string {
+	keyPrefix := kv.KeyPrefix()
+	if strings.HasPrefix(key, "/") {
+		return keyPrefix + key
+	}
+	return keyPrefix + "/" + key
+}
+
+func validKey(key string) error {
+	if strings.ContainsAny(key, "*?#[]$") {
+		return fmt.Errorf("key %q must not contain wildcards or any of ?, #, [ and ]", key)
+	}
+	return validKeyExpr(key)
+}
+
+func (kv *kvStore) Set(key string, value proto.Message, highConsistency bool) error {
+	valueAny, ok := value.(*anypb.Any)
+	if !ok {
+		var err error
+		valueAny, err = anypb.New(value)
+		if err != nil {
+			return err
+		}
+	}
+	return kv.SetAny(key, valueAny, highConsistency)
+}
+
+// SetAny stores the value. The fake store is always consistent, so
+// highConsistency has no effect.
+func (kv *kvStore) SetAny(key string, valueAny *anypb.Any, highConsistency bool) error {
+	prefixedKey := kv.addKeyPrefix(key)
+	if err := validKey(prefixedKey); err != nil {
+		return err
+	}
+	bytes, err := proto.Marshal(valueAny)
+	if err != nil {
+		return err
+	}
+	kv.ps.mu.Lock()
+	defer kv.ps.mu.Unlock()
+	if kv.ps.closed {
+		return errClosed
+	}
+	kv.ps.values[prefixedKey] = proto.Clone(valueAny).(*anypb.Any)
+	kv.ps.deliver(prefixedKey, bytes)
+	return nil
+}
+
+// Get returns the value for the given key. Like the pubsub package, it
+// returns the first matching value for a key with wildcards, here in key
+// order. The fake store answers immediately, so timeout has no effect.
+func (kv *kvStore) Get(key string, timeout *time.Duration) (*anypb.Any, error) {
+	return kv.getRaw(kv.addKeyPrefix(key))
+}
+
+func (kv *kvStore) getRaw(rawKey string) (*anypb.Any, error) {
+	kv.ps.mu.Lock()
+	defer kv.ps.mu.Unlock()
+	if hasWildcards(rawKey) {
+		if err := validKeyExpr(rawKey); err != nil {
+			return nil, err
+		}
+		matching := kv.matchingLocked(rawKey)
+		if len(matching) == 0 {
+			return nil, fmt.Errorf("no key matches %q: %w", rawKey, kvstore.ErrNotFound)
+		}
+		rawKey = matching[0]
+	}
+	value, ok := kv.ps.values[rawKey]
+	if !ok {
+		return nil, fmt.Errorf("%q not found: %w", rawKey, kvstore.ErrNotFound)
+	}
+	return proto.Clone(value).(*anypb.Any), nil
+}
+
+// matchingLocked returns the prefixed keys that match a prefixed key
+// expression, in lexicographic order. Requires kv.ps.mu.
+func (kv *kvStore) matchingLocked(rawKeyExpr string) []string {
+	var keys []string
+	for k := range kv.ps.values {
+		if keyExprMatches(rawKeyExpr, k) {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// GetAll invokes valueCallback for every value whose key matches the
+// expression and then ondoneCallback, asynchronously like the pubsub package.
+func (kv *kvStore) GetAll(key string, valueCallback func(*anypb.Any), ondoneCallback func(string)) (kvstore.KVQuery, error) {
+	return kv.query(kv.addKeyPrefix(key), func(rawKey string) {
+		if value, err := kv.getRaw(rawKey); err == nil {
+			valueCallback(value)
+		}
+	}, ondoneCallback)
+}
+
+// GetAllWithKeys invokes keyValueCallback for every key that matches the
+// expression and its value and then ondoneCallback, asynchronously like the
+// pubsub package. The keys include their prefix.
+func (kv *kvStore) GetAllWithKeys(key string, keyValueCallback func(string, *anypb.Any), ondoneCallback func(string)) (kvstore.KVQuery, error) {
+	return kv.query(kv.addKeyPrefix(key), func(rawKey string) {
+		if value, err := kv.getRaw(rawKey); err == nil {
+			keyValueCallback(rawKey, value)
+		}
+	}, ondoneCallback)
+}
+
+// ListAllKeys invokes keyCallback for every key that matches the expression
+// and then ondoneCallback, asynchronously like the pubsub package. The keys
+// include their prefix.
+func (kv *kvStore) ListAllKeys(key string, keyCallback func(string), ondoneCallback func(string)) (kvstore.KVQuery, error) {
+	return kv.query(kv.addKeyPrefix(key), keyCallback, ondoneCallback)
+}
+
+func (kv *kvStore) query(rawKeyExpr string, callback func(rawKey string), ondoneCallback func(string)) (kvstore.KVQuery, error) {
+	if err := validKeyExpr(rawKeyExpr); err != nil {
+		return nil, err
+	}
+	kv.ps.mu.Lock()
+	keys := kv.matchingLocked(rawKeyExpr)
+	kv.ps.mu.Unlock()
+	go func() {
+		for _, k := range keys {
+			callback(k)
+		}
+		ondoneCallback(rawKeyExpr)
+	}()
+	return query{}, nil
+}
+
+type query struct{}
+
+func (query) Close() {}
+
+// Delete removes the values whose keys match the given key expression and
+// notifies the subscriptions of each deleted key.
+func (kv *kvStore) Delete(key string) error {
+	prefixedKey := kv.addKeyPrefix(key)
+	if err := validKeyExpr(prefixedKey); err != nil {
+		return err
+	}
+	kv.ps.mu.Lock()
+	defer kv.ps.mu.Unlock()
+	if kv.ps.closed {
+		return errClosed
+	}
+	if !hasWildcards(prefixedKey) {
+		delete(kv.ps.values, prefixedKey)
+		kv.ps.deliver(prefixedKey, nil)
+		return nil
+	}
+	for _, k := range kv.matchingLocked(prefixedKey) {
+		delete(kv.ps.values, k)
+		kv.ps.deliver(k, nil)
+	}
+	return nil
+}
+
+// AdminCloudCopy records the value of sourceKey as copied to targetKey, see
+// PubSub.CloudCopies.
+func (kv *kvStore) AdminCloudCopy(sourceKey string, targetKey string, timeout time.Duration) error {
+	if err := validKeyExpr(sourceKey); err != nil {
+		return err
+	}
+	if err := validKeyExpr(targetKey); err != nil {
+		return err
+	}
+	value, err := kv.Get(sourceKey, &timeout)
+	if err != nil {
+		return err
+	}
+	kv.ps.mu.Lock()
+	defer kv.ps.mu.Unlock()
+	kv.ps.cloudCopies[targetKey] = value
+	return nil
+}
+
+func (kv *kvStore) Subscribe(
+	keyExpression string, config pubsubinterface.TopicConfig, exemplar proto.Message,
+	msgCallback func(string, proto.Message),
+	deletionCallback func(string),
+	errCallback func(string, *anypb.Any, error),
+) (pubsubinterface.Subscription, error) {
+	typeCheckingCallback := func(key string, value *anypb.Any) {
+		msg := exemplar.ProtoReflect().New().Interface()
+		if err := value.UnmarshalTo(msg); err != nil {
+			errCallback(key, value, err)
+			return
+		}
+		msgCallback(key, msg)
+	}
+	return kv.ps.NewKVStoreSubscription(kv.addKeyPrefix(keyExpression), config, typeCheckingCallback, deletionCallback)
+}
+
+func (kv *kvStore) SubscribeToRawValues(
+	keyExpression string, config pubsubinterface.TopicConfig,
+	msgCallback func(string, *anypb.Any),
+	deletionCallback func(string),
+) (pubsubinterface.Subscription, error) {
+	return kv.ps.NewKVStoreSubscription(kv.addKeyPrefix(keyExpression), config, msgCallback, deletionCallback)
+}
+
+func (kv *kvStore) GetWorkcellReplicationNamespace() (string, error) {
+	value, err := kv.getRaw(defaultKeyPrefix + "/" + workcellInfoKey)
+	if err != nil {
+		return "", err
+	}
+	workcellInfo := &workcellinfopb.WorkcellInfo{}
+	if err := value.UnmarshalTo(workcellInfo); err != nil {
+		return "", err
+	}
+	return workcellInfo.GetWorkcellName(), nil
+}
+
+func (kv *kvStore) GetGlobalReplicationNamespace() string {
+	return globalReplicationNamespace
+}
@@ -442,11 +442,15 @@ type kvStoreHandle struct {
 	keyPrefix   string
 }
 
-func (kv *kvStoreHandle) addKeyPrefix(key string) string {
-	keyPrefix := defaultKeyPrefix
+func (kv *kvStoreHandle) KeyPrefix() string {
 	if kv.keyPrefix != "" {
-		keyPrefix = kv.keyPrefix
+		return kv.keyPrefix
 	}
+	return defaultKeyPrefix
+}
+
+func (kv *kvStoreHandle) addKeyPrefix(key string) string {
+	keyPrefix := kv.KeyPrefix()
 	if key[0] == '/' {
 		return keyPrefix + key
 	}
@@ -605,6 +609,13 @@ func (kv *kvStoreHandle) GetAll(key string, valueCallback func(*anypb.Any), ondo
 	return kv.query(kv.addKeyPrefix(key), queryCallback, ondoneCallback)
 }
 
+func (kv *kvStoreHandle) GetAllWithKeys(key string, keyValueCallback func(string, *anypb.Any), ondoneCallback func(string)) (kvstore.KVQuery, error) {
+	queryCallback := func(keyexpr string, bytes []byte) {
+		keyValueCallback(keyexpr, value(bytes))
+	}
+	return kv.query(kv.addKeyPrefix(key), queryCallback, ondoneCallback)
+}
+
 func (kv *kvStoreHandle) ListAllKeys(key string, keyCallback func(string), ondoneCallback func(string)) (kvstore.KVQuery, error) {
 	queryCallback := func(keyexpr string, bytes []byte) {
 		keyCallback(keyexpr)
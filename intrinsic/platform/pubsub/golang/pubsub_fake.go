@@ -0,0 +1,519 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pubsubfake provides an in-memory implementation of
+// pubsubinterface.PubSub and kvstore.KVStore for tests.
+//
+// It does not need cgo or a zenoh router, but mirrors the behavior of the
+// pubsub package where tests can observe it:
+//
+//   - Topics and keys are prefixed like in the pubsub package and key
+//     expressions support the `*`, `**` and `$*` wildcards.
+//   - Callbacks run asynchronously, one goroutine per subscription. Messages on
+//     HighReliability subscriptions are never dropped, Sensor subscriptions
+//     drop their oldest pending message when their queue is full.
+//   - Subscriptions receive the same arguments as with the pubsub package,
+//     e.g., KV store keys including their prefix.
+//
+// Use Flush to wait until all published messages have been delivered:
+//
+//	ps := pubsubfake.New()
+//	defer ps.Close()
+//	// Pass ps to the code under test, publish messages, ...
+//	ps.Flush()
+//	// Check the effects of the delivered messages.
+package pubsubfake
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"intrinsic/platform/pubsub/golang/kvstore"
+	"intrinsic/platform/pubsub/golang/pubsubinterface"
+
+	"google.golang.org/protobuf/proto"
+
+	pubsubpb "intrinsic/platform/pubsub/adapters/pubsub_go_proto"
+
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+
+	workcellinfopb "intrinsic/platform/common/proto/workcell_info_go_proto"
+)
+
+const defaultSensorQueueSize = 16
+
+var errClosed = errors.New("pubsub is closed")
+
+// Option configures a fake PubSub.
+type Option func(*PubSub)
+
+// WithSensorQueueSize sets how many messages a subscription with Sensor QoS
+// queues before it drops the oldest one. Defaults to 16.
+func WithSensorQueueSize(n int) Option {
+	return func(ps *PubSub) {
+		ps.sensorQueueSize = n
+	}
+}
+
+// WithWorkcellName stores the workcell info with the given workcell name in
+// the KV store, which GetWorkcellReplicationNamespace and
+// ReplicatedKVStoreForWorkcell read. Without it, they return
+// kvstore.ErrNotFound like before the workcell info is available.
+func WithWorkcellName(name string) Option {
+	return func(ps *PubSub) {
+		info, err := anypb.New(&workcellinfopb.WorkcellInfo{WorkcellName: name})
+		if err != nil {
+			panic(err)
+		}
+		ps.values[defaultKeyPrefix+"/"+workcellInfoKey] = info
+	}
+}
+
+// PubSub is an in-memory implementation of pubsubinterface.PubSub. It also
+// provides KV stores like pubsub.Handle. All KV stores of a PubSub share the
+// same data.
+type PubSub struct {
+	sensorQueueSize int
+
+	mu            sync.Mutex
+	closed        bool
+	subscriptions map[*subscription]bool
+	publishers    map[*publisher]bool
+	// values are the values of the KV stores, keyed by prefixed key.
+	values map[string]*anypb.Any
+	// cloudCopies are the values copied with AdminCloudCopy, keyed by target
+	// key.
+	cloudCopies map[string]*anypb.Any
+}
+
+var _ pubsubinterface.PubSub = new(PubSub)
+
+// New creates a fake PubSub. The caller should Close it after use to stop the
+// delivery goroutines.
+func New(opts ...Option) *PubSub {
+	ps := &PubSub{
+		sensorQueueSize: defaultSensorQueueSize,
+		subscriptions:   make(map[*subscription]bool),
+		publishers:      make(map[*publisher]bool),
+		values:          make(map[string]*anypb.Any),
+		cloudCopies:     make(map[string]*anypb.Any),
+	}
+	for _, opt := range opts {
+		opt(ps)
+	}
+	return ps
+}
+
+func addTopicPrefix(topic string) string {
+	topicWithoutLeadingSlash := strings.TrimPrefix(topic, "/")
+	if strings.HasPrefix(topicWithoutLeadingSlash, "interipc_ps/") {
+		return topicWithoutLeadingSlash
+	}
+	return "in/" + topicWithoutLeadingSlash
+}
+
+func checkQos(config pubsubinterface.TopicConfig) error {
+	switch config.Qos {
+	case pubsubinterface.Sensor, pubsubinterface.HighReliability:
+		return nil
+	default:
+		return fmt.Errorf("unknown QOS setting %v", config.Qos)
+	}
+}
+
+// Close closes all subscriptions and publishers. Pending messages are
+// dropped.
+func (ps *PubSub) Close() {
+	ps.mu.Lock()
+	ps.closed = true
+	subs := ps.subscriptions
+	ps.subscriptions = make(map[*subscription]bool)
+	ps.publishers = make(map[*publisher]bool)
+	ps.mu.Unlock()
+	for s := range subs {
+		s.stop()
+	}
+}
+
+// Flush blocks until all messages that were published or written to the KV
+// stores have been delivered to the callbacks of the subscriptions, including
+// messages published by those callbacks. It must not be called from a
+// callback.
+func (ps *PubSub) Flush() {
+	for {
+		ps.mu.Lock()
+		subs := make([]*subscription, 0, len(ps.subscriptions))
+		for s := range ps.subscriptions {
+			subs = append(subs, s)
+		}
+		ps.mu.Unlock()
+		idle := true
+		for _, s := range subs {
+			if s.wait() {
+				idle = false
+			}
+		}
+		if idle {
+			return
+		}
+	}
+}
+
+// CloudCopies returns the values that were copied to the cloud KV store with
+// AdminCloudCopy, keyed by target key.
+func (ps *PubSub) CloudCopies() map[string]*anypb.Any {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	res := make(map[string]*anypb.Any, len(ps.cloudCopies))
+	for k, v := range ps.cloudCopies {
+		res[k] = proto.Clone(v).(*anypb.Any)
+	}
+	return res
+}
+
+// NewPublisher creates a publisher for a topic.
+func (ps *PubSub) NewPublisher(topic string, config pubsubinterface.TopicConfig) (pubsubinterface.Publisher, error) {
+	if err := checkQos(config); err != nil {
+		return nil, err
+	}
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if ps.closed {
+		return nil, errClosed
+	}
+	p := &publisher{ps: ps, topicName: topic}
+	ps.publishers[p] = true
+	return p, nil
+}
+
+// NewSubscription creates a subscription to the given topic, using the
+// exemplar proto as the type expected to be called by the msgCallback. The
+// errCallback is invoked when unmarshaling the payload fails; its first
+// argument receives the raw packet bytes (as a string) and its second argument
+// receives the unmarshal error.
+func (ps *PubSub) NewSubscription(topic string, config pubsubinterface.TopicConfig, exemplar proto.Message, msgCallback func(proto.Message), errCallback func(string, error)) (pubsubinterface.Subscription, error) {
+	return ps.subscribe(topic, addTopicPrefix(topic), config, func(_ string, bytes []byte) {
+		packet := &pubsubpb.PubSubPacket{}
+		if err := proto.Unmarshal(bytes, packet); err != nil {
+			return
+		}
+		msg := exemplar.ProtoReflect().New().Interface()
+		if err := packet.GetPayload().UnmarshalTo(msg); err != nil {
+			errCallback(string(bytes), err)
+			return
+		}
+		msgCallback(msg)
+	})
+}
+
+// NewRawSubscription creates a subscription to the given topic, passing the
+// full packet to callback.
+func (ps *PubSub) NewRawSubscription(topic string, config pubsubinterface.TopicConfig, callback func(*pubsubpb.PubSubPacket)) (pubsubinterface.Subscription, error) {
+	return ps.subscribe(topic, addTopicPrefix(topic), config, func(_ string, bytes []byte) {
+		packet := &pubsubpb.PubSubPacket{}
+		if err := proto.Unmarshal(bytes, packet); err != nil {
+			return
+		}
+		callback(packet)
+	})
+}
+
+// NewKVStoreSubscription creates a subscription to the given key of the KV
+// store, see pubsub.Handle.NewKVStoreSubscription. The key is used as is.
+func (ps *PubSub) NewKVStoreSubscription(key string, config pubsubinterface.TopicConfig, msgCallback func(string, *anypb.Any), deletionCallback func(string)) (pubsubinterface.Subscription, error) {
+	return ps.subscribe(key, key, config, func(key string, bytes []byte) {
+		if len(bytes) == 0 {
+			deletionCallback(key)
+			return
+		}
+		value := &anypb.Any{}
+		if err := proto.Unmarshal(bytes, value); err != nil {
+			return
+		}
+		msgCallback(key, value)
+	})
+}
+
+func (ps *PubSub) subscribe(topic string, keyExpr string, config pubsubinterface.TopicConfig, callback func(key string, bytes []byte)) (*subscription, error) {
+	if err := checkQos(config); err != nil {
+		return nil, err
+	}
+	if err := validKeyExpr(keyExpr); err != nil {
+		return nil, err
+	}
+	s := &subscription{
+		ps:        ps,
+		topicName: topic,
+		keyExpr:   keyExpr,
+		callback:  callback,
+	}
+	if config.Qos == pubsubinterface.Sensor {
+		s.queueSize = ps.sensorQueueSize
+	}
+	s.cond = sync.NewCond(&s.mu)
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if ps.closed {
+		return nil, errClosed
+	}
+	ps.subscriptions[s] = true
+	go s.run()
+	return s, nil
+}
+
+// deliver queues bytes for all subscriptions that match key. Requires ps.mu.
+func (ps *PubSub) deliver(key string, bytes []byte) {
+	for s := range ps.subscriptions {
+		if keyExprMatches(s.keyExpr, key) {
+			s.enqueue(delivery{key: key, bytes: bytes})
+		}
+	}
+}
+
+// KVStore returns the KV store with the default key prefix.
+func (ps *PubSub) KVStore() kvstore.KVStore {
+	return &kvStore{ps: ps}
+}
+
+// KVStoreReplicated returns the replicated KV store.
+func (ps *PubSub) KVStoreReplicated() kvstore.KVStore {
+	return &kvStore{ps: ps, keyPrefix: replicationKeyPrefix}
+}
+
+// ReplicatedKVStoreForWorkcell returns the replicated KV store of the
+// workcell, see WithWorkcellName.
+func (ps *PubSub) ReplicatedKVStoreForWorkcell() (kvstore.KVStore, error) {
+	name, err := ps.KVStore().GetWorkcellReplicationNamespace()
+	if err != nil {
+		return nil, err
+	}
+	return ps.KVStoreWithPrefix(fmt.Sprintf("%s/%s", replicationKeyPrefix, name)), nil
+}
+
+// GlobalReplicatedKVStore returns the KV store that is replicated to all
+// workcells of an organization.
+func (ps *PubSub) GlobalReplicatedKVStore() kvstore.KVStore {
+	return ps.KVStoreWithPrefix(fmt.Sprintf("%s/%s", replicationKeyPrefix, globalReplicationNamespace))
+}
+
+// KVStoreWithPrefix returns a KV store that uses the given prefix instead of
+// the default key prefix.
+func (ps *PubSub) KVStoreWithPrefix(prefix string) kvstore.KVStore {
+	return &kvStore{ps: ps, keyPrefix: prefix}
+}
+
+type delivery struct {
+	key   string
+	bytes []byte
+}
+
+type subscription struct {
+	ps        *PubSub
+	topicName string
+	keyExpr   string
+	callback  func(key string, bytes []byte)
+	// queueSize limits the pending deliveries if positive.
+	queueSize int
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queue   []delivery
+	running bool
+	closed  bool
+}
+
+func (s *subscription) TopicName() string { return s.topicName }
+
+// Close closes the subscription. Pending messages are dropped, but a callback
+// that is running when Close is called may still finish.
+func (s *subscription) Close() {
+	s.ps.mu.Lock()
+	delete(s.ps.subscriptions, s)
+	s.ps.mu.Unlock()
+	s.stop()
+}
+
+func (s *subscription) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	s.queue = nil
+	s.cond.Broadcast()
+}
+
+func (s *subscription) enqueue(d delivery) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	if s.queueSize > 0 && len(s.queue) >= s.queueSize {
+		s.queue = s.queue[1:]
+	}
+	s.queue = append(s.queue, d)
+	s.cond.Broadcast()
+}
+
+// wait blocks until the subscription has no pending or running callbacks and
+// reports whether it had any.
+func (s *subscription) wait() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	busy := false
+	for !s.closed && (len(s.queue) > 0 || s.running) {
+		busy = true
+		s.cond.Wait()
+	}
+	return busy
+}
+
+func (s *subscription) run() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for {
+		for !s.closed && len(s.queue) == 0 {
+			s.cond.Wait()
+		}
+		if s.closed {
+			return
+		}
+		d := s.queue[0]
+		s.queue = s.queue[1:]
+		s.running = true
+		s.mu.Unlock()
+		s.callback(d.key, d.bytes)
+		s.mu.Lock()
+		s.running = false
+		s.cond.Broadcast()
+	}
+}
+
+type publisher struct {
+	ps        *PubSub
+	topicName string
+}
+
+func (p *publisher) TopicName() string { return p.topicName }
+
+func (p *publisher) Publish(msg proto.Message) error {
+	payload, err := anypb.New(msg)
+	if err != nil {
+		return err
+	}
+	return p.PublishAny(payload)
+}
+
+func (p *publisher) PublishAny(msg *anypb.Any) error {
+	packet := &pubsubpb.PubSubPacket{
+		PublishTime: timestamppb.Now(),
+		Payload:     msg,
+	}
+	bytes, err := proto.Marshal(packet)
+	if err != nil {
+		return err
+	}
+	p.ps.mu.Lock()
+	defer p.ps.mu.Unlock()
+	if !p.ps.publishers[p] {
+		return fmt.Errorf("publisher for topic %q is closed", p.topicName)
+	}
+	p.ps.deliver(addTopicPrefix(p.topicName), bytes)
+	return nil
+}
+
+func (p *publisher) HasMatchingSubscribers() (bool, error) {
+	key := addTopicPrefix(p.topicName)
+	p.ps.mu.Lock()
+	defer p.ps.mu.Unlock()
+	for s := range p.ps.subscriptions {
+		if keyExprMatches(s.keyExpr, key) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (p *publisher) Close() {
+	p.ps.mu.Lock()
+	defer p.ps.mu.Unlock()
+	delete(p.ps.publishers, p)
+}
+
+// validKeyExpr checks that a key expression has no empty chunks.
+func validKeyExpr(keyExpr string) error {
+	if keyExpr == "" {
+		return fmt.Errorf("Keyexpr must not be empty")
+	}
+	for _, chunk := range strings.Split(keyExpr, "/") {
+		if chunk == "" {
+			return fmt.Errorf("Keyexpr %q must not contain empty parts", keyExpr)
+		}
+	}
+	return nil
+}
+
+// hasWildcards reports whether a key expression contains wildcards.
+func hasWildcards(keyExpr string) bool {
+	return strings.ContainsAny(keyExpr, "*$")
+}
+
+// keyExprMatches reports whether the key expression expr includes key, which
+// must not contain wildcards. As in zenoh, `*` matches exactly one chunk, `**`
+// matches any number of chunks and `$*` matches any part of a chunk.
+func keyExprMatches(expr, key string) bool {
+	return chunksMatch(strings.Split(expr, "/"), strings.Split(key, "/"))
+}
+
+func chunksMatch(expr, key []string) bool {
+	if len(expr) == 0 {
+		return len(key) == 0
+	}
+	if expr[0] == "**" {
+		for i := 0; i <= len(key); i++ {
+			if chunksMatch(expr[1:], key[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	return len(key) > 0 && chunkMatches(expr[0], key[0]) && chunksMatch(expr[1:], key[1:])
+}
+
+// chunkMatches reports whether a single chunk of a key expression matches a
+// chunk of a key.
+func chunkMatches(expr, chunk string) bool {
+	if expr == "*" {
+		return true
+	}
+	parts := strings.Split(expr, "$*")
+	if len(parts) == 1 {
+		return expr == chunk
+	}
+	if !strings.HasPrefix(chunk, parts[0]) {
+		return false
+	}
+	chunk = chunk[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, p := range parts[1 : len(parts)-1] {
+		i := strings.Index(chunk, p)
+		if i < 0 {
+			return false
+		}
+		chunk = chunk[i+len(p):]
+	}
+	return len(chunk) >= len(last) && strings.HasSuffix(chunk, last)
+}
@@ -0,0 +1,310 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsubfake
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"intrinsic/platform/pubsub/golang/kvstore"
+	"intrinsic/platform/pubsub/golang/pubsubinterface"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+
+	pubsubpb "intrinsic/platform/pubsub/adapters/pubsub_go_proto"
+
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var reliable = pubsubinterface.TopicConfig{Qos: pubsubinterface.HighReliability}
+
+func TestKeyExprMatches(t *testing.T) {
+	tests := []struct {
+		expr string
+		key  string
+		want bool
+	}{
+		{expr: "a/b/c", key: "a/b/c", want: true},
+		{expr: "a/b/c", key: "a/b", want: false},
+		{expr: "a/*/c", key: "a/b/c", want: true},
+		{expr: "a/*", key: "a/b/c", want: false},
+		{expr: "a/**", key: "a", want: true},
+		{expr: "a/**", key: "a/b/c", want: true},
+		{expr: "a/**/c", key: "a/c", want: true},
+		{expr: "a/**/c", key: "a/x/y/c", want: true},
+		{expr: "a/**/c", key: "a/x/y/d", want: false},
+		{expr: "a/b$*", key: "a/bcd", want: true},
+		{expr: "a/b$*x$*d", key: "a/bxd", want: true},
+		{expr: "a/ab$*ba", key: "a/aba", want: false},
+	}
+	for _, tc := range tests {
+		if got := keyExprMatches(tc.expr, tc.key); got != tc.want {
+			t.Errorf("keyExprMatches(%q, %q) = %v, want %v", tc.expr, tc.key, got, tc.want)
+		}
+	}
+}
+
+func TestPublishSubscribe(t *testing.T) {
+	ps := New()
+	defer ps.Close()
+
+	var mu sync.Mutex
+	var got []string
+	var errs int
+	sub, err := ps.NewSubscription("robot/*", reliable, &wrapperspb.StringValue{},
+		func(msg proto.Message) {
+			mu.Lock()
+			defer mu.Unlock()
+			got = append(got, msg.(*wrapperspb.StringValue).GetValue())
+		},
+		func(packet string, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs++
+		})
+	if err != nil {
+		t.Fatalf("NewSubscription() failed: %v", err)
+	}
+	var packets []*pubsubpb.PubSubPacket
+	if _, err := ps.NewRawSubscription("/robot/status", reliable, func(p *pubsubpb.PubSubPacket) {
+		mu.Lock()
+		defer mu.Unlock()
+		packets = append(packets, p)
+	}); err != nil {
+		t.Fatalf("NewRawSubscription() failed: %v", err)
+	}
+
+	pub, err := ps.NewPublisher("/robot/status", reliable)
+	if err != nil {
+		t.Fatalf("NewPublisher() failed: %v", err)
+	}
+	if ok, err := pub.HasMatchingSubscribers(); err != nil || !ok {
+		t.Errorf("HasMatchingSubscribers() = %v, %v, want true", ok, err)
+	}
+	for _, msg := range []proto.Message{wrapperspb.String("a"), wrapperspb.Int64(1), wrapperspb.String("b")} {
+		if err := pub.Publish(msg); err != nil {
+			t.Fatalf("Publish(%v) failed: %v", msg, err)
+		}
+	}
+	ps.Flush()
+
+	mu.Lock()
+	if diff := cmp.Diff([]string{"a", "b"}, got); diff != "" {
+		t.Errorf("NewSubscription() received unexpected messages (-want +got):\n%s", diff)
+	}
+	if errs != 1 {
+		t.Errorf("NewSubscription() reported %d errors, want 1", errs)
+	}
+	if len(packets) != 3 || packets[0].GetPublishTime() == nil {
+		t.Errorf("NewRawSubscription() received %v, want 3 packets with publish times", packets)
+	}
+	mu.Unlock()
+
+	sub.Close()
+	if err := pub.Publish(wrapperspb.String("c")); err != nil {
+		t.Fatalf("Publish() failed: %v", err)
+	}
+	ps.Flush()
+	mu.Lock()
+	if len(got) != 2 {
+		t.Errorf("closed subscription received %v", got)
+	}
+	mu.Unlock()
+
+	if _, err := ps.NewPublisher("robot/status", pubsubinterface.TopicConfig{Qos: 7}); err == nil {
+		t.Errorf("NewPublisher() with unknown QoS succeeded, want error")
+	}
+}
+
+func TestSensorQosDropsOldest(t *testing.T) {
+	ps := New(WithSensorQueueSize(2))
+	defer ps.Close()
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var got []int64
+	if _, err := ps.NewSubscription("values", pubsubinterface.TopicConfig{Qos: pubsubinterface.Sensor}, &wrapperspb.Int64Value{},
+		func(msg proto.Message) {
+			select {
+			case started <- struct{}{}:
+			default:
+			}
+			<-release
+			mu.Lock()
+			defer mu.Unlock()
+			got = append(got, msg.(*wrapperspb.Int64Value).GetValue())
+		}, nil); err != nil {
+		t.Fatalf("NewSubscription() failed: %v", err)
+	}
+	pub, err := ps.NewPublisher("values", pubsubinterface.TopicConfig{Qos: pubsubinterface.Sensor})
+	if err != nil {
+		t.Fatalf("NewPublisher() failed: %v", err)
+	}
+	if err := pub.Publish(wrapperspb.Int64(0)); err != nil {
+		t.Fatalf("Publish() failed: %v", err)
+	}
+	// Wait until the first message is being delivered, so that the following
+	// ones are queued.
+	<-started
+	for i := int64(1); i <= 4; i++ {
+		if err := pub.Publish(wrapperspb.Int64(i)); err != nil {
+			t.Fatalf("Publish() failed: %v", err)
+		}
+	}
+	close(release)
+	ps.Flush()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if diff := cmp.Diff([]int64{0, 3, 4}, got); diff != "" {
+		t.Errorf("Sensor subscription received unexpected messages (-want +got):\n%s", diff)
+	}
+}
+
+func TestKVStore(t *testing.T) {
+	ps := New(WithWorkcellName("cell"))
+	defer ps.Close()
+	kv := ps.KVStore()
+
+	var mu sync.Mutex
+	var events []string
+	if _, err := kv.Subscribe("config/**", reliable, &wrapperspb.Int64Value{},
+		func(key string, msg proto.Message) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, "set "+key)
+		},
+		func(key string) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, "delete "+key)
+		},
+		func(key string, value *anypb.Any, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, "error "+key)
+		}); err != nil {
+		t.Fatalf("Subscribe() failed: %v", err)
+	}
+
+	for _, e := range []struct {
+		key   string
+		value proto.Message
+	}{
+		{"config/a", wrapperspb.Int64(1)},
+		{"config/b/c", wrapperspb.Int64(2)},
+		{"other", wrapperspb.Int64(3)},
+	} {
+		if err := kv.Set(e.key, e.value, false); err != nil {
+			t.Fatalf("Set(%q) failed: %v", e.key, err)
+		}
+	}
+	if err := kv.Set("config/d", wrapperspb.String("wrong type"), true); err != nil {
+		t.Fatalf("Set() failed: %v", err)
+	}
+	if err := kv.Set("config/*", wrapperspb.Int64(4), false); err == nil {
+		t.Errorf("Set() with a wildcard key succeeded, want error")
+	}
+
+	value, err := kv.Get("config/a", nil)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	got := &wrapperspb.Int64Value{}
+	if err := value.UnmarshalTo(got); err != nil || got.GetValue() != 1 {
+		t.Errorf("Get() = %v, want 1", value)
+	}
+	if _, err := kv.Get("missing", nil); !errors.Is(err, kvstore.ErrNotFound) {
+		t.Errorf("Get() of a missing key returned %v, want %v", err, kvstore.ErrNotFound)
+	}
+	// Like the pubsub package, Get returns the first match of a wildcard key.
+	value, err = kv.Get("config/*", nil)
+	if err != nil {
+		t.Fatalf("Get() of a wildcard key failed: %v", err)
+	}
+	if err := value.UnmarshalTo(got); err != nil || got.GetValue() != 1 {
+		t.Errorf("Get() of a wildcard key = %v, want 1", value)
+	}
+	if _, err := kv.Get("missing/**", nil); !errors.Is(err, kvstore.ErrNotFound) {
+		t.Errorf("Get() of a wildcard key without matches returned %v, want %v", err, kvstore.ErrNotFound)
+	}
+
+	keys := make(chan string)
+	if _, err := kv.ListAllKeys("config/**", func(key string) { keys <- key }, func(string) { close(keys) }); err != nil {
+		t.Fatalf("ListAllKeys() failed: %v", err)
+	}
+	var listed []string
+	for k := range keys {
+		listed = append(listed, k)
+	}
+	if diff := cmp.Diff([]string{"kv_store/config/a", "kv_store/config/b/c", "kv_store/config/d"}, listed); diff != "" {
+		t.Errorf("ListAllKeys() returned unexpected keys (-want +got):\n%s", diff)
+	}
+
+	values := make(chan string)
+	if _, err := kv.GetAllWithKeys("config/b/*", func(key string, value *anypb.Any) {
+		v := &wrapperspb.Int64Value{}
+		if err := value.UnmarshalTo(v); err != nil {
+			t.Errorf("UnmarshalTo() failed: %v", err)
+		}
+		values <- fmt.Sprintf("%s=%d", key, v.GetValue())
+	}, func(string) { close(values) }); err != nil {
+		t.Fatalf("GetAllWithKeys() failed: %v", err)
+	}
+	var got []string
+	for v := range values {
+		got = append(got, v)
+	}
+	if diff := cmp.Diff([]string{"kv_store/config/b/c=2"}, got); diff != "" {
+		t.Errorf("GetAllWithKeys() returned unexpected values (-want +got):\n%s", diff)
+	}
+	if prefix := kv.KeyPrefix(); prefix != "kv_store" {
+		t.Errorf("KeyPrefix() = %q, want %q", prefix, "kv_store")
+	}
+
+	if err := kv.Delete("config/b/*"); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	ps.Flush()
+	mu.Lock()
+	want := []string{"set kv_store/config/a", "set kv_store/config/b/c", "error kv_store/config/d", "delete kv_store/config/b/c"}
+	if diff := cmp.Diff(want, events); diff != "" {
+		t.Errorf("Subscribe() received unexpected events (-want +got):\n%s", diff)
+	}
+	mu.Unlock()
+
+	namespace, err := kv.GetWorkcellReplicationNamespace()
+	if err != nil || namespace != "cell" {
+		t.Errorf("GetWorkcellReplicationNamespace() = %q, %v, want %q", namespace, err, "cell")
+	}
+	if _, err := New().KVStore().GetWorkcellReplicationNamespace(); !errors.Is(err, kvstore.ErrNotFound) {
+		t.Errorf("GetWorkcellReplicationNamespace() without workcell info returned %v, want %v", err, kvstore.ErrNotFound)
+	}
+	replicated, err := ps.ReplicatedKVStoreForWorkcell()
+	if err != nil {
+		t.Fatalf("ReplicatedKVStoreForWorkcell() failed: %v", err)
+	}
+	if err := replicated.Set("config/a", wrapperspb.Int64(5), false); err != nil {
+		t.Fatalf("Set() failed: %v", err)
+	}
+	if _, err := ps.KVStoreWithPrefix("kv_store_repl/cell").Get("config/a", nil); err != nil {
+		t.Errorf("Get() of a replicated value failed: %v", err)
+	}
+}
@@ -43,7 +43,8 @@ type TopicConfig struct {
 
 // PubSub is the main interface
 //
-// Currently the only implementation is that provided by the pubsub package.
+// It is implemented by the pubsub package and, for tests without cgo and a
+// zenoh router, by the in-memory pubsubfake package.
 type PubSub interface {
 	// Frees the resources and unsubscribes from all topics.
 	Close()
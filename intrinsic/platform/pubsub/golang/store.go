@@ -0,0 +1,331 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kvstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"intrinsic/platform/pubsub/golang/pubsubinterface"
+
+	anypb "google.golang.org/protobuf/types/known/anypb"
+)
+
+// KeyValue is a key of a KV store and its value.
+type KeyValue struct {
+	Key   string
+	Value *anypb.Any
+}
+
+// EventType is the type of a WatchEvent.
+type EventType int
+
+const (
+	// Put signals that a value was added or updated.
+	Put EventType = iota + 1
+	// Deleted signals that a value was deleted.
+	Deleted
+	// Synced signals that all values of the initial snapshot have been
+	// emitted.
+	Synced
+)
+
+func (t EventType) String() string {
+	switch t {
+	case Put:
+		return "Put"
+	case Deleted:
+		return "Deleted"
+	case Synced:
+		return "Synced"
+	default:
+		return fmt.Sprintf("EventType(%d)", int(t))
+	}
+}
+
+// WatchEvent is a change of a KV store, as emitted by Store.Watch.
+type WatchEvent struct {
+	Type EventType
+	// Key is the key of the changed value, relative to the prefix of the store
+	// like the keys passed to Set. Empty for Synced.
+	Key string
+	// Value is the new value for Put, nil otherwise.
+	Value *anypb.Any
+	// Snapshot is true for the Put events of the initial snapshot.
+	Snapshot bool
+}
+
+// Store wraps a KVStore with methods that take a context and return
+// iterators, instead of timeouts and callbacks.
+type Store struct {
+	kv KVStore
+	// mu serializes CompareAndSet.
+	mu sync.Mutex
+}
+
+// NewStore returns a Store that reads from and watches kv.
+func NewStore(kv KVStore) *Store {
+	return &Store{kv: kv}
+}
+
+// KVStore returns the wrapped KVStore, e.g., for writing values.
+func (s *Store) KVStore() KVStore {
+	return s.kv
+}
+
+// Get returns the value for the given key. It returns an error wrapping
+// ErrNotFound if there is none, and the error of ctx if ctx is done first.
+func (s *Store) Get(ctx context.Context, key string) (*anypb.Any, error) {
+	var timeout *time.Duration
+	if deadline, ok := ctx.Deadline(); ok {
+		d := time.Until(deadline)
+		timeout = &d
+	}
+	type result struct {
+		value *anypb.Any
+		err   error
+	}
+	res := make(chan result, 1)
+	go func() {
+		value, err := s.kv.Get(key, timeout)
+		res <- result{value: value, err: err}
+	}()
+	select {
+	case r := <-res:
+		if errors.Is(r.err, ErrDeadlineExceeded) && ctx.Err() != nil {
+			return nil, fmt.Errorf("%w: %w", r.err, ctx.Err())
+		}
+		return r.value, r.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to get %q: %w", key, ctx.Err())
+	}
+}
+
+// relativeKey returns the key of a query result relative to the prefix of the
+// store, like the keys passed to Set.
+func (s *Store) relativeKey(rawKey string) string {
+	return strings.TrimPrefix(rawKey, s.kv.KeyPrefix()+"/")
+}
+
+// runQuery starts a query with start and waits until it is done. It returns
+// the error of ctx if ctx is done first.
+func runQuery(ctx context.Context, start func(ondone func(string)) (KVQuery, error)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	var once sync.Once
+	query, err := start(func(string) {
+		once.Do(func() { close(done) })
+	})
+	if err != nil {
+		return err
+	}
+	select {
+	case <-done:
+		query.Close()
+		return nil
+	case <-ctx.Done():
+		// The query must be kept alive until it is done.
+		go func() {
+			<-done
+			query.Close()
+		}()
+		return ctx.Err()
+	}
+}
+
+// listKeys returns the keys that match keyExpr, relative to the prefix of the
+// store, in lexicographic order. Keys that hold versions are skipped.
+func (s *Store) listKeys(ctx context.Context, keyExpr string) ([]string, error) {
+	var mu sync.Mutex
+	var keys []string
+	if err := runQuery(ctx, func(ondone func(string)) (KVQuery, error) {
+		return s.kv.ListAllKeys(keyExpr, func(rawKey string) {
+			mu.Lock()
+			defer mu.Unlock()
+			if k := s.relativeKey(rawKey); !isVersionKey(k) {
+				keys = append(keys, k)
+			}
+		}, ondone)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list keys matching %q: %w", keyExpr, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	slices.Sort(keys)
+	return slices.Compact(keys), nil
+}
+
+// getAll returns the keys and values that match keyExpr, with keys relative to
+// the prefix of the store, in lexicographic order of the keys. Keys that hold
+// versions are skipped. The keys and values are read with a single query.
+func (s *Store) getAll(ctx context.Context, keyExpr string) ([]KeyValue, error) {
+	var mu sync.Mutex
+	var values []KeyValue
+	if err := runQuery(ctx, func(ondone func(string)) (KVQuery, error) {
+		return s.kv.GetAllWithKeys(keyExpr, func(rawKey string, value *anypb.Any) {
+			mu.Lock()
+			defer mu.Unlock()
+			if k := s.relativeKey(rawKey); !isVersionKey(k) {
+				values = append(values, KeyValue{Key: k, Value: value})
+			}
+		}, ondone)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get values matching %q: %w", keyExpr, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	slices.SortStableFunc(values, func(a, b KeyValue) int { return strings.Compare(a.Key, b.Key) })
+	return slices.CompactFunc(values, func(a, b KeyValue) bool { return a.Key == b.Key }), nil
+}
+
+// Keys returns an iterator over the keys that match the key expression, in
+// lexicographic order. Keys under VersionKeyPrefix are skipped. The iteration
+// ends with a non-nil error if listing the keys fails.
+func (s *Store) Keys(ctx context.Context, keyExpr string) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		keys, err := s.listKeys(ctx, keyExpr)
+		if err != nil {
+			yield("", err)
+			return
+		}
+		for _, k := range keys {
+			if !yield(k, nil) {
+				return
+			}
+		}
+	}
+}
+
+// GetAll returns an iterator over the keys and values that match the key
+// expression, in lexicographic order of the keys. They are read with a single
+// query before the iteration starts. Keys under VersionKeyPrefix are skipped.
+// The iteration ends with a non-nil error if reading fails.
+func (s *Store) GetAll(ctx context.Context, keyExpr string) iter.Seq2[KeyValue, error] {
+	return func(yield func(KeyValue, error) bool) {
+		values, err := s.getAll(ctx, keyExpr)
+		if err != nil {
+			yield(KeyValue{}, err)
+			return
+		}
+		for _, kv := range values {
+			if !yield(kv, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Watch returns an iterator over the changes of the values that match the key
+// expression. It first emits a Put event for each current value, in
+// lexicographic order of the keys, and a Synced event. Afterwards it emits a
+// Put or Deleted event for each change, in the order of the changes. Changes
+// that happen while the snapshot is taken may be emitted although the
+// snapshot already contains them. Changes of keys under VersionKeyPrefix are
+// skipped.
+//
+// The iteration ends with a non-nil error when ctx is done or reading fails.
+func (s *Store) Watch(ctx context.Context, keyExpr string) iter.Seq2[WatchEvent, error] {
+	return func(yield func(WatchEvent, error) bool) {
+		w := &watcher{notify: make(chan struct{}, 1)}
+		// Subscribe before taking the snapshot so that no change is missed.
+		sub, err := s.kv.SubscribeToRawValues(keyExpr, pubsubinterface.TopicConfig{Qos: pubsubinterface.HighReliability},
+			func(key string, value *anypb.Any) {
+				w.add(rawEvent{typ: Put, key: key, value: value})
+			},
+			func(key string) {
+				w.add(rawEvent{typ: Deleted, key: key})
+			})
+		if err != nil {
+			yield(WatchEvent{}, fmt.Errorf("failed to watch %q: %w", keyExpr, err))
+			return
+		}
+		defer sub.Close()
+
+		values, err := s.getAll(ctx, keyExpr)
+		if err != nil {
+			yield(WatchEvent{}, err)
+			return
+		}
+		for _, kv := range values {
+			if !yield(WatchEvent{Type: Put, Key: kv.Key, Value: kv.Value, Snapshot: true}, nil) {
+				return
+			}
+		}
+		if !yield(WatchEvent{Type: Synced}, nil) {
+			return
+		}
+
+		for {
+			for _, e := range w.take() {
+				key := s.relativeKey(e.key)
+				if isVersionKey(key) {
+					continue
+				}
+				if !yield(WatchEvent{Type: e.typ, Key: key, Value: e.value}, nil) {
+					return
+				}
+			}
+			select {
+			case <-w.notify:
+			case <-ctx.Done():
+				yield(WatchEvent{}, ctx.Err())
+				return
+			}
+		}
+	}
+}
+
+// rawEvent is a change as reported by a subscription, with the prefixed key.
+type rawEvent struct {
+	typ   EventType
+	key   string
+	value *anypb.Any
+}
+
+// watcher queues the changes reported by a subscription until Watch emits
+// them.
+type watcher struct {
+	mu     sync.Mutex
+	events []rawEvent
+	notify chan struct{}
+}
+
+func (w *watcher) add(e rawEvent) {
+	w.mu.Lock()
+	w.events = append(w.events, e)
+	w.mu.Unlock()
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (w *watcher) take() []rawEvent {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	events := w.events
+	w.events = nil
+	return events
+}
@@ -0,0 +1,370 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kvstore_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"intrinsic/platform/pubsub/golang/kvstore"
+	"intrinsic/platform/pubsub/golang/pubsubfake"
+	"intrinsic/platform/pubsub/golang/pubsubinterface"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func set(t *testing.T, kv kvstore.KVStore, key string, value int64) {
+	t.Helper()
+	if err := kv.Set(key, wrapperspb.Int64(value), false); err != nil {
+		t.Fatalf("Set(%q) failed: %v", key, err)
+	}
+}
+
+func TestStoreGet(t *testing.T) {
+	ps := pubsubfake.New()
+	defer ps.Close()
+	store := kvstore.NewStore(ps.KVStore())
+	set(t, store.KVStore(), "a", 1)
+
+	value, err := store.Get(context.Background(), "a")
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	got := &wrapperspb.Int64Value{}
+	if err := value.UnmarshalTo(got); err != nil || got.GetValue() != 1 {
+		t.Errorf("Get() = %v, want 1", value)
+	}
+	if _, err := store.Get(context.Background(), "missing"); !errors.Is(err, kvstore.ErrNotFound) {
+		t.Errorf("Get() of a missing key returned %v, want %v", err, kvstore.ErrNotFound)
+	}
+}
+
+func TestStoreKeysAndGetAll(t *testing.T) {
+	ps := pubsubfake.New()
+	defer ps.Close()
+	store := kvstore.NewStore(ps.KVStore())
+	set(t, store.KVStore(), "config/b", 2)
+	set(t, store.KVStore(), "config/a", 1)
+	set(t, store.KVStore(), "other", 3)
+	ctx := context.Background()
+
+	var keys []string
+	for k, err := range store.Keys(ctx, "config/*") {
+		if err != nil {
+			t.Fatalf("Keys() failed: %v", err)
+		}
+		keys = append(keys, k)
+	}
+	if diff := cmp.Diff([]string{"config/a", "config/b"}, keys); diff != "" {
+		t.Errorf("Keys() returned unexpected keys (-want +got):\n%s", diff)
+	}
+
+	var values []string
+	for kv, err := range store.GetAll(ctx, "config/*") {
+		if err != nil {
+			t.Fatalf("GetAll() failed: %v", err)
+		}
+		v := &wrapperspb.Int64Value{}
+		if err := kv.Value.UnmarshalTo(v); err != nil {
+			t.Fatalf("UnmarshalTo() failed: %v", err)
+		}
+		values = append(values, fmt.Sprintf("%s=%d", kv.Key, v.GetValue()))
+	}
+	if diff := cmp.Diff([]string{"config/a=1", "config/b=2"}, values); diff != "" {
+		t.Errorf("GetAll() returned unexpected values (-want +got):\n%s", diff)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	for _, err := range store.Keys(canceled, "config/*") {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Keys() with a canceled context returned %v, want %v", err, context.Canceled)
+		}
+	}
+}
+
+func TestStoreGetAllWithPrefix(t *testing.T) {
+	ps := pubsubfake.New()
+	defer ps.Close()
+	store := kvstore.NewStore(ps.KVStoreWithPrefix("kv_store_repl/cell"))
+	set(t, store.KVStore(), "config/a", 1)
+	set(t, store.KVStore(), "/cell/b", 2)
+	ctx := context.Background()
+
+	for _, keyExpr := range []string{"**", "/**", "*/*"} {
+		var got []string
+		for kv, err := range store.GetAll(ctx, keyExpr) {
+			if err != nil {
+				t.Fatalf("GetAll(%q) failed: %v", keyExpr, err)
+			}
+			v := &wrapperspb.Int64Value{}
+			if err := kv.Value.UnmarshalTo(v); err != nil {
+				t.Fatalf("UnmarshalTo() failed: %v", err)
+			}
+			got = append(got, fmt.Sprintf("%s=%d", kv.Key, v.GetValue()))
+		}
+		if diff := cmp.Diff([]string{"cell/b=2", "config/a=1"}, got); diff != "" {
+			t.Errorf("GetAll(%q) returned unexpected values (-want +got):\n%s", keyExpr, diff)
+		}
+	}
+}
+
+func TestStoreWatch(t *testing.T) {
+	ps := pubsubfake.New()
+	defer ps.Close()
+	store := kvstore.NewStore(ps.KVStore())
+	kv := store.KVStore()
+	set(t, kv, "config/a", 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var got []string
+	for e, err := range store.Watch(ctx, "config/**") {
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("Watch() failed: %v", err)
+			}
+			break
+		}
+		got = append(got, fmt.Sprintf("%v %s snapshot=%v", e.Type, e.Key, e.Snapshot))
+		switch len(got) {
+		case 2:
+			// Synced: change the store.
+			set(t, kv, "config/b", 2)
+			if err := kv.Delete("config/a"); err != nil {
+				t.Fatalf("Delete() failed: %v", err)
+			}
+		case 4:
+			cancel()
+		}
+	}
+
+	want := []string{
+		"Put config/a snapshot=true",
+		"Synced  snapshot=false",
+		"Put config/b snapshot=false",
+		"Deleted config/a snapshot=false",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Watch() emitted unexpected events (-want +got):\n%s", diff)
+	}
+}
+
+func TestCompareAndSet(t *testing.T) {
+	ps := pubsubfake.New()
+	defer ps.Close()
+	store := kvstore.NewStore(ps.KVStore())
+	ctx := context.Background()
+	set(t, store.KVStore(), "unversioned", 1)
+
+	tests := []struct {
+		name            string
+		key             string
+		expectedVersion int64
+		wantVersion     int64
+		wantErr         error
+	}{
+		{name: "create", key: "a", expectedVersion: 0, wantVersion: 1},
+		{name: "update", key: "a", expectedVersion: 1, wantVersion: 2},
+		{name: "stale version", key: "a", expectedVersion: 1, wantErr: kvstore.ErrConflict},
+		{name: "missing key", key: "b", expectedVersion: 1, wantErr: kvstore.ErrConflict},
+		{name: "unversioned value", key: "unversioned", expectedVersion: 0, wantVersion: 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			version, err := store.CompareAndSet(ctx, tc.key, tc.expectedVersion, wrapperspb.Int64(tc.expectedVersion))
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("CompareAndSet() returned error %v, want %v", err, tc.wantErr)
+			}
+			if version != tc.wantVersion {
+				t.Errorf("CompareAndSet() = %d, want %d", version, tc.wantVersion)
+			}
+		})
+	}
+
+	value, version, err := store.GetVersioned(ctx, "a")
+	if err != nil {
+		t.Fatalf("GetVersioned() failed: %v", err)
+	}
+	got := &wrapperspb.Int64Value{}
+	if err := value.UnmarshalTo(got); err != nil || got.GetValue() != 1 || version != 2 {
+		t.Errorf("GetVersioned() = %v, %d, want 1, 2", value, version)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	ps := pubsubfake.New()
+	defer ps.Close()
+	ctx := context.Background()
+	increment := func(old *anypb.Any) (proto.Message, error) {
+		v := &wrapperspb.Int64Value{}
+		if old != nil {
+			if err := old.UnmarshalTo(v); err != nil {
+				return nil, err
+			}
+		}
+		return wrapperspb.Int64(v.GetValue() + 1), nil
+	}
+
+	// The writers share a Store, which serializes their writes, so no update
+	// may be lost.
+	store := kvstore.NewStore(ps.KVStore())
+	const writers = 8
+	var succeeded atomic.Int64
+	var wg sync.WaitGroup
+	for range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 5 {
+				_, err := store.Update(ctx, "counter", increment)
+				switch {
+				case err == nil:
+					succeeded.Add(1)
+				case !errors.Is(err, kvstore.ErrConflict):
+					t.Errorf("Update() failed: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	value, version, err := store.GetVersioned(ctx, "counter")
+	if err != nil {
+		t.Fatalf("GetVersioned() failed: %v", err)
+	}
+	got := &wrapperspb.Int64Value{}
+	if err := value.UnmarshalTo(got); err != nil {
+		t.Fatalf("UnmarshalTo() failed: %v", err)
+	}
+	if got.GetValue() != succeeded.Load() || version != succeeded.Load() {
+		t.Errorf("GetVersioned() = %d, %d, want %d successful updates", got.GetValue(), version, succeeded.Load())
+	}
+
+	wantErr := errors.New("failed")
+	if _, err := store.Update(ctx, "counter", func(*anypb.Any) (proto.Message, error) { return nil, wantErr }); !errors.Is(err, wantErr) {
+		t.Errorf("Update() returned %v, want %v", err, wantErr)
+	}
+}
+
+// abortingKVStore aborts the next high-consistency writes, as many as given by
+// aborts, like the KV store does when it detects a write race.
+type abortingKVStore struct {
+	kvstore.KVStore
+	aborts int
+}
+
+func (kv *abortingKVStore) Set(key string, value proto.Message, highConsistency bool) error {
+	if highConsistency && kv.aborts > 0 {
+		kv.aborts--
+		return kvstore.ErrAborted
+	}
+	return kv.KVStore.Set(key, value, highConsistency)
+}
+
+func (kv *abortingKVStore) SetAny(key string, valueAny *anypb.Any, highConsistency bool) error {
+	if highConsistency && kv.aborts > 0 {
+		kv.aborts--
+		return kvstore.ErrAborted
+	}
+	return kv.KVStore.SetAny(key, valueAny, highConsistency)
+}
+
+func TestCompareAndSetAborted(t *testing.T) {
+	ps := pubsubfake.New()
+	defer ps.Close()
+	ctx := context.Background()
+	store := kvstore.NewStore(&abortingKVStore{KVStore: ps.KVStore(), aborts: 1})
+
+	if _, err := store.CompareAndSet(ctx, "a", 0, wrapperspb.Int64(1)); !errors.Is(err, kvstore.ErrConflict) {
+		t.Errorf("CompareAndSet() of an aborted write returned %v, want %v", err, kvstore.ErrConflict)
+	}
+
+	store = kvstore.NewStore(&abortingKVStore{KVStore: ps.KVStore(), aborts: 2})
+	version, err := store.Update(ctx, "b", func(*anypb.Any) (proto.Message, error) {
+		return wrapperspb.Int64(1), nil
+	})
+	if err != nil {
+		t.Fatalf("Update() failed: %v", err)
+	}
+	if version != 1 {
+		t.Errorf("Update() = %d, want 1", version)
+	}
+}
+
+func TestVersionedValuesAreStoredAsIs(t *testing.T) {
+	ps := pubsubfake.New()
+	defer ps.Close()
+	store := kvstore.NewStore(ps.KVStore())
+	kv := store.KVStore()
+	ctx := context.Background()
+
+	values := make(chan proto.Message, 2)
+	sub, err := kv.Subscribe("config/*", pubsubinterface.TopicConfig{Qos: pubsubinterface.HighReliability}, &wrapperspb.Int64Value{},
+		func(_ string, value proto.Message) { values <- value },
+		func(string) {},
+		func(key string, _ *anypb.Any, err error) {
+			t.Errorf("Subscribe() reported an error for %q: %v", key, err)
+		})
+	if err != nil {
+		t.Fatalf("Subscribe() failed: %v", err)
+	}
+	defer sub.Close()
+
+	if _, err := store.CompareAndSet(ctx, "config/a", 0, wrapperspb.Int64(1)); err != nil {
+		t.Fatalf("CompareAndSet() failed: %v", err)
+	}
+	// Returning the old value as is must not wrap it into another Any.
+	version, err := store.Update(ctx, "config/a", func(old *anypb.Any) (proto.Message, error) { return old, nil })
+	if err != nil {
+		t.Fatalf("Update() failed: %v", err)
+	}
+	if version != 2 {
+		t.Errorf("Update() = %d, want 2", version)
+	}
+
+	value, err := kv.Get("config/a", nil)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	got := &wrapperspb.Int64Value{}
+	if err := value.UnmarshalTo(got); err != nil || got.GetValue() != 1 {
+		t.Errorf("Get() = %v, want 1", value)
+	}
+	for range 2 {
+		if got := <-values; !proto.Equal(got, wrapperspb.Int64(1)) {
+			t.Errorf("Subscribe() received %v, want 1", got)
+		}
+	}
+
+	var keys []string
+	for k, err := range store.Keys(ctx, "**") {
+		if err != nil {
+			t.Fatalf("Keys() failed: %v", err)
+		}
+		keys = append(keys, k)
+	}
+	if diff := cmp.Diff([]string{"config/a"}, keys); diff != "" {
+		t.Errorf("Keys() returned unexpected keys (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package typedpubsub provides typed, channel-based publishers and
+// subscriptions on top of pubsubinterface.PubSub.
+//
+//	msgs, err := typedpubsub.Subscribe[*mypb.Status](ctx, ps, "robot/status", typedpubsub.SubscribeConfig{})
+//	if err != nil {
+//		// error handling
+//	}
+//	for msg := range msgs {
+//		// msg has type *mypb.Status. The loop ends when ctx is done.
+//	}
+package typedpubsub
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"intrinsic/platform/pubsub/golang/pubsubinterface"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const defaultBufferSize = 16
+
+// OverflowPolicy determines what happens to a message that arrives while the
+// buffer of a subscription is full.
+type OverflowPolicy int
+
+const (
+	// DropOldest drops the oldest buffered message to make room for the new
+	// one.
+	DropOldest OverflowPolicy = iota
+	// DropNewest drops the new message.
+	DropNewest
+	// Block blocks the delivery of messages until there is room in the buffer.
+	// A slow consumer then delays all messages of the subscription, and
+	// messages may be dropped by the PubSub implementation instead, depending
+	// on the QoS.
+	Block
+)
+
+// SubscribeConfig configures a subscription created with Subscribe.
+type SubscribeConfig struct {
+	// TopicConfig is the configuration of the topic.
+	TopicConfig pubsubinterface.TopicConfig
+	// BufferSize is the capacity of the returned channel. Defaults to 16.
+	BufferSize int
+	// Overflow determines what happens when the buffer is full. Defaults to
+	// DropOldest.
+	Overflow OverflowPolicy
+	// Errors, if set, receives an error for each message that cannot be
+	// unmarshaled into the message type of the subscription. Errors are
+	// dropped if Errors is not ready to receive them. Subscribe never closes
+	// Errors.
+	Errors chan<- error
+}
+
+// Subscribe creates a subscription to topic that delivers messages of type T
+// on the returned channel. The subscription is closed and the channel is
+// closed when ctx is done.
+func Subscribe[T proto.Message](ctx context.Context, ps pubsubinterface.PubSub, topic string, cfg SubscribeConfig) (<-chan T, error) {
+	size := cfg.BufferSize
+	if size <= 0 {
+		size = defaultBufferSize
+	}
+	switch cfg.Overflow {
+	case DropOldest, DropNewest, Block:
+	default:
+		return nil, fmt.Errorf("unknown overflow policy %v", cfg.Overflow)
+	}
+	var zero T
+	exemplar := zero.ProtoReflect().New().Interface()
+
+	msgs := make(chan T, size)
+	// mu guards sending to and closing msgs.
+	var mu sync.Mutex
+	closed := false
+	send := func(msg T) {
+		mu.Lock()
+		defer mu.Unlock()
+		if closed {
+			return
+		}
+		switch cfg.Overflow {
+		case Block:
+			select {
+			case msgs <- msg:
+			case <-ctx.Done():
+			}
+			return
+		case DropOldest:
+			for {
+				select {
+				case msgs <- msg:
+					return
+				default:
+				}
+				select {
+				case <-msgs:
+				default:
+				}
+			}
+		case DropNewest:
+			select {
+			case msgs <- msg:
+			default:
+			}
+		}
+	}
+	onError := func(_ string, err error) {
+		if cfg.Errors == nil {
+			return
+		}
+		select {
+		case cfg.Errors <- fmt.Errorf("failed to unmarshal message on topic %q: %w", topic, err):
+		default:
+		}
+	}
+
+	sub, err := ps.NewSubscription(topic, cfg.TopicConfig, exemplar, func(msg proto.Message) {
+		send(msg.(T))
+	}, onError)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to topic %q: %w", topic, err)
+	}
+	go func() {
+		<-ctx.Done()
+		sub.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		closed = true
+		close(msgs)
+	}()
+	return msgs, nil
+}
+
+// Publisher publishes messages of type T to a topic.
+type Publisher[T proto.Message] struct {
+	pub pubsubinterface.Publisher
+}
+
+// NewPublisher creates a publisher for messages of type T. The caller should
+// Close it after use.
+func NewPublisher[T proto.Message](ps pubsubinterface.PubSub, topic string, config pubsubinterface.TopicConfig) (*Publisher[T], error) {
+	pub, err := ps.NewPublisher(topic, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create publisher for topic %q: %w", topic, err)
+	}
+	return &Publisher[T]{pub: pub}, nil
+}
+
+// Publish publishes msg.
+func (p *Publisher[T]) Publish(msg T) error {
+	return p.pub.Publish(msg)
+}
+
+// TopicName returns the name of the topic.
+func (p *Publisher[T]) TopicName() string {
+	return p.pub.TopicName()
+}
+
+// HasMatchingSubscribers returns true if there are subscribers for the topic.
+func (p *Publisher[T]) HasMatchingSubscribers() (bool, error) {
+	return p.pub.HasMatchingSubscribers()
+}
+
+// Close closes the publisher.
+func (p *Publisher[T]) Close() {
+	p.pub.Close()
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typedpubsub
+
+import (
+	"context"
+	"testing"
+
+	"intrinsic/platform/pubsub/golang/pubsubfake"
+	"intrinsic/platform/pubsub/golang/pubsubinterface"
+
+	"google.golang.org/protobuf/proto"
+
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var reliable = pubsubinterface.TopicConfig{Qos: pubsubinterface.HighReliability}
+
+func publish(t *testing.T, ps *pubsubfake.PubSub, topic string, msgs ...proto.Message) {
+	t.Helper()
+	pub, err := ps.NewPublisher(topic, reliable)
+	if err != nil {
+		t.Fatalf("NewPublisher() failed: %v", err)
+	}
+	defer pub.Close()
+	for _, msg := range msgs {
+		if err := pub.Publish(msg); err != nil {
+			t.Fatalf("Publish(%v) failed: %v", msg, err)
+		}
+	}
+	ps.Flush()
+}
+
+func TestSubscribe(t *testing.T) {
+	ps := pubsubfake.New()
+	defer ps.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errs := make(chan error, 1)
+	msgs, err := Subscribe[*wrapperspb.Int64Value](ctx, ps, "values", SubscribeConfig{TopicConfig: reliable, Errors: errs})
+	if err != nil {
+		t.Fatalf("Subscribe() failed: %v", err)
+	}
+	pub, err := NewPublisher[*wrapperspb.Int64Value](ps, "values", reliable)
+	if err != nil {
+		t.Fatalf("NewPublisher() failed: %v", err)
+	}
+	defer pub.Close()
+	if err := pub.Publish(wrapperspb.Int64(1)); err != nil {
+		t.Fatalf("Publish() failed: %v", err)
+	}
+	publish(t, ps, "values", wrapperspb.String("wrong type"))
+
+	if got := <-msgs; got.GetValue() != 1 {
+		t.Errorf("Subscribe() received %v, want 1", got)
+	}
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Errorf("Subscribe() reported a nil error")
+		}
+	default:
+		t.Errorf("Subscribe() did not report the message with the wrong type")
+	}
+
+	cancel()
+	for msg := range msgs {
+		t.Errorf("Subscribe() received %v after cancellation", msg)
+	}
+}
+
+func TestSubscribeOverflow(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy OverflowPolicy
+		want   []int64
+	}{
+		{name: "DropOldest", policy: DropOldest, want: []int64{2, 3}},
+		{name: "DropNewest", policy: DropNewest, want: []int64{1, 2}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := pubsubfake.New()
+			defer ps.Close()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			msgs, err := Subscribe[*wrapperspb.Int64Value](ctx, ps, "values", SubscribeConfig{
+				TopicConfig: reliable,
+				BufferSize:  2,
+				Overflow:    tc.policy,
+			})
+			if err != nil {
+				t.Fatalf("Subscribe() failed: %v", err)
+			}
+			publish(t, ps, "values", wrapperspb.Int64(1), wrapperspb.Int64(2), wrapperspb.Int64(3))
+			for i, want := range tc.want {
+				if got := <-msgs; got.GetValue() != want {
+					t.Errorf("message %d = %v, want %v", i, got.GetValue(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestSubscribeBlock(t *testing.T) {
+	ps := pubsubfake.New()
+	defer ps.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	msgs, err := Subscribe[*wrapperspb.Int64Value](ctx, ps, "values", SubscribeConfig{
+		TopicConfig: reliable,
+		BufferSize:  1,
+		Overflow:    Block,
+	})
+	if err != nil {
+		t.Fatalf("Subscribe() failed: %v", err)
+	}
+	pub, err := NewPublisher[*wrapperspb.Int64Value](ps, "values", reliable)
+	if err != nil {
+		t.Fatalf("NewPublisher() failed: %v", err)
+	}
+	defer pub.Close()
+	for i := int64(1); i <= 3; i++ {
+		if err := pub.Publish(wrapperspb.Int64(i)); err != nil {
+			t.Fatalf("Publish() failed: %v", err)
+		}
+	}
+	// No message is dropped although the buffer only holds one message.
+	for want := int64(1); want <= 3; want++ {
+		if got := <-msgs; got.GetValue() != want {
+			t.Errorf("Subscribe() received %v, want %v", got.GetValue(), want)
+		}
+	}
+}
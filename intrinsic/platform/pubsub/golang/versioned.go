@@ -0,0 +1,186 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kvstore
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	vvpb "intrinsic/platform/pubsub/versioned_value_go_proto"
+
+	anypb "google.golang.org/protobuf/types/known/anypb"
+)
+
+// VersionKeyPrefix is the prefix of the keys under which CompareAndSet stores
+// the versions of values. The version of the value for key is stored under
+// MakeKey(VersionKeyPrefix, key), so that the value itself is stored as is.
+// Store skips these keys when listing, reading and watching values.
+const VersionKeyPrefix = "_versions"
+
+// maxUpdateAttempts is the number of times Update tries to write a value
+// before it gives up because of conflicts.
+const maxUpdateAttempts = 10
+
+func versionKey(key string) string {
+	return MakeKey(VersionKeyPrefix, key)
+}
+
+// isVersionKey reports whether key, relative to the prefix of the store, holds
+// the version of a value.
+func isVersionKey(key string) bool {
+	return strings.HasPrefix(strings.TrimPrefix(key, "/"), VersionKeyPrefix+"/")
+}
+
+// getVersion returns the version stored for key. A key that was never written
+// with CompareAndSet has version 0.
+func (s *Store) getVersion(ctx context.Context, key string) (*vvpb.VersionedValue, error) {
+	stored, err := s.Get(ctx, versionKey(key))
+	if errors.Is(err, ErrNotFound) {
+		return &vvpb.VersionedValue{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	version := &vvpb.VersionedValue{}
+	if err := stored.UnmarshalTo(version); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal version of %q: %w", key, err)
+	}
+	return version, nil
+}
+
+// GetVersioned returns the value for the given key and its version. It
+// returns an error wrapping ErrNotFound if there is no value. Values that were
+// only written with Set have version 0.
+func (s *Store) GetVersioned(ctx context.Context, key string) (*anypb.Any, int64, error) {
+	version, err := s.getVersion(ctx, key)
+	if err != nil {
+		return nil, 0, err
+	}
+	value, err := s.Get(ctx, key)
+	if err != nil {
+		return nil, 0, err
+	}
+	return value, version.GetVersion(), nil
+}
+
+// CompareAndSet sets the value for the given key if its current version is
+// expectedVersion and returns the new version. An expectedVersion of 0 matches
+// a key that was never written with CompareAndSet. It returns an error
+// wrapping ErrConflict if the version does not match or a concurrent write is
+// detected. Like KVStore.Set, it writes an *anypb.Any value as is.
+//
+// The value is stored as is, so it can be read with Get and KVStore.Subscribe.
+// The version is stored under a separate key, see VersionKeyPrefix, and is
+// written first: a writer that loses the race for a version never writes its
+// value. Deleting the value with KVStore.Delete keeps its version.
+//
+// Conflicts are only reliably detected between writers that share this
+// Store. See ErrConflict for writers in other processes.
+func (s *Store) CompareAndSet(ctx context.Context, key string, expectedVersion int64, value proto.Message) (int64, error) {
+	valueAny, ok := value.(*anypb.Any)
+	if !ok {
+		var err error
+		if valueAny, err = anypb.New(value); err != nil {
+			return 0, fmt.Errorf("failed to marshal value for %q: %w", key, err)
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.getVersion(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	if current.GetVersion() != expectedVersion {
+		return 0, fmt.Errorf("%w: %q has version %d, want %d", ErrConflict, key, current.GetVersion(), expectedVersion)
+	}
+	writeID, err := newWriteID()
+	if err != nil {
+		return 0, err
+	}
+	next := &vvpb.VersionedValue{
+		Version: expectedVersion + 1,
+		WriteId: writeID,
+	}
+	if err := s.kv.Set(versionKey(key), next, true); errors.Is(err, ErrAborted) {
+		return 0, fmt.Errorf("%w: writing the version of %q was aborted: %w", ErrConflict, key, err)
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to set the version of %q: %w", key, err)
+	}
+
+	// Read the version back to detect writers that raced with this one.
+	written, err := s.getVersion(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	if written.GetWriteId() != writeID {
+		return 0, fmt.Errorf("%w: the version of %q was written concurrently", ErrConflict, key)
+	}
+
+	if err := s.kv.SetAny(key, valueAny, true); errors.Is(err, ErrAborted) {
+		return 0, fmt.Errorf("%w: writing %q was aborted: %w", ErrConflict, key, err)
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to set %q: %w", key, err)
+	}
+	return next.GetVersion(), nil
+}
+
+// Update sets the value for the given key to the result of fn, applied to the
+// current value, and returns the new version. fn receives nil if there is no
+// value. If a concurrent write is detected, Update calls fn again with the new
+// value; fn should therefore not have side effects. An error returned by fn is
+// returned as is.
+func (s *Store) Update(ctx context.Context, key string, fn func(old *anypb.Any) (proto.Message, error)) (int64, error) {
+	for range maxUpdateAttempts {
+		current, err := s.getVersion(ctx, key)
+		if err != nil {
+			return 0, err
+		}
+		old, err := s.Get(ctx, key)
+		if errors.Is(err, ErrNotFound) {
+			old, err = nil, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		value, err := fn(old)
+		if err != nil {
+			return 0, err
+		}
+		version, err := s.CompareAndSet(ctx, key, current.GetVersion(), value)
+		if errors.Is(err, ErrConflict) {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return 0, fmt.Errorf("failed to update %q: %w", key, ctxErr)
+			}
+			continue
+		}
+		return version, err
+	}
+	return 0, fmt.Errorf("%w: failed to update %q after %d attempts", ErrConflict, key, maxUpdateAttempts)
+}
+
+func newWriteID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate write ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package icon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"intrinsic/tools/inctl/util/cobrautil"
+	"intrinsic/tools/inctl/util/printer"
+
+	"github.com/spf13/cobra"
+
+	loggingmodepb "intrinsic/icon/proto/logging_mode_go_proto"
+)
+
+const loggingModePrefix = "LOGGING_MODE_"
+
+// loggingModeView is the logging mode of the ICON server.
+type loggingModeView struct {
+	LoggingMode string `json:"logging_mode"`
+}
+
+func (v *loggingModeView) String() string {
+	return v.LoggingMode
+}
+
+func newLoggingModeView(mode loggingmodepb.LoggingMode) *loggingModeView {
+	return &loggingModeView{LoggingMode: strings.ToLower(strings.TrimPrefix(mode.String(), loggingModePrefix))}
+}
+
+// parseLoggingMode accepts the name of a logging mode with or without prefix,
+// in any case, e.g. "throttled" or "LOGGING_MODE_THROTTLED".
+func parseLoggingMode(s string) (loggingmodepb.LoggingMode, error) {
+	name := strings.ToUpper(s)
+	if !strings.HasPrefix(name, loggingModePrefix) {
+		name = loggingModePrefix + name
+	}
+	mode, ok := loggingmodepb.LoggingMode_value[name]
+	if !ok || mode == int32(loggingmodepb.LoggingMode_LOGGING_MODE_UNSPECIFIED) {
+		return 0, fmt.Errorf("invalid logging mode %q, want %q or %q", s,
+			newLoggingModeView(loggingmodepb.LoggingMode_LOGGING_MODE_FULL_RATE), newLoggingModeView(loggingmodepb.LoggingMode_LOGGING_MODE_THROTTLED))
+	}
+	return loggingmodepb.LoggingMode(mode), nil
+}
+
+func getLoggingMode(ctx context.Context, prtr printer.CommandPrinter) error {
+	ctx, client, err := makeIconClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	mode, err := client.GetLoggingMode(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get logging mode: %w", err)
+	}
+	prtr.Println(newLoggingModeView(mode))
+	return nil
+}
+
+func setLoggingMode(ctx context.Context, prtr printer.CommandPrinter, value string) error {
+	mode, err := parseLoggingMode(value)
+	if err != nil {
+		return err
+	}
+
+	ctx, client, err := makeIconClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.SetLoggingMode(ctx, mode); err != nil {
+		return fmt.Errorf("failed to set logging mode: %w", err)
+	}
+	prtr.Println(newLoggingModeView(mode))
+	return nil
+}
+
+var iconLoggingModeCmd = cobrautil.ParentOfNestedSubcommands("logging-mode", "Get or set the logging mode of the ICON server")
+
+var iconLoggingModeGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Print the logging mode, full_rate or throttled",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		prtr, err := printer.NewPrinterFromCommand(cmd)
+		if err != nil {
+			return err
+		}
+		return getLoggingMode(cmd.Context(), prtr)
+	},
+}
+
+var iconLoggingModeSetCmd = &cobra.Command{
+	Use:   "set full_rate|throttled",
+	Short: "Set the logging mode",
+	Long: `Set the logging mode, i.e., whether the robot status is logged at every control cycle (full_rate) or at a throttled rate (throttled).
+
+$ inctl icon logging-mode set throttled`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		prtr, err := printer.NewPrinterFromCommand(cmd)
+		if err != nil {
+			return err
+		}
+		return setLoggingMode(cmd.Context(), prtr, args[0])
+	},
+}
+
+func init() {
+	iconLoggingModeCmd.AddCommand(iconLoggingModeGetCmd)
+	iconLoggingModeCmd.AddCommand(iconLoggingModeSetCmd)
+	iconCmd.AddCommand(iconLoggingModeCmd)
+}
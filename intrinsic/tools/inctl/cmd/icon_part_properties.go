@@ -0,0 +1,210 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package icon
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"intrinsic/icon/go/icon"
+	"intrinsic/tools/inctl/util/cobrautil"
+	"intrinsic/tools/inctl/util/printer"
+
+	"github.com/spf13/cobra"
+
+	pb "intrinsic/icon/proto/v1/service_go_proto"
+)
+
+// partPropertiesView holds the part properties of some or all parts.
+type partPropertiesView struct {
+	Time *time.Time `json:"time,omitempty"`
+	// Parts maps part names to property names to bool or float64 values.
+	Parts map[string]map[string]any `json:"parts"`
+}
+
+func (v *partPropertiesView) String() string {
+	var lines []string
+	for part, properties := range v.Parts {
+		for name, value := range properties {
+			lines = append(lines, fmt.Sprintf("%s.%s: %v", part, name, value))
+		}
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
+func partPropertyValue(v *pb.PartPropertyValue) any {
+	switch v := v.GetValue().(type) {
+	case *pb.PartPropertyValue_BoolValue:
+		return v.BoolValue
+	case *pb.PartPropertyValue_DoubleValue:
+		return v.DoubleValue
+	default:
+		return nil
+	}
+}
+
+// parsePartPropertyValue parses value with the type of the current value of
+// the property.
+func parsePartPropertyValue(current *pb.PartPropertyValue, value string) (*pb.PartPropertyValue, error) {
+	switch current.GetValue().(type) {
+	case *pb.PartPropertyValue_BoolValue:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bool %q", value)
+		}
+		return icon.BoolPartPropertyValue(b), nil
+	case *pb.PartPropertyValue_DoubleValue:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", value)
+		}
+		return icon.Float64PartPropertyValue(f), nil
+	default:
+		return nil, fmt.Errorf("unsupported property type %T", current.GetValue())
+	}
+}
+
+// parsePartPropertyAssignments parses PART.PROPERTY=VALUE arguments, using
+// the current properties to look up the type of each property.
+func parsePartPropertyAssignments(current *pb.GetPartPropertiesResponse, args []string) (map[string]map[string]*pb.PartPropertyValue, error) {
+	properties := make(map[string]map[string]*pb.PartPropertyValue)
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		part, name, ok2 := strings.Cut(key, ".")
+		if !ok || !ok2 {
+			return nil, fmt.Errorf("invalid argument %q, want PART.PROPERTY=VALUE", arg)
+		}
+		currentValue, ok := current.GetPartPropertiesByPartName()[part].GetPropertyValuesByName()[name]
+		if !ok {
+			return nil, fmt.Errorf("part %q has no property %q", part, name)
+		}
+		v, err := parsePartPropertyValue(currentValue, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+		if properties[part] == nil {
+			properties[part] = make(map[string]*pb.PartPropertyValue)
+		}
+		properties[part][name] = v
+	}
+	return properties, nil
+}
+
+func getPartProperties(ctx context.Context, prtr printer.CommandPrinter, parts []string) error {
+	ctx, client, err := makeIconClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	resp, err := client.GetPartProperties(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get part properties: %w", err)
+	}
+	v := &partPropertiesView{Parts: make(map[string]map[string]any)}
+	if resp.GetTimestampWall() != nil {
+		t := resp.GetTimestampWall().AsTime()
+		v.Time = &t
+	}
+	for part, properties := range resp.GetPartPropertiesByPartName() {
+		if len(parts) > 0 && !slices.Contains(parts, part) {
+			continue
+		}
+		v.Parts[part] = make(map[string]any)
+		for name, value := range properties.GetPropertyValuesByName() {
+			v.Parts[part][name] = partPropertyValue(value)
+		}
+	}
+	for _, part := range parts {
+		if _, ok := v.Parts[part]; !ok {
+			return fmt.Errorf("part %q has no properties", part)
+		}
+	}
+	prtr.Println(v)
+	return nil
+}
+
+func setPartProperties(ctx context.Context, prtr printer.CommandPrinter, args []string) error {
+	ctx, client, err := makeIconClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	current, err := client.GetPartProperties(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get part properties: %w", err)
+	}
+	properties, err := parsePartPropertyAssignments(current, args)
+	if err != nil {
+		return err
+	}
+	if err := client.SetPartProperties(ctx, properties); err != nil {
+		return fmt.Errorf("failed to set part properties: %w", err)
+	}
+	v := &partPropertiesView{Parts: make(map[string]map[string]any)}
+	for part, values := range properties {
+		v.Parts[part] = make(map[string]any)
+		for name, value := range values {
+			v.Parts[part][name] = partPropertyValue(value)
+		}
+	}
+	prtr.Println(v)
+	return nil
+}
+
+var iconPartPropertiesCmd = cobrautil.ParentOfNestedSubcommands("part-properties", "Get or set properties of ICON parts")
+
+var iconPartPropertiesGetCmd = &cobra.Command{
+	Use:   "get [PART...]",
+	Short: "Print the properties of all or the given parts",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		prtr, err := printer.NewPrinterFromCommand(cmd)
+		if err != nil {
+			return err
+		}
+		return getPartProperties(cmd.Context(), prtr, args)
+	},
+}
+
+var iconPartPropertiesSetCmd = &cobra.Command{
+	Use:   "set PART.PROPERTY=VALUE...",
+	Short: "Set properties of parts",
+	Long: `Set properties of parts.
+
+Values are parsed according to the type of the property, which is either a bool or a number. Use "get" to list the available properties.
+
+$ inctl icon part-properties set robot.internal_controller_p_value=1.23 gripper.enabled=true`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		prtr, err := printer.NewPrinterFromCommand(cmd)
+		if err != nil {
+			return err
+		}
+		return setPartProperties(cmd.Context(), prtr, args)
+	},
+}
+
+func init() {
+	iconPartPropertiesCmd.AddCommand(iconPartPropertiesGetCmd)
+	iconPartPropertiesCmd.AddCommand(iconPartPropertiesSetCmd)
+	iconCmd.AddCommand(iconPartPropertiesCmd)
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package icon
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"intrinsic/tools/inctl/util/cobrautil"
+	"intrinsic/tools/inctl/util/printer"
+
+	"github.com/spf13/cobra"
+)
+
+// speedOverrideView is the speed override of the ICON server.
+type speedOverrideView struct {
+	SpeedOverride float64 `json:"speed_override"`
+}
+
+func (v *speedOverrideView) String() string {
+	return strconv.FormatFloat(v.SpeedOverride, 'g', -1, 64)
+}
+
+func getSpeedOverride(ctx context.Context, prtr printer.CommandPrinter) error {
+	ctx, client, err := makeIconClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	speedOverride, err := client.GetSpeedOverride(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get speed override: %w", err)
+	}
+	prtr.Println(&speedOverrideView{SpeedOverride: speedOverride})
+	return nil
+}
+
+func setSpeedOverride(ctx context.Context, prtr printer.CommandPrinter, value string) error {
+	speedOverride, err := strconv.ParseFloat(value, 64)
+	if err != nil || speedOverride < 0 || speedOverride > 1 {
+		return fmt.Errorf("invalid speed override %q, want a number between 0 and 1", value)
+	}
+
+	ctx, client, err := makeIconClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.SetSpeedOverride(ctx, speedOverride); err != nil {
+		return fmt.Errorf("failed to set speed override: %w", err)
+	}
+	prtr.Println(&speedOverrideView{SpeedOverride: speedOverride})
+	return nil
+}
+
+var iconSpeedOverrideCmd = cobrautil.ParentOfNestedSubcommands("speed-override", "Get or set the speed override of the ICON server")
+
+var iconSpeedOverrideGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Print the speed override, a number between 0 and 1",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		prtr, err := printer.NewPrinterFromCommand(cmd)
+		if err != nil {
+			return err
+		}
+		return getSpeedOverride(cmd.Context(), prtr)
+	},
+}
+
+var iconSpeedOverrideSetCmd = &cobra.Command{
+	Use:   "set VALUE",
+	Short: "Set the speed override to a number between 0 and 1",
+	Long: `Set the speed override to a number between 0 and 1.
+
+Compatible actions scale down their speed according to the speed override.
+
+$ inctl icon speed-override set 0.5`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		prtr, err := printer.NewPrinterFromCommand(cmd)
+		if err != nil {
+			return err
+		}
+		return setSpeedOverride(cmd.Context(), prtr, args[0])
+	},
+}
+
+func init() {
+	iconSpeedOverrideCmd.AddCommand(iconSpeedOverrideGetCmd)
+	iconSpeedOverrideCmd.AddCommand(iconSpeedOverrideSetCmd)
+	iconCmd.AddCommand(iconSpeedOverrideCmd)
+}
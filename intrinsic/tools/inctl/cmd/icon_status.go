@@ -17,71 +17,197 @@ package icon
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"sort"
+	"strings"
+	"time"
+
+	"intrinsic/icon/go/icon"
+	"intrinsic/tools/inctl/util/printer"
 
 	"github.com/spf13/cobra"
 )
 
-func showStatus(ctx context.Context) error {
-	ctx, client, err := makeIconClient(ctx)
-	if err != nil {
-		return err
-	}
-	defer client.Close()
+var (
+	flagWatch         bool
+	flagWatchInterval time.Duration
+)
 
-	opStatus, err := client.OperationalStatus(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to get operational status: %w", err)
-	}
+// partStatusView is the status of a single part.
+type partStatusView struct {
+	State       string `json:"state"`
+	FaultReason string `json:"fault_reason,omitempty"`
+}
 
-	fmt.Printf("Operational Status: %s\n", opStatus.GetState())
-	if opStatus.GetFaultReason() != "" {
-		fmt.Printf("Fault Reason:      %s\n", opStatus.GetFaultReason())
-	}
+// safetyStatusView is the status of the safety system.
+type safetyStatusView struct {
+	ModeOfSafeOperation string `json:"mode_of_safe_operation"`
+	EstopButtonStatus   string `json:"estop_button_status"`
+	EnableButtonStatus  string `json:"enable_button_status"`
+	RequestedBehavior   string `json:"requested_behavior"`
+}
 
-	status, err := client.Status(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to get detailed status: %w", err)
+// statusView is the status of the ICON server and its parts.
+type statusView struct {
+	// Time is only set when watching the status.
+	Time             *time.Time                `json:"time,omitempty"`
+	OperationalState string                    `json:"operational_state"`
+	FaultReason      string                    `json:"fault_reason,omitempty"`
+	Parts            map[string]partStatusView `json:"parts,omitempty"`
+	Safety           *safetyStatusView         `json:"safety,omitempty"`
+}
+
+func (v *statusView) String() string {
+	var b strings.Builder
+	if v.Time != nil {
+		fmt.Fprintf(&b, "[%s]\n", v.Time.Format(time.RFC3339))
+	}
+	fmt.Fprintf(&b, "Operational Status: %s\n", v.OperationalState)
+	if v.FaultReason != "" {
+		fmt.Fprintf(&b, "Fault Reason:      %s\n", v.FaultReason)
 	}
 
-	if len(status.GetPartStatus()) > 0 {
-		fmt.Println("\nPart Statuses:")
+	if len(v.Parts) > 0 {
+		b.WriteString("\nPart Statuses:\n")
 		// Sort part names for consistent output
 		var partNames []string
-		for name := range status.GetPartStatus() {
+		for name := range v.Parts {
 			partNames = append(partNames, name)
 		}
 		sort.Strings(partNames)
 
 		for _, name := range partNames {
-			partStatus := status.GetPartStatus()[name]
-			fmt.Printf("  %s:\n", name)
-			fmt.Printf("    State: %s\n", partStatus.GetOperationalStatus().GetState())
-			if partStatus.GetOperationalStatus().GetFaultReason() != "" {
-				fmt.Printf("    Fault: %s\n", partStatus.GetOperationalStatus().GetFaultReason())
+			partStatus := v.Parts[name]
+			fmt.Fprintf(&b, "  %s:\n", name)
+			fmt.Fprintf(&b, "    State: %s\n", partStatus.State)
+			if partStatus.FaultReason != "" {
+				fmt.Fprintf(&b, "    Fault: %s\n", partStatus.FaultReason)
 			}
 		}
 	}
 
-	if status.SafetyStatus != nil {
-		fmt.Println("\nSafety Status:")
-		fmt.Printf("  Mode of Safe Operation: %s\n", status.SafetyStatus.GetModeOfSafeOperation())
-		fmt.Printf("  E-Stop Button Status:   %s\n", status.SafetyStatus.GetEstopButtonStatus())
-		fmt.Printf("  Enable Button Status:   %s\n", status.SafetyStatus.GetEnableButtonStatus())
-		fmt.Printf("  Requested Behavior:     %s\n", status.SafetyStatus.GetRequestedBehavior())
+	if v.Safety != nil {
+		b.WriteString("\nSafety Status:\n")
+		fmt.Fprintf(&b, "  Mode of Safe Operation: %s\n", v.Safety.ModeOfSafeOperation)
+		fmt.Fprintf(&b, "  E-Stop Button Status:   %s\n", v.Safety.EstopButtonStatus)
+		fmt.Fprintf(&b, "  Enable Button Status:   %s\n", v.Safety.EnableButtonStatus)
+		fmt.Fprintf(&b, "  Requested Behavior:     %s\n", v.Safety.RequestedBehavior)
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func getStatus(ctx context.Context, client icon.Client) (*statusView, error) {
+	opStatus, err := client.OperationalStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operational status: %w", err)
+	}
+	status, err := client.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get detailed status: %w", err)
 	}
 
+	v := &statusView{
+		OperationalState: opStatus.GetState().String(),
+		FaultReason:      opStatus.GetFaultReason(),
+	}
+	for name, partStatus := range status.GetPartStatus() {
+		if v.Parts == nil {
+			v.Parts = make(map[string]partStatusView)
+		}
+		v.Parts[name] = partStatusView{
+			State:       partStatus.GetOperationalStatus().GetState().String(),
+			FaultReason: partStatus.GetOperationalStatus().GetFaultReason(),
+		}
+	}
+	if s := status.GetSafetyStatus(); s != nil {
+		v.Safety = &safetyStatusView{
+			ModeOfSafeOperation: s.GetModeOfSafeOperation().String(),
+			EstopButtonStatus:   s.GetEstopButtonStatus().String(),
+			EnableButtonStatus:  s.GetEnableButtonStatus().String(),
+			RequestedBehavior:   s.GetRequestedBehavior().String(),
+		}
+	}
+	return v, nil
+}
+
+func showStatus(ctx context.Context, prtr printer.CommandPrinter) error {
+	ctx, client, err := makeIconClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	v, err := getStatus(ctx, client)
+	if err != nil {
+		return err
+	}
+	prtr.Println(v)
 	return nil
 }
 
+// watchStatus polls the status every interval and prints it whenever it
+// changes, until ctx is canceled.
+func watchStatus(ctx context.Context, prtr printer.CommandPrinter, interval time.Duration) error {
+	ctx, client, err := makeIconClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var previous *statusView
+	for {
+		v, err := getStatus(ctx, client)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if previous == nil || !reflect.DeepEqual(previous, v) {
+			previous = v
+			now := time.Now()
+			// Only the printed copy has a time, so that it does not count as a change.
+			printed := *v
+			printed.Time = &now
+			prtr.Println(&printed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
 var iconStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show the current status of the ICON server and its parts",
+	Long: `Show the current status of the ICON server and its parts.
+
+With --watch, the status is polled continuously and printed whenever it changes:
+$ inctl icon status --watch [--interval 500ms]
+
+With --output ndjson (or json), every status update is printed as one line of JSON.`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return showStatus(cmd.Context())
+		prtr, err := printer.NewPrinterFromCommand(cmd)
+		if err != nil {
+			return err
+		}
+		if flagWatch {
+			if flagWatchInterval <= 0 {
+				return fmt.Errorf("--interval must be positive, got %v", flagWatchInterval)
+			}
+			return watchStatus(cmd.Context(), prtr, flagWatchInterval)
+		}
+		return showStatus(cmd.Context(), prtr)
 	},
 }
 
 func init() {
+	iconStatusCmd.Flags().BoolVar(&flagWatch, "watch", false, "Continuously print the status whenever it changes")
+	iconStatusCmd.Flags().DurationVar(&flagWatchInterval, "interval", time.Second, "Interval at which the status is polled with --watch")
 	iconCmd.AddCommand(iconStatusCmd)
 }
@@ -0,0 +1,335 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"intrinsic/executive/go/blackboard"
+	blackboardpb "intrinsic/executive/proto/blackboard_service_go_proto"
+	executiveservicepb "intrinsic/executive/proto/executive_service_go_proto"
+	protoregistrygrpcpb "intrinsic/proto_tools/proto/proto_registry_go_proto"
+	"intrinsic/proto_tools/registry/protoregistryclient"
+	"intrinsic/tools/inctl/cmd/root"
+	"intrinsic/tools/inctl/util/cobrautil"
+	"intrinsic/tools/inctl/util/printer"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	// JSONFormat is the JSON format for blackboard values.
+	JSONFormat = "json"
+)
+
+var (
+	flagBlackboardScope string
+	flagValueFormat     string
+	flagValueTypeURL    string
+)
+
+// blackboardSession bundles the state needed by the blackboard commands.
+type blackboardSession struct {
+	client    *blackboard.Client
+	operation string
+	// resolver resolves the type URLs of blackboard values. It knows the types
+	// of the proto registry, of the file descriptor sets in the operation's
+	// behavior tree and all compiled-in types.
+	resolver protoregistryclient.Resolver
+}
+
+func newBlackboardSession(ctx context.Context, conn *grpc.ClientConn) (*blackboardSession, error) {
+	exC := executiveservicepb.NewExecutiveServiceClient(conn)
+	op, err := resolveOperation(ctx, exC, flagOperationName)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := operationMetadata(op)
+	if err != nil {
+		return nil, err
+	}
+	nodeTypes, err := MergedTypesForAllScriptNodesInTree(ctx, metadata.GetBehaviorTree())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating merged Types from behavior tree script nodes")
+	}
+
+	return &blackboardSession{
+		client:    blackboard.NewClient(conn),
+		operation: op.GetName(),
+		resolver: protoregistryclient.NewProtoRegistryResolver(
+			ctx,
+			protoregistrygrpcpb.NewProtoRegistryClient(conn),
+			[]protoregistryclient.Resolver{nodeTypes, protoregistry.GlobalTypes},
+		),
+	}, nil
+}
+
+// blackboardEntry is the output of the blackboard commands.
+type blackboardEntry struct {
+	Key     string          `json:"key"`
+	Scope   string          `json:"scope,omitempty"`
+	TypeURL string          `json:"type_url"`
+	Value   json.RawMessage `json:"value,omitempty"`
+
+	// text is the text representation of the value (if requested).
+	text string
+}
+
+func (e *blackboardEntry) String() string {
+	s := e.Key
+	if e.Scope != "" {
+		s += fmt.Sprintf(" (scope: %s)", e.Scope)
+	}
+	s += " " + e.TypeURL
+	if e.text != "" {
+		s += "\n" + e.text
+	}
+	return s
+}
+
+// newBlackboardEntry converts the given blackboard value for printing. The
+// value itself is only included if withValue is true.
+func (s *blackboardSession) newBlackboardEntry(value *blackboardpb.BlackboardValue, withValue bool) (*blackboardEntry, error) {
+	entry := &blackboardEntry{
+		Key:     value.GetKey(),
+		Scope:   value.GetScope(),
+		TypeURL: value.GetValue().GetTypeUrl(),
+	}
+	if !withValue {
+		return entry, nil
+	}
+
+	msg, err := anypb.UnmarshalNew(value.GetValue(), proto.UnmarshalOptions{Resolver: s.resolver})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not unpack value of %q", value.GetKey())
+	}
+	entry.Value, err = protojson.MarshalOptions{Resolver: s.resolver}.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not marshal value of %q to JSON", value.GetKey())
+	}
+	switch flagValueFormat {
+	case TextProtoFormat:
+		entry.text = prototext.MarshalOptions{Resolver: s.resolver, Multiline: true, Indent: "  "}.Format(msg)
+	case JSONFormat:
+		entry.text = string(entry.Value)
+	default:
+		return nil, fmt.Errorf("unknown value format %q", flagValueFormat)
+	}
+	return entry, nil
+}
+
+// parseValue parses the given value in the format given by --value_format
+// into a message of the given type.
+func (s *blackboardSession) parseValue(typeURL string, content string) (*anypb.Any, error) {
+	msgType, err := s.resolver.FindMessageByURL(typeURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not resolve type %q", typeURL)
+	}
+	msg := msgType.New().Interface()
+	switch flagValueFormat {
+	case TextProtoFormat:
+		err = prototext.UnmarshalOptions{Resolver: s.resolver}.Unmarshal([]byte(content), msg)
+	case JSONFormat:
+		err = protojson.UnmarshalOptions{Resolver: s.resolver}.Unmarshal([]byte(content), msg)
+	default:
+		return nil, fmt.Errorf("unknown value format %q", flagValueFormat)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse value as %s", msgType.Descriptor().FullName())
+	}
+	value, err := anypb.New(msg)
+	if err != nil {
+		return nil, err
+	}
+	// Keep the given type URL, anypb.New() always uses the default prefix.
+	value.TypeUrl = typeURL
+	return value, nil
+}
+
+func addValueFormatFlag(cmd *cobra.Command) {
+	allowedFormats := []string{TextProtoFormat, JSONFormat}
+	cmd.Flags().StringVar(
+		&flagValueFormat, "value_format", TextProtoFormat,
+		fmt.Sprintf("(optional) format of blackboard values. One of: (%s)", strings.Join(allowedFormats, ", ")))
+}
+
+var blackboardCmd = cobrautil.ParentOfNestedSubcommands(
+	root.BlackboardCmdName,
+	"Interacts with the blackboard of executive operations",
+)
+
+var blackboardListCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "list",
+	Short: "List the blackboard values of an operation.",
+	Long: `List the keys and types of the blackboard values of an operation.
+
+$ inctl blackboard list --org my_org --solution my_solution_id [--operation NAME] [--scope SCOPE] [--with_values]`,
+	Args: cobra.NoArgs,
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	s, err := newBlackboardSession(ctx, conn)
+	if err != nil {
+		return err
+	}
+	withValues, err := cmd.Flags().GetBool("with_values")
+	if err != nil {
+		return err
+	}
+	values, err := s.client.List(ctx, s.operation, blackboard.ListOptions{
+		Scope:      flagBlackboardScope,
+		WithValues: withValues,
+	})
+	if err != nil {
+		return err
+	}
+
+	prtr, err := printer.NewPrinterFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	for _, value := range values {
+		entry, err := s.newBlackboardEntry(value, withValues)
+		if err != nil {
+			return err
+		}
+		prtr.Println(entry)
+	}
+	return nil
+})
+
+var blackboardGetCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "get KEY",
+	Short: "Get a blackboard value.",
+	Long: `Get a value from the blackboard of an operation.
+
+$ inctl blackboard get --org my_org --solution my_solution_id [--operation NAME] [--scope SCOPE] [--value_format textproto|json] my_key`,
+	Args: cobra.ExactArgs(1),
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	s, err := newBlackboardSession(ctx, conn)
+	if err != nil {
+		return err
+	}
+	value, err := s.client.Get(ctx, s.operation, flagBlackboardScope, args[0])
+	if err != nil {
+		return err
+	}
+	entry, err := s.newBlackboardEntry(value, true)
+	if err != nil {
+		return err
+	}
+
+	prtr, err := printer.NewPrinterFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	prtr.Println(entry)
+	return nil
+})
+
+var blackboardSetCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "set KEY VALUE",
+	Short: "Set a blackboard value.",
+	Long: `Set a value on the blackboard of an operation.
+
+The value is parsed according to --value_format. If --type_url is not given, the type of the existing value is used.
+
+$ inctl blackboard set --org my_org --solution my_solution_id [--operation NAME] [--scope SCOPE] my_key 'value: 3'
+$ inctl blackboard set --org my_org --solution my_solution_id --value_format json --type_url type.googleapis.com/google.protobuf.Int64Value my_key '"3"'`,
+	Args: cobra.ExactArgs(2),
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	s, err := newBlackboardSession(ctx, conn)
+	if err != nil {
+		return err
+	}
+	key := args[0]
+
+	typeURL := flagValueTypeURL
+	if typeURL == "" {
+		existing, err := s.client.Get(ctx, s.operation, flagBlackboardScope, key)
+		if err != nil {
+			return errors.Wrap(err, "could not determine type of value, use --type_url to set it")
+		}
+		typeURL = existing.GetValue().GetTypeUrl()
+	}
+	value, err := s.parseValue(typeURL, args[1])
+	if err != nil {
+		return err
+	}
+	updated, err := s.client.Set(ctx, s.operation, flagBlackboardScope, key, value)
+	if err != nil {
+		return err
+	}
+	entry, err := s.newBlackboardEntry(updated, true)
+	if err != nil {
+		return err
+	}
+
+	prtr, err := printer.NewPrinterFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	prtr.Println(entry)
+	return nil
+})
+
+var blackboardDeleteCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "delete KEY",
+	Short: "Delete a blackboard value.",
+	Long: `Delete a value from the blackboard of an operation.
+
+$ inctl blackboard delete --org my_org --solution my_solution_id [--operation NAME] [--scope SCOPE] my_key`,
+	Args: cobra.ExactArgs(1),
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	exC := executiveservicepb.NewExecutiveServiceClient(conn)
+	op, err := resolveOperation(ctx, exC, flagOperationName)
+	if err != nil {
+		return err
+	}
+	if err := blackboard.NewClient(conn).Delete(ctx, op.GetName(), flagBlackboardScope, args[0]); err != nil {
+		return err
+	}
+
+	prtr, err := printer.NewPrinterFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	printer.PrintMsgf(prtr, "Deleted blackboard value %q.", args[0])
+	return nil
+})
+
+func init() {
+	for _, cmd := range []*cobra.Command{blackboardListCmd, blackboardGetCmd, blackboardSetCmd, blackboardDeleteCmd} {
+		addOperationFlag(cmd)
+		cmd.Flags().StringVar(&flagBlackboardScope, "scope", "", "Scope of the blackboard value. Defaults to the scope of the process tree (or all scopes for list).")
+		blackboardCmd.AddCommand(cmd)
+	}
+	for _, cmd := range []*cobra.Command{blackboardListCmd, blackboardGetCmd, blackboardSetCmd} {
+		addValueFormatFlag(cmd)
+	}
+	blackboardListCmd.Flags().Bool("with_values", false, "Also print the values, not only keys and types.")
+	blackboardSetCmd.Flags().StringVar(&flagValueTypeURL, "type_url", "", "Type URL of the value. Defaults to the type of the existing value.")
+
+	blackboardCmd.AddCommand(blackboardSnapshotCmd)
+	root.RootCmd.AddCommand(blackboardCmd)
+}
@@ -0,0 +1,288 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"intrinsic/executive/go/blackboard"
+	blackboardpb "intrinsic/executive/proto/blackboard_service_go_proto"
+	executiveservicepb "intrinsic/executive/proto/executive_service_go_proto"
+	"intrinsic/tools/inctl/util/cobrautil"
+	"intrinsic/tools/inctl/util/printer"
+	"intrinsic/util/status/extstatus"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	flagSnapshotDisplayName string
+	flagSnapshotFormat      string
+)
+
+// snapshotInfo is the output of the snapshot commands.
+type snapshotInfo struct {
+	Handle      string    `json:"handle"`
+	DisplayName string    `json:"display_name,omitempty"`
+	SaveTime    time.Time `json:"save_time"`
+	SizeBytes   uint64    `json:"estimated_size_bytes"`
+	Source      string    `json:"source"`
+}
+
+func newSnapshotInfo(snapshot *blackboardpb.BlackboardSnapshot) *snapshotInfo {
+	return &snapshotInfo{
+		Handle:      snapshot.GetHandle(),
+		DisplayName: snapshot.GetDisplayName(),
+		SaveTime:    snapshot.GetSaveTime().AsTime(),
+		SizeBytes:   snapshot.GetEstimatedSizeBytes(),
+		Source:      strings.TrimPrefix(snapshot.GetSnapshotSource().String(), "SNAPSHOT_SOURCE_"),
+	}
+}
+
+func (s *snapshotInfo) String() string {
+	return fmt.Sprintf("%s\t%q\t%s\t%d bytes\t%s", s.Handle, s.DisplayName,
+		s.SaveTime.Local().Format(time.DateTime), s.SizeBytes, strings.ToLower(s.Source))
+}
+
+func addSnapshotFormatFlag(cmd *cobra.Command) {
+	allowedFormats := []string{TextProtoFormat, BinaryProtoFormat}
+	cmd.Flags().StringVar(
+		&flagSnapshotFormat, "snapshot_format", BinaryProtoFormat,
+		fmt.Sprintf("(optional) format of the snapshot file. One of: (%s)", strings.Join(allowedFormats, ", ")))
+}
+
+var blackboardSnapshotCmd = cobrautil.ParentOfNestedSubcommands(
+	"snapshot",
+	"Manage blackboard snapshots",
+)
+
+var blackboardSnapshotCreateCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "create",
+	Short: "Save the blackboard of an operation as a snapshot.",
+	Long: `Save the blackboard of an operation as a snapshot on the executive.
+
+$ inctl blackboard snapshot create --org my_org --solution my_solution_id [--operation NAME] [--display_name NAME]`,
+	Args: cobra.NoArgs,
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	exC := executiveservicepb.NewExecutiveServiceClient(conn)
+	op, err := resolveOperation(ctx, exC, flagOperationName)
+	if err != nil {
+		return err
+	}
+	snapshot, err := blackboard.NewClient(conn).CreateSnapshot(ctx, op.GetName(), flagSnapshotDisplayName)
+	if err != nil {
+		return err
+	}
+
+	prtr, err := printer.NewPrinterFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	prtr.Println(newSnapshotInfo(snapshot))
+	return nil
+})
+
+var blackboardSnapshotLoadCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "load HANDLE",
+	Short: "Load a snapshot into the blackboard of an operation.",
+	Long: `Load a snapshot into the blackboard of an operation. Values of the snapshot overwrite existing values with the same key.
+
+$ inctl blackboard snapshot load --org my_org --solution my_solution_id [--operation NAME] HANDLE`,
+	Args: cobra.ExactArgs(1),
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	exC := executiveservicepb.NewExecutiveServiceClient(conn)
+	op, err := resolveOperation(ctx, exC, flagOperationName)
+	if err != nil {
+		return err
+	}
+	diagnostics, err := blackboard.NewClient(conn).LoadSnapshot(ctx, op.GetName(), args[0])
+	if err != nil {
+		return err
+	}
+
+	prtr, err := printer.NewPrinterFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	printer.PrintMsgf(prtr, "Loaded snapshot %s into operation %s.", args[0], op.GetName())
+	if diagnostics != nil {
+		prtr.PrintErrln(extstatus.FromProto(diagnostics).String())
+	}
+	return nil
+})
+
+var blackboardSnapshotListCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "list",
+	Short: "List the blackboard snapshots.",
+	Long: `List the blackboard snapshots saved on the executive.
+
+$ inctl blackboard snapshot list --org my_org --solution my_solution_id`,
+	Args: cobra.NoArgs,
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	snapshots, err := blackboard.NewClient(conn).ListSnapshots(ctx)
+	if err != nil {
+		return err
+	}
+
+	prtr, err := printer.NewPrinterFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	for _, snapshot := range snapshots {
+		prtr.Println(newSnapshotInfo(snapshot))
+	}
+	return nil
+})
+
+var blackboardSnapshotRmCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "rm HANDLE",
+	Short: "Delete a blackboard snapshot.",
+	Long: `Delete a blackboard snapshot saved on the executive.
+
+$ inctl blackboard snapshot rm --org my_org --solution my_solution_id HANDLE`,
+	Args: cobra.ExactArgs(1),
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	if err := blackboard.NewClient(conn).DeleteSnapshot(ctx, args[0]); err != nil {
+		return err
+	}
+
+	prtr, err := printer.NewPrinterFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	printer.PrintMsgf(prtr, "Deleted snapshot %s.", args[0])
+	return nil
+})
+
+var blackboardSnapshotExportCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "export",
+	Short: "Export the blackboard of an operation to a local file.",
+	Long: `Export all values on the blackboard of an operation to a local file. The file can be imported into an operation on any cluster with 'inctl blackboard snapshot import'.
+
+The file contains an intrinsic_proto.executive.ListBlackboardValuesResponse proto.
+
+$ inctl blackboard snapshot export --org my_org --solution my_solution_id [--operation NAME] --output_file /tmp/blackboard.binpb [--snapshot_format textproto|binaryproto]`,
+	Args: cobra.NoArgs,
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	s, err := newBlackboardSession(ctx, conn)
+	if err != nil {
+		return err
+	}
+	export, err := s.client.Export(ctx, s.operation)
+	if err != nil {
+		return err
+	}
+
+	var content []byte
+	switch flagSnapshotFormat {
+	case TextProtoFormat:
+		content = []byte(prototext.MarshalOptions{Resolver: s.resolver, Multiline: true, Indent: "  "}.Format(export))
+	case BinaryProtoFormat:
+		if content, err = proto.Marshal(export); err != nil {
+			return errors.Wrap(err, "could not marshal blackboard values")
+		}
+	default:
+		return fmt.Errorf("unknown format %s", flagSnapshotFormat)
+	}
+	if err := os.WriteFile(flagOutputFile, content, 0o644); err != nil {
+		return errors.Wrapf(err, "could not write to file %s", flagOutputFile)
+	}
+
+	prtr, err := printer.NewPrinterFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	printer.PrintMsgf(prtr, "Exported %d blackboard values to %s.", len(export.GetValues()), flagOutputFile)
+	return nil
+})
+
+var blackboardSnapshotImportCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "import",
+	Short: "Import blackboard values from a local file.",
+	Long: `Import blackboard values exported with 'inctl blackboard snapshot export' into the blackboard of an operation. Values of the file overwrite existing values with the same key and scope.
+
+$ inctl blackboard snapshot import --org my_org --solution my_solution_id [--operation NAME] --input_file /tmp/blackboard.binpb [--snapshot_format textproto|binaryproto]`,
+	Args: cobra.NoArgs,
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	s, err := newBlackboardSession(ctx, conn)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(flagInputFile)
+	if err != nil {
+		return errors.Wrapf(err, "could not read input file")
+	}
+
+	export := &blackboardpb.ListBlackboardValuesResponse{}
+	switch flagSnapshotFormat {
+	case TextProtoFormat:
+		err = prototext.UnmarshalOptions{Resolver: s.resolver}.Unmarshal(content, export)
+	case BinaryProtoFormat:
+		err = proto.Unmarshal(content, export)
+	default:
+		return fmt.Errorf("unknown format %s", flagSnapshotFormat)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "could not parse input file")
+	}
+	if err := s.client.Import(ctx, s.operation, export); err != nil {
+		return err
+	}
+
+	prtr, err := printer.NewPrinterFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	printer.PrintMsgf(prtr, "Imported %d blackboard values into operation %s.", len(export.GetValues()), s.operation)
+	return nil
+})
+
+func init() {
+	for _, cmd := range []*cobra.Command{
+		blackboardSnapshotCreateCmd,
+		blackboardSnapshotLoadCmd,
+		blackboardSnapshotListCmd,
+		blackboardSnapshotRmCmd,
+		blackboardSnapshotExportCmd,
+		blackboardSnapshotImportCmd,
+	} {
+		blackboardSnapshotCmd.AddCommand(cmd)
+	}
+	for _, cmd := range []*cobra.Command{
+		blackboardSnapshotCreateCmd,
+		blackboardSnapshotLoadCmd,
+		blackboardSnapshotExportCmd,
+		blackboardSnapshotImportCmd,
+	} {
+		addOperationFlag(cmd)
+	}
+	blackboardSnapshotCreateCmd.Flags().StringVar(&flagSnapshotDisplayName, "display_name", "", "Display name of the snapshot.")
+
+	addSnapshotFormatFlag(blackboardSnapshotExportCmd)
+	blackboardSnapshotExportCmd.Flags().StringVar(&flagOutputFile, "output_file", "", "File to write the blackboard values to.")
+	blackboardSnapshotExportCmd.MarkFlagRequired("output_file")
+
+	addSnapshotFormatFlag(blackboardSnapshotImportCmd)
+	blackboardSnapshotImportCmd.Flags().StringVar(&flagInputFile, "input_file", "", "File to read the blackboard values from.")
+	blackboardSnapshotImportCmd.MarkFlagRequired("input_file")
+}
@@ -52,6 +52,10 @@ const (
 	TextProtoFormat = "textproto"
 	// BinaryProtoFormat is the binary proto output format.
 	BinaryProtoFormat = "binaryproto"
+	// DotFormat is the Graphviz DOT output format.
+	DotFormat = "dot"
+	// MermaidFormat is the Mermaid flowchart output format.
+	MermaidFormat = "mermaid"
 )
 
 var (
@@ -63,6 +67,7 @@ var (
 	flagClearTreeID   bool
 	flagClearNodeIDs  bool
 	flagProcessFormat string
+	flagSelect        string
 )
 
 type nodeIDCleaner struct{}
@@ -243,14 +248,18 @@ func MergedTypesForAllScriptNodesInTree(ctx context.Context, bt *behaviortreepb.
 	return types, nil
 }
 
+func addConnectionFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&flagSolutionName, "solution", "", "Id of the solution to interact with. For example, use 'inctl solutions list --org my_org --output json [--filter running_in_sim]' to see the list of solutions.")
+	cmd.Flags().StringVar(&flagClusterName, "cluster", "", "Name of the cluster to interact with.")
+	cmd.Flags().StringVar(&flagServerAddress, "server", "", "Server address of the cluster. Format is {ADDRESS}:{PORT}, for example 'localhost:17080'")
+}
+
 func addCommonGetSetFlags(cmd *cobra.Command) {
 	allowedFormats := []string{TextProtoFormat, BinaryProtoFormat}
 	cmd.Flags().StringVar(
 		&flagProcessFormat, "process_format", TextProtoFormat,
 		fmt.Sprintf("(optional) input/output format. One of: (%s)", strings.Join(allowedFormats, ", ")))
-	cmd.Flags().StringVar(&flagSolutionName, "solution", "", "Id of the solution to interact with. For example, use 'inctl solutions list --org my_org --output json [--filter running_in_sim]' to see the list of solutions.")
-	cmd.Flags().StringVar(&flagClusterName, "cluster", "", "Name of the cluster to interact with.")
-	cmd.Flags().StringVar(&flagServerAddress, "server", "", "Server address of the cluster. Format is {ADDRESS}:{PORT}, for example 'localhost:17080'")
+	addConnectionFlags(cmd)
 	cmd.Flags().BoolVar(&flagClearTreeID, "clear_tree_id", true, "Clear the tree_id field from the BT proto.")
 	cmd.Flags().BoolVar(&flagClearNodeIDs, "clear_node_ids", true, "Clear the nodes' id fields from the BT proto.")
 }
@@ -263,5 +272,20 @@ var processCmd = cobrautil.ParentOfNestedSubcommands(
 func init() {
 	processCmd.AddCommand(processGetCmd)
 	processCmd.AddCommand(processSetCmd)
+	processCmd.AddCommand(processStartCmd)
+	processCmd.AddCommand(processSuspendCmd)
+	processCmd.AddCommand(processResumeCmd)
+	processCmd.AddCommand(processCancelCmd)
+	processCmd.AddCommand(processResetCmd)
+	processCmd.AddCommand(processWaitCmd)
+	processCmd.AddCommand(processAbandonCmd)
+	processCmd.AddCommand(processWatchCmd)
+	processCmd.AddCommand(processBreakpointCmd)
+	processCmd.AddCommand(processNodeSettingsCmd)
+	processCmd.AddCommand(processLintCmd)
+	processCmd.AddCommand(processDiffCmd)
+	processCmd.AddCommand(processPatchCmd)
+	processCmd.AddCommand(processRegisterCmd)
+	processCmd.AddCommand(processUnregisterCmd)
 	root.RootCmd.AddCommand(processCmd)
 }
@@ -0,0 +1,272 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"intrinsic/executive/go/nodeidentifier"
+	behaviortreepb "intrinsic/executive/proto/behavior_tree_go_proto"
+	executiveservicepb "intrinsic/executive/proto/executive_service_go_proto"
+	"intrinsic/tools/inctl/util/cobrautil"
+	"intrinsic/tools/inctl/util/printer"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+var (
+	flagNodeTreeID     string
+	flagNodeID         uint32
+	flagNodeName       string
+	flagBreakpointType string
+)
+
+var breakpointTypes = map[string]behaviortreepb.BehaviorTree_Breakpoint_Type{
+	"before": behaviortreepb.BehaviorTree_Breakpoint_BEFORE,
+	"after":  behaviortreepb.BehaviorTree_Breakpoint_AFTER,
+}
+
+// addNodeFlags adds the flags to address a node of the operation's behavior
+// tree either by tree id and node id or by node name.
+func addNodeFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&flagNodeTreeID, "tree_id", "", "Id of the tree containing the node. Must be used together with --node_id.")
+	cmd.Flags().Uint32Var(&flagNodeID, "node_id", 0, "Id of the node. Must be used together with --tree_id.")
+	cmd.Flags().StringVar(&flagNodeName, "node_name", "", "Name of the node. Must be unique in the behavior tree of the operation.")
+	cmd.MarkFlagsRequiredTogether("tree_id", "node_id")
+	cmd.MarkFlagsMutuallyExclusive("tree_id", "node_name")
+	cmd.MarkFlagsOneRequired("tree_id", "node_name")
+}
+
+// resolveNodeFromFlags returns the tree id and node id of the node addressed
+// by the node flags. Node names are resolved using the behavior tree of the
+// given operation.
+func resolveNodeFromFlags(ctx context.Context, exC executiveservicepb.ExecutiveServiceClient, operationName string) (*behaviortreepb.BehaviorTree_NodeIdentifier, error) {
+	if flagNodeName == "" {
+		return &behaviortreepb.BehaviorTree_NodeIdentifier{
+			TreeId: flagNodeTreeID,
+			NodeId: flagNodeID,
+		}, nil
+	}
+
+	metadata, err := exC.GetOperationMetadata(ctx, &executiveservicepb.GetOperationMetadataRequest{Name: operationName})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get metadata of operation %q", operationName)
+	}
+	match, err := nodeidentifier.FindUniqueByName(ctx, metadata.GetBehaviorTree(), flagNodeName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not resolve node name")
+	}
+	// The executive addresses nodes in called behavior trees by their own tree
+	// id, so the nested identifier is not needed here.
+	return nodeidentifier.Innermost(match.Identifier), nil
+}
+
+// breakpointInfo is the output of the breakpoint commands.
+type breakpointInfo struct {
+	TreeID   string `json:"tree_id"`
+	NodeID   uint32 `json:"node_id"`
+	Type     string `json:"type"`
+	NodeName string `json:"node_name,omitempty"`
+}
+
+func (b *breakpointInfo) String() string {
+	s := fmt.Sprintf("%s:%d %s", b.TreeID, b.NodeID, strings.ToLower(b.Type))
+	if b.NodeName != "" {
+		s += fmt.Sprintf(" %q", b.NodeName)
+	}
+	return s
+}
+
+var processBreakpointCmd = cobrautil.ParentOfNestedSubcommands(
+	"breakpoint",
+	"Manage breakpoints of an executive operation",
+)
+
+var processBreakpointAddCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "add",
+	Short: "Add a breakpoint to a node.",
+	Long: `Add a breakpoint to a node of the behavior tree of an operation. The operation is suspended before or after the node is executed.
+
+$ inctl process breakpoint add --org my_org --solution my_solution_id [--operation NAME] --node_name "Move to pose" [--type before|after]
+$ inctl process breakpoint add --org my_org --solution my_solution_id [--operation NAME] --tree_id TREE_ID --node_id 42 [--type before|after]`,
+	Args: cobra.NoArgs,
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	exC := executiveservicepb.NewExecutiveServiceClient(conn)
+	op, err := resolveOperation(ctx, exC, flagOperationName)
+	if err != nil {
+		return err
+	}
+	bpType, err := parseEnumFlag("type", flagBreakpointType, breakpointTypes)
+	if err != nil {
+		return err
+	}
+	id, err := resolveNodeFromFlags(ctx, exC, op.GetName())
+	if err != nil {
+		return err
+	}
+
+	bp, err := exC.CreateBreakpoint(ctx, &executiveservicepb.CreateBreakpointRequest{
+		Name: op.GetName(),
+		Breakpoint: &behaviortreepb.BehaviorTree_Breakpoint{
+			TreeId: id.GetTreeId(),
+			NodeId: id.GetNodeId(),
+			Type:   bpType,
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to create breakpoint")
+	}
+
+	prtr, err := printer.NewPrinterFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	prtr.Println(&breakpointInfo{
+		TreeID:   bp.GetTreeId(),
+		NodeID:   bp.GetNodeId(),
+		Type:     bp.GetType().String(),
+		NodeName: flagNodeName,
+	})
+	return nil
+})
+
+var processBreakpointRmCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "rm",
+	Short: "Remove a breakpoint from a node.",
+	Long: `Remove a breakpoint from a node of the behavior tree of an operation.
+
+$ inctl process breakpoint rm --org my_org --solution my_solution_id [--operation NAME] --node_name "Move to pose" [--type before|after]`,
+	Args: cobra.NoArgs,
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	exC := executiveservicepb.NewExecutiveServiceClient(conn)
+	op, err := resolveOperation(ctx, exC, flagOperationName)
+	if err != nil {
+		return err
+	}
+	bpType, err := parseEnumFlag("type", flagBreakpointType, breakpointTypes)
+	if err != nil {
+		return err
+	}
+	id, err := resolveNodeFromFlags(ctx, exC, op.GetName())
+	if err != nil {
+		return err
+	}
+
+	if _, err := exC.DeleteBreakpoint(ctx, &executiveservicepb.DeleteBreakpointRequest{
+		Name: op.GetName(),
+		Breakpoint: &behaviortreepb.BehaviorTree_Breakpoint{
+			TreeId: id.GetTreeId(),
+			NodeId: id.GetNodeId(),
+			Type:   bpType,
+		},
+	}); err != nil {
+		return errors.Wrapf(err, "unable to delete breakpoint")
+	}
+
+	prtr, err := printer.NewPrinterFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	printer.PrintMsgf(prtr, "Removed breakpoint %s:%d.", id.GetTreeId(), id.GetNodeId())
+	return nil
+})
+
+var processBreakpointListCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "list",
+	Short: "List the breakpoints of an operation.",
+	Long: `List the breakpoints of the behavior tree of an operation.
+
+$ inctl process breakpoint list --org my_org --solution my_solution_id [--operation NAME]`,
+	Args: cobra.NoArgs,
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	exC := executiveservicepb.NewExecutiveServiceClient(conn)
+	op, err := resolveOperation(ctx, exC, flagOperationName)
+	if err != nil {
+		return err
+	}
+
+	resp, err := exC.ListBreakpoints(ctx, &executiveservicepb.ListBreakpointsRequest{Name: op.GetName()})
+	if err != nil {
+		return errors.Wrapf(err, "unable to list breakpoints")
+	}
+	metadata, err := operationMetadata(op)
+	if err != nil {
+		return err
+	}
+
+	prtr, err := printer.NewPrinterFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	for _, bp := range resp.GetBreakpoints() {
+		info := &breakpointInfo{
+			TreeID: bp.GetTreeId(),
+			NodeID: bp.GetNodeId(),
+			Type:   bp.GetType().String(),
+		}
+		// The name is for information only, so a missing node is not an error.
+		if match, err := nodeidentifier.FindByID(ctx, metadata.GetBehaviorTree(), bp.GetTreeId(), bp.GetNodeId()); err == nil {
+			info.NodeName = match.Element.Node().GetName()
+		}
+		prtr.Println(info)
+	}
+	return nil
+})
+
+var processBreakpointClearCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "clear",
+	Short: "Remove all breakpoints of an operation.",
+	Long: `Remove all breakpoints of the behavior tree of an operation.
+
+$ inctl process breakpoint clear --org my_org --solution my_solution_id [--operation NAME]`,
+	Args: cobra.NoArgs,
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	exC := executiveservicepb.NewExecutiveServiceClient(conn)
+	op, err := resolveOperation(ctx, exC, flagOperationName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := exC.DeleteAllBreakpoints(ctx, &executiveservicepb.DeleteAllBreakpointsRequest{Name: op.GetName()}); err != nil {
+		return errors.Wrapf(err, "unable to delete breakpoints")
+	}
+
+	prtr, err := printer.NewPrinterFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	printer.PrintMsgf(prtr, "Removed all breakpoints of operation %s.", op.GetName())
+	return nil
+})
+
+func init() {
+	for _, cmd := range []*cobra.Command{
+		processBreakpointAddCmd,
+		processBreakpointRmCmd,
+		processBreakpointListCmd,
+		processBreakpointClearCmd,
+	} {
+		addOperationFlag(cmd)
+		processBreakpointCmd.AddCommand(cmd)
+	}
+	for _, cmd := range []*cobra.Command{processBreakpointAddCmd, processBreakpointRmCmd} {
+		addNodeFlags(cmd)
+		cmd.Flags().StringVar(&flagBreakpointType, "type", "before", "Type of the breakpoint. One of: (before, after)")
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"intrinsic/assets/platformlevelswitch"
+	"intrinsic/executive/go/behaviortreediff"
+	btpb "intrinsic/executive/proto/behavior_tree_go_proto"
+	executiveservicepb "intrinsic/executive/proto/executive_service_go_proto"
+	protoregistrygrpcpb "intrinsic/proto_tools/proto/proto_registry_go_proto"
+	"intrinsic/proto_tools/registry/protoregistryclient"
+	"intrinsic/tools/inctl/util/orgutil"
+	"intrinsic/tools/inctl/util/printer"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+var (
+	viperProcessDiff       = viper.New()
+	flagDiffAgainstCluster bool
+)
+
+// resolverChain resolves types using the first of its resolvers that knows
+// the type.
+type resolverChain []protoregistryclient.Resolver
+
+func (c resolverChain) FindMessageByName(message protoreflect.FullName) (protoreflect.MessageType, error) {
+	for _, r := range c {
+		if mt, err := r.FindMessageByName(message); err == nil {
+			return mt, nil
+		}
+	}
+	return nil, protoregistry.NotFound
+}
+
+func (c resolverChain) FindMessageByURL(url string) (protoreflect.MessageType, error) {
+	for _, r := range c {
+		if mt, err := r.FindMessageByURL(url); err == nil {
+			return mt, nil
+		}
+	}
+	return nil, protoregistry.NotFound
+}
+
+func (c resolverChain) FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	for _, r := range c {
+		if et, err := r.FindExtensionByName(field); err == nil {
+			return et, nil
+		}
+	}
+	return nil, protoregistry.NotFound
+}
+
+func (c resolverChain) FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error) {
+	for _, r := range c {
+		if et, err := r.FindExtensionByNumber(message, field); err == nil {
+			return et, nil
+		}
+	}
+	return nil, protoregistry.NotFound
+}
+
+// deserializeBTWithoutRegistry parses a behavior tree without access to the
+// proto registry of a solution. Expanded Any protos can only be parsed if
+// their type is described by a file descriptor set in the tree or compiled
+// into inctl.
+func deserializeBTWithoutRegistry(ctx context.Context, format string, content []byte) (*btpb.BehaviorTree, error) {
+	// Pass 1: Unmarshal with a dummy resolver to collect the file descriptor
+	// sets in the tree (see deserializeFromText).
+	btWithEmptyAnys, err := deserializeBTForLint(format, content)
+	if err != nil || format != TextProtoFormat {
+		return btWithEmptyAnys, err
+	}
+
+	nodeTypes, err := MergedTypesForAllScriptNodesInTree(ctx, btWithEmptyAnys)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating merged Types from behavior tree script nodes")
+	}
+
+	// Pass 2: Unmarshal while resolving type URLs using the descriptors
+	// collected from the tree and the compiled-in types.
+	unmarshaller := prototext.UnmarshalOptions{
+		Resolver:       resolverChain{nodeTypes, protoregistry.GlobalTypes},
+		AllowPartial:   true,
+		DiscardUnknown: true,
+	}
+	bt := &btpb.BehaviorTree{}
+	if err := unmarshaller.Unmarshal(content, bt); err != nil {
+		return nil, errors.Wrap(err, "could not parse input file (use --solution, --cluster or --server to resolve types from the proto registry of a solution)")
+	}
+	return bt, nil
+}
+
+// readBTForDiff reads and parses the behavior tree in the given file. The
+// proto registry client is used to resolve types if not nil.
+func readBTForDiff(ctx context.Context, path string, protoRegistry protoregistrygrpcpb.ProtoRegistryClient) (*btpb.BehaviorTree, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read file %s", path)
+	}
+	var bt *btpb.BehaviorTree
+	if protoRegistry != nil {
+		bt, err = deserializeBT(ctx, flagProcessFormat, content, protoRegistry)
+	} else {
+		bt, err = deserializeBTWithoutRegistry(ctx, flagProcessFormat, content)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not deserialize %s", path)
+	}
+	return bt, nil
+}
+
+// diffResult is the output of the diff command for JSON output.
+type diffResult struct {
+	Changes []behaviortreediff.NodeChange `json:"changes"`
+}
+
+func (r *diffResult) String() string {
+	lines := make([]string, len(r.Changes))
+	for i, c := range r.Changes {
+		lines[i] = c.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+var processDiffCmd = platformlevelswitch.WrapCmdOptional(&cobra.Command{
+	Use:   "diff OLD_FILE [NEW_FILE]",
+	Short: "Show the structural differences between two processes.",
+	Long: `Show the structural differences between two processes (behavior trees).
+
+Nodes are matched by their id, falling back to their name and position. The output lists added (+), removed (-) and moved (>) nodes as well as modified nodes (~) with their changed fields. Parameters of skills and script nodes are compared field by field.
+
+Two positional arguments: Compare two local files.
+$ inctl process diff [--process_format textproto|binaryproto] [--output json] /tmp/old.textproto /tmp/new.textproto
+
+With --against-cluster: Compare the process currently loaded in the executive (old) with a local file (new).
+$ inctl process diff --org my_org --solution my_solution_id --against-cluster /tmp/new.textproto
+
+If --solution, --cluster or --server is given, types of parameters in text protos are resolved using the proto registry of the solution. Otherwise only types described in the files themselves can be resolved.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if flagDiffAgainstCluster {
+			return cobra.ExactArgs(1)(cmd, args)
+		}
+		return cobra.ExactArgs(2)(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		if flagDiffAgainstCluster && !isConnectionRequested() {
+			return fmt.Errorf("--against-cluster requires one of --solution, --cluster or --server")
+		}
+		if !isConnectionRequested() {
+			a, err := readBTForDiff(ctx, args[0], nil)
+			if err != nil {
+				return err
+			}
+			b, err := readBTForDiff(ctx, args[1], nil)
+			if err != nil {
+				return err
+			}
+			return diffProcesses(ctx, cmd, a, b)
+		}
+
+		projectName := viperProcessDiff.GetString(orgutil.KeyProject)
+		orgName := viperProcessDiff.GetString(orgutil.KeyOrganization)
+		ctx, conn, err := connectToCluster(ctx, projectName,
+			orgName, flagServerAddress,
+			flagSolutionName, flagClusterName)
+		if err != nil {
+			return errors.Wrapf(err, "could not dial connection")
+		}
+		defer conn.Close()
+
+		protoRegistry := protoregistrygrpcpb.NewProtoRegistryClient(conn)
+		var a, b *btpb.BehaviorTree
+		if flagDiffAgainstCluster {
+			if a, err = getActiveBT(ctx, executiveservicepb.NewExecutiveServiceClient(conn)); err != nil {
+				return errors.Wrap(err, "could not get active behavior tree")
+			}
+			if b, err = readBTForDiff(ctx, args[0], protoRegistry); err != nil {
+				return err
+			}
+		} else {
+			if a, err = readBTForDiff(ctx, args[0], protoRegistry); err != nil {
+				return err
+			}
+			if b, err = readBTForDiff(ctx, args[1], protoRegistry); err != nil {
+				return err
+			}
+		}
+		resolver := protoregistryclient.NewProtoRegistryResolver(ctx, protoRegistry, []protoregistryclient.Resolver{})
+		return diffProcesses(ctx, cmd, a, b, behaviortreediff.WithResolver(resolver))
+	},
+}, viperProcessDiff)
+
+func diffProcesses(ctx context.Context, cmd *cobra.Command, a, b *btpb.BehaviorTree, opts ...behaviortreediff.Option) error {
+	changes, err := behaviortreediff.Diff(ctx, a, b, opts...)
+	if err != nil {
+		return errors.Wrap(err, "could not compare processes")
+	}
+
+	prtr, err := printer.NewPrinterFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	switch printer.GetFlagOutputType(cmd) {
+	case printer.OutputTypeJSON:
+		result := &diffResult{Changes: changes}
+		if result.Changes == nil {
+			result.Changes = []behaviortreediff.NodeChange{}
+		}
+		prtr.Println(result)
+	default:
+		for _, c := range changes {
+			prtr.Println(c)
+		}
+	}
+	return nil
+}
+
+func init() {
+	allowedFormats := []string{TextProtoFormat, BinaryProtoFormat}
+	processDiffCmd.Flags().StringVar(
+		&flagProcessFormat, "process_format", TextProtoFormat,
+		fmt.Sprintf("(optional) input format of the files. One of: (%s)", strings.Join(allowedFormats, ", ")))
+	processDiffCmd.Flags().BoolVar(&flagDiffAgainstCluster, "against-cluster", false, "Compare the process currently loaded in the executive with the given file.")
+	addConnectionFlags(processDiffCmd)
+}
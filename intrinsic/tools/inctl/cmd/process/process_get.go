@@ -18,12 +18,16 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"intrinsic/assets/idutils"
 	"intrinsic/assets/platformlevelswitch"
 	"intrinsic/assets/processes/processbundle"
 	installedassetspb "intrinsic/assets/proto/installed_assets_go_proto"
 	viewpb "intrinsic/assets/proto/view_go_proto"
+	"intrinsic/executive/go/behaviortreegraph"
+	"intrinsic/executive/go/behaviortreelint"
+	"intrinsic/executive/go/behaviortreequery"
 	behaviortreepb "intrinsic/executive/proto/behavior_tree_go_proto"
 	executiveservicepb "intrinsic/executive/proto/executive_service_go_proto"
 	solutionservicepb "intrinsic/frontend/solution_service/proto/solution_service_go_proto"
@@ -52,21 +56,29 @@ type messageWithBT struct {
 	behaviorTree *behaviortreepb.BehaviorTree
 }
 
-func serializeToTextProto(ctx context.Context, msg messageWithBT, protoRegistry protoregistrygrpcpb.ProtoRegistryClient) ([]byte, error) {
-	nodeTypes, err := MergedTypesForAllScriptNodesInTree(ctx, msg.behaviorTree)
+// treeResolver returns a resolver for the types used in the given tree.
+// Intrinsic type URLs are resolved using the proto registry and other type URLs
+// using the descriptors collected from the behavior tree (with the compiled-in
+// types as a last fallback).
+func treeResolver(ctx context.Context, bt *behaviortreepb.BehaviorTree, protoRegistry protoregistrygrpcpb.ProtoRegistryClient) (protoregistryclient.Resolver, error) {
+	nodeTypes, err := MergedTypesForAllScriptNodesInTree(ctx, bt)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed creating merged Types from behavior tree script nodes")
 	}
+	return protoregistryclient.NewProtoRegistryResolver(
+		ctx,
+		protoRegistry,
+		[]protoregistryclient.Resolver{nodeTypes, protoregistry.GlobalTypes},
+	), nil
+}
 
-	// Marshal while resolving Intrinsic type URLs using the proto registry and
-	// other type URLs using the descriptors collected from the behavior tree
-	// (with the compiled-in types as a last fallback).
+func serializeToTextProto(ctx context.Context, msg messageWithBT, protoRegistry protoregistrygrpcpb.ProtoRegistryClient) ([]byte, error) {
+	resolver, err := treeResolver(ctx, msg.behaviorTree, protoRegistry)
+	if err != nil {
+		return nil, err
+	}
 	marshaller := prototext.MarshalOptions{
-		Resolver: protoregistryclient.NewProtoRegistryResolver(
-			ctx,
-			protoRegistry,
-			[]protoregistryclient.Resolver{nodeTypes, protoregistry.GlobalTypes},
-		),
+		Resolver:  resolver,
 		Indent:    "  ",
 		Multiline: true,
 	}
@@ -74,6 +86,43 @@ func serializeToTextProto(ctx context.Context, msg messageWithBT, protoRegistry
 	return []byte(s), nil
 }
 
+// serializeSelection serializes the elements of the tree that match the given
+// query as text protos. Each element is preceded by a comment with its path in
+// the tree.
+func serializeSelection(ctx context.Context, msg messageWithBT, protoRegistry protoregistrygrpcpb.ProtoRegistryClient, expr string) ([]byte, error) {
+	query, err := behaviortreequery.Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	resolver, err := treeResolver(ctx, msg.behaviorTree, protoRegistry)
+	if err != nil {
+		return nil, err
+	}
+	elements, err := query.Select(ctx, msg.behaviorTree, behaviortreequery.WithResolver(resolver))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not evaluate %q", expr)
+	}
+	marshaller := prototext.MarshalOptions{
+		Resolver:  resolver,
+		Indent:    "  ",
+		Multiline: true,
+	}
+	var b strings.Builder
+	for _, element := range elements {
+		var m proto.Message
+		switch {
+		case element.Tree() != nil:
+			m = element.Tree()
+		case element.Node() != nil:
+			m = element.Node()
+		default:
+			m = element.Condition()
+		}
+		fmt.Fprintf(&b, "# %s\n%s\n", behaviortreelint.Path(element), marshaller.Format(m))
+	}
+	return []byte(b.String()), nil
+}
+
 func serializeToBinaryProto(msg messageWithBT) ([]byte, error) {
 	marshaller := proto.MarshalOptions{}
 	content, err := marshaller.Marshal(msg.message)
@@ -83,6 +132,17 @@ func serializeToBinaryProto(msg messageWithBT) ([]byte, error) {
 	return content, nil
 }
 
+func serializeToGraph(ctx context.Context, msg messageWithBT, format string) ([]byte, error) {
+	g, err := behaviortreegraph.New(ctx, msg.behaviorTree)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create graph")
+	}
+	if format == DotFormat {
+		return []byte(g.DOT()), nil
+	}
+	return []byte(g.Mermaid()), nil
+}
+
 func serializeMessage(ctx context.Context, protoRegistry protoregistrygrpcpb.ProtoRegistryClient, msg messageWithBT, format string) ([]byte, error) {
 	var data []byte
 	var err error
@@ -97,6 +157,11 @@ func serializeMessage(ctx context.Context, protoRegistry protoregistrygrpcpb.Pro
 		if err != nil {
 			return nil, errors.Wrapf(err, "could not serialize BT to binary")
 		}
+	case DotFormat, MermaidFormat:
+		data, err = serializeToGraph(ctx, msg, format)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not render BT as graph")
+		}
 	default:
 		return nil, fmt.Errorf("unknown format %s", format)
 	}
@@ -113,6 +178,9 @@ type getProcessParams struct {
 	format          string
 	clearTreeID     bool
 	clearNodeIDs    bool
+	// If set, only the elements of the behavior tree that match this query are
+	// returned.
+	selectExpr string
 }
 
 func downloadProcess(ctx context.Context, params *getProcessParams) (messageWithBT, error) {
@@ -192,6 +260,13 @@ func getProcess(ctx context.Context, params *getProcessParams) ([]byte, error) {
 
 	clearTree(outputMsg.behaviorTree, params.clearTreeID, params.clearNodeIDs)
 
+	if params.selectExpr != "" {
+		if params.format != TextProtoFormat {
+			return nil, fmt.Errorf("--select is only supported with --process_format=%s", TextProtoFormat)
+		}
+		return serializeSelection(ctx, outputMsg, params.protoRegistry, params.selectExpr)
+	}
+
 	return serializeMessage(ctx, params.protoRegistry, outputMsg, params.format)
 }
 
@@ -207,6 +282,12 @@ $ inctl process get --org my_org --solution my_solution_id [--output_file /tmp/p
 No positional argument: Get the "active" process which is currently loaded in the executive. The output is an intrinsic_proto.executive.BehaviorTree proto.
 $ inctl process get --org my_org --solution my_solution_id [--output_file /tmp/process.txtpb] [--process_format textproto|binaryproto]
 
+With --process_format dot or mermaid, the behavior tree is rendered as a graph in the Graphviz DOT language or as a Mermaid flowchart instead.
+$ inctl process get --org my_org --solution my_solution_id --process_format dot --output_file /tmp/process.dot
+
+With --select, only the elements of the behavior tree that match the given query are printed, each preceded by its path in the tree. Queries select nodes by type and field values, for example "//task[skill_id=\"ai.intrinsic.move_robot\"]" or "sequence[name=\"pick\"]/*".
+$ inctl process get --org my_org --solution my_solution_id --select '//task[skill_id="ai.intrinsic.move_robot"]'
+
 [legacy process support]
 One positional argument: Get the legacy process with the given name as stored in the solution. The output is an intrinsic_proto.executive.BehaviorTree proto.
 $ inctl process get --org my_org --solution my_solution_id [--output_file /tmp/process.txtpb] [--process_format textproto|binaryproto] "My Process"`,
@@ -235,6 +316,7 @@ $ inctl process get --org my_org --solution my_solution_id [--output_file /tmp/p
 				format:          flagProcessFormat,
 				clearTreeID:     flagClearTreeID,
 				clearNodeIDs:    flagClearNodeIDs,
+				selectExpr:      flagSelect,
 			})
 			if err != nil {
 				return errors.Wrapf(err, "could not get process")
@@ -257,5 +339,8 @@ $ inctl process get --org my_org --solution my_solution_id [--output_file /tmp/p
 
 func init() {
 	addCommonGetSetFlags(processGetCmd)
+	allowedFormats := []string{TextProtoFormat, BinaryProtoFormat, DotFormat, MermaidFormat}
+	processGetCmd.Flags().Lookup("process_format").Usage = fmt.Sprintf("(optional) output format. One of: (%s)", strings.Join(allowedFormats, ", "))
 	processGetCmd.Flags().StringVar(&flagOutputFile, "output_file", "", "If set, writes the process to the given file instead of stdout.")
+	processGetCmd.Flags().StringVar(&flagSelect, "select", "", "If set, only prints the elements of the behavior tree that match the given query (e.g., '//task[skill_id=\"ai.intrinsic.move_robot\"]').")
 }
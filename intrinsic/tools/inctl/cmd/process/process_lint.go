@@ -0,0 +1,223 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"intrinsic/assets/idutils"
+	"intrinsic/assets/platformlevelswitch"
+	atypepb "intrinsic/assets/proto/asset_type_go_proto"
+	installedassetspb "intrinsic/assets/proto/installed_assets_go_proto"
+	viewpb "intrinsic/assets/proto/view_go_proto"
+	"intrinsic/executive/go/behaviortreelint"
+	btpb "intrinsic/executive/proto/behavior_tree_go_proto"
+	protoregistrygrpcpb "intrinsic/proto_tools/proto/proto_registry_go_proto"
+	"intrinsic/proto_tools/registry/protoregistryclient"
+	"intrinsic/tools/inctl/cmd/root"
+	"intrinsic/tools/inctl/util/orgutil"
+	"intrinsic/tools/inctl/util/printer"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const exitCodeLintErrors = 2
+
+var (
+	viperProcessLint        = viper.New()
+	flagKnownBlackboardKeys []string
+)
+
+// deserializeBTForLint parses a behavior tree without resolving the type URLs
+// of Any protos. Expanded Any protos are parsed to empty messages but keep
+// their type URL, so the linter can report unresolvable types instead of
+// failing to parse the tree.
+func deserializeBTForLint(format string, content []byte) (*btpb.BehaviorTree, error) {
+	bt := &btpb.BehaviorTree{}
+	switch format {
+	case TextProtoFormat:
+		unmarshaller := prototext.UnmarshalOptions{
+			Resolver:       newResolverToEmpty(),
+			AllowPartial:   true,
+			DiscardUnknown: true,
+		}
+		if err := unmarshaller.Unmarshal(content, bt); err != nil {
+			return nil, errors.Wrapf(err, "could not parse input file")
+		}
+	case BinaryProtoFormat:
+		if err := proto.Unmarshal(content, bt); err != nil {
+			return nil, errors.Wrapf(err, "could not parse input file")
+		}
+	default:
+		return nil, fmt.Errorf("unknown format %s", format)
+	}
+	return bt, nil
+}
+
+// installedAssetsChecker returns a function that checks whether a skill or
+// process with the given id is installed. The installed assets are listed
+// once on first use.
+func installedAssetsChecker(client installedassetspb.InstalledAssetsClient) behaviortreelint.IsInstalledFunc {
+	var installed map[string]bool
+	return func(ctx context.Context, id string) (bool, error) {
+		if installed == nil {
+			ids := map[string]bool{}
+			req := &installedassetspb.ListInstalledAssetsRequest{
+				View: viewpb.AssetViewType_ASSET_VIEW_TYPE_BASIC,
+				StrictFilter: &installedassetspb.ListInstalledAssetsRequest_Filter{
+					AssetTypes: []atypepb.AssetType{atypepb.AssetType_ASSET_TYPE_SKILL, atypepb.AssetType_ASSET_TYPE_PROCESS},
+				},
+			}
+			for {
+				resp, err := client.ListInstalledAssets(ctx, req)
+				if err != nil {
+					return false, errors.Wrap(err, "could not list installed assets")
+				}
+				for _, asset := range resp.GetInstalledAssets() {
+					ids[idutils.IDFromProtoUnchecked(asset.GetMetadata().GetIdVersion().GetId())] = true
+				}
+				if resp.GetNextPageToken() == "" {
+					break
+				}
+				req.PageToken = resp.GetNextPageToken()
+			}
+			installed = ids
+		}
+		return installed[id], nil
+	}
+}
+
+// lintResult is the output of the lint command for JSON output.
+type lintResult struct {
+	Findings []behaviortreelint.Finding `json:"findings"`
+}
+
+func (r *lintResult) String() string {
+	lines := make([]string, len(r.Findings))
+	for i, f := range r.Findings {
+		lines[i] = f.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isConnectionRequested() bool {
+	return flagSolutionName != "" || flagClusterName != "" || flagServerAddress != ""
+}
+
+var processLintCmd = platformlevelswitch.WrapCmdOptional(&cobra.Command{
+	Use:   "lint",
+	Short: "Check a process for common mistakes.",
+	Long: `Check a behavior tree for common mistakes without loading it into the executive.
+
+Without connection flags only the tree itself is checked. If --solution, --cluster or --server is given, the tree is additionally checked for skills that are not installed and for parameters whose type cannot be resolved.
+
+` + fmt.Sprintf("The exit code is %d if any error (as opposed to a warning) was found.", exitCodeLintErrors) + `
+
+$ inctl process lint --input_file /tmp/tree.textproto [--process_format textproto|binaryproto] [--output json]
+$ inctl process lint --org my_org --solution my_solution_id --input_file /tmp/tree.textproto`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		content, err := os.ReadFile(flagInputFile)
+		if err != nil {
+			return errors.Wrapf(err, "could not read input file")
+		}
+		bt, err := deserializeBTForLint(flagProcessFormat, content)
+		if err != nil {
+			return err
+		}
+
+		rules := behaviortreelint.DefaultRules(flagKnownBlackboardKeys...)
+		if isConnectionRequested() {
+			projectName := viperProcessLint.GetString(orgutil.KeyProject)
+			orgName := viperProcessLint.GetString(orgutil.KeyOrganization)
+			var conn *grpc.ClientConn
+			ctx, conn, err = connectToCluster(ctx, projectName,
+				orgName, flagServerAddress,
+				flagSolutionName, flagClusterName)
+			if err != nil {
+				return errors.Wrapf(err, "could not dial connection")
+			}
+			defer conn.Close()
+
+			nodeTypes, err := MergedTypesForAllScriptNodesInTree(ctx, bt)
+			if err != nil {
+				return errors.Wrap(err, "failed creating merged Types from behavior tree script nodes")
+			}
+			resolver := protoregistryclient.NewProtoRegistryResolver(
+				ctx,
+				protoregistrygrpcpb.NewProtoRegistryClient(conn),
+				[]protoregistryclient.Resolver{nodeTypes, protoregistry.GlobalTypes},
+			)
+			rules = append(rules,
+				behaviortreelint.SkillNotInstalledRule(installedAssetsChecker(installedassetspb.NewInstalledAssetsClient(conn))),
+				behaviortreelint.UnresolvableTypeURLRule(resolver),
+			)
+		}
+		return lintProcess(ctx, cmd, bt, rules)
+	},
+}, viperProcessLint)
+
+func lintProcess(ctx context.Context, cmd *cobra.Command, bt *btpb.BehaviorTree, rules []behaviortreelint.Rule) error {
+	findings, err := behaviortreelint.New(rules...).Lint(ctx, bt)
+	if err != nil {
+		return errors.Wrap(err, "could not lint process")
+	}
+
+	prtr, err := printer.NewPrinterFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	switch printer.GetFlagOutputType(cmd) {
+	case printer.OutputTypeJSON:
+		result := &lintResult{Findings: findings}
+		if result.Findings == nil {
+			result.Findings = []behaviortreelint.Finding{}
+		}
+		prtr.Println(result)
+	default:
+		for _, f := range findings {
+			prtr.Println(f)
+		}
+	}
+
+	if behaviortreelint.HasErrors(findings) {
+		return &root.ExitError{
+			Code: exitCodeLintErrors,
+			Err:  fmt.Errorf("process has %d findings, including errors", len(findings)),
+		}
+	}
+	return nil
+}
+
+func init() {
+	processLintCmd.Flags().StringVar(&flagInputFile, "input_file", "", "File to read the process from.")
+	processLintCmd.MarkFlagRequired("input_file")
+	allowedFormats := []string{TextProtoFormat, BinaryProtoFormat}
+	processLintCmd.Flags().StringVar(
+		&flagProcessFormat, "process_format", TextProtoFormat,
+		fmt.Sprintf("(optional) input format. One of: (%s)", strings.Join(allowedFormats, ", ")))
+	processLintCmd.Flags().StringSliceVar(&flagKnownBlackboardKeys, "known_blackboard_keys", nil, "Blackboard keys that are provided outside of the tree (e.g., by a loaded snapshot) and should not be reported as unknown.")
+	addConnectionFlags(processLintCmd)
+}
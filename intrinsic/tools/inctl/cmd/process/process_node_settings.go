@@ -0,0 +1,106 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"context"
+	"fmt"
+
+	behaviortreepb "intrinsic/executive/proto/behavior_tree_go_proto"
+	executiveservicepb "intrinsic/executive/proto/executive_service_go_proto"
+	"intrinsic/tools/inctl/util/printer"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+var (
+	flagNodeMode           string
+	flagNodeDisabledResult string
+)
+
+var nodeModes = map[string]behaviortreepb.BehaviorTree_Node_ExecutionSettings_Mode{
+	"normal":   behaviortreepb.BehaviorTree_Node_ExecutionSettings_NORMAL,
+	"disabled": behaviortreepb.BehaviorTree_Node_ExecutionSettings_DISABLED,
+}
+
+var nodeDisabledResults = map[string]behaviortreepb.BehaviorTree_Node_ExecutionSettings_DisabledResultState{
+	"succeeded": behaviortreepb.BehaviorTree_Node_ExecutionSettings_SUCCEEDED,
+	"failed":    behaviortreepb.BehaviorTree_Node_ExecutionSettings_FAILED,
+}
+
+var processNodeSettingsCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "node-settings",
+	Short: "Set the execution settings of a node.",
+	Long: `Set the execution settings of a node of the behavior tree of an operation.
+
+A disabled node is not executed. Instead, it immediately transitions to the state given by --disabled_result.
+
+$ inctl process node-settings --org my_org --solution my_solution_id [--operation NAME] --node_name "Move to pose" --mode disabled [--disabled_result succeeded|failed]
+$ inctl process node-settings --org my_org --solution my_solution_id [--operation NAME] --tree_id TREE_ID --node_id 42 --mode normal`,
+	Args: cobra.NoArgs,
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	exC := executiveservicepb.NewExecutiveServiceClient(conn)
+	mode, err := parseEnumFlag("mode", flagNodeMode, nodeModes)
+	if err != nil {
+		return err
+	}
+	settings := &behaviortreepb.BehaviorTree_Node_ExecutionSettings{Mode: mode}
+	if mode != behaviortreepb.BehaviorTree_Node_ExecutionSettings_DISABLED {
+		if cmd.Flags().Changed("disabled_result") {
+			return fmt.Errorf("--disabled_result can only be used with --mode=disabled")
+		}
+	} else {
+		result, err := parseEnumFlag("disabled_result", flagNodeDisabledResult, nodeDisabledResults)
+		if err != nil {
+			return err
+		}
+		settings.DisabledResultState = &result
+	}
+
+	op, err := resolveOperation(ctx, exC, flagOperationName)
+	if err != nil {
+		return err
+	}
+	id, err := resolveNodeFromFlags(ctx, exC, op.GetName())
+	if err != nil {
+		return err
+	}
+
+	if _, err := exC.SetNodeExecutionSettings(ctx, &executiveservicepb.SetNodeExecutionSettingsRequest{
+		Name:              op.GetName(),
+		TreeId:            id.GetTreeId(),
+		NodeId:            id.GetNodeId(),
+		ExecutionSettings: settings,
+	}); err != nil {
+		return errors.Wrapf(err, "unable to set execution settings of node %s:%d", id.GetTreeId(), id.GetNodeId())
+	}
+
+	prtr, err := printer.NewPrinterFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	printer.PrintMsgf(prtr, "Set node %s:%d to %s.", id.GetTreeId(), id.GetNodeId(), flagNodeMode)
+	return nil
+})
+
+func init() {
+	addOperationFlag(processNodeSettingsCmd)
+	addNodeFlags(processNodeSettingsCmd)
+	processNodeSettingsCmd.Flags().StringVar(&flagNodeMode, "mode", "", "Execution mode of the node. One of: (normal, disabled)")
+	processNodeSettingsCmd.Flags().StringVar(&flagNodeDisabledResult, "disabled_result", "succeeded", "State a disabled node results in. One of: (succeeded, failed)")
+	processNodeSettingsCmd.MarkFlagRequired("mode")
+}
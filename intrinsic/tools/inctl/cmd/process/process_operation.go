@@ -0,0 +1,513 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"intrinsic/assets/platformlevelswitch"
+	executionmodepb "intrinsic/executive/proto/executive_execution_mode_go_proto"
+	executiveservicepb "intrinsic/executive/proto/executive_service_go_proto"
+	runmetadatapb "intrinsic/executive/proto/run_metadata_go_proto"
+	"intrinsic/tools/inctl/cmd/root"
+	"intrinsic/tools/inctl/util/orgutil"
+	"intrinsic/tools/inctl/util/printer"
+
+	longrunningpb "cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Exit codes of commands which wait for an operation to finish. Any other
+// error (e.g., an unreachable cluster) results in exit code 1.
+const (
+	exitCodeOperationFailed    = 2
+	exitCodeOperationCanceled  = 3
+	exitCodeOperationSuspended = 4
+)
+
+// waitPollInterval is the minimum time between two WaitOperation calls. The
+// executive may return from WaitOperation early, so this avoids busy looping.
+const waitPollInterval = 500 * time.Millisecond
+
+var (
+	flagOperationName  string
+	flagExecutionMode  string
+	flagSimulationMode string
+	flagStartTreeID    string
+	flagStartNodeID    uint32
+	flagResumeMode     string
+	flagKeepBlackboard bool
+	flagWait           bool
+	flagWaitTimeout    time.Duration
+)
+
+var executionModes = map[string]executionmodepb.ExecutionMode{
+	"normal":    executionmodepb.ExecutionMode_EXECUTION_MODE_NORMAL,
+	"step_wise": executionmodepb.ExecutionMode_EXECUTION_MODE_STEP_WISE,
+}
+
+var simulationModes = map[string]executionmodepb.SimulationMode{
+	"reality":      executionmodepb.SimulationMode_SIMULATION_MODE_REALITY,
+	"preview":      executionmodepb.SimulationMode_SIMULATION_MODE_PREVIEW,
+	"fast_preview": executionmodepb.SimulationMode_SIMULATION_MODE_FAST_PREVIEW,
+}
+
+var resumeModes = map[string]executiveservicepb.ResumeOperationRequest_ResumeMode{
+	"continue": executiveservicepb.ResumeOperationRequest_CONTINUE,
+	"step":     executiveservicepb.ResumeOperationRequest_STEP,
+	"next":     executiveservicepb.ResumeOperationRequest_NEXT,
+}
+
+// finishedStates are the operation states in which an operation does not make
+// any progress without further interaction.
+var finishedStates = map[runmetadatapb.RunMetadata_State]bool{
+	runmetadatapb.RunMetadata_SUCCEEDED: true,
+	runmetadatapb.RunMetadata_FAILED:    true,
+	runmetadatapb.RunMetadata_CANCELED:  true,
+	runmetadatapb.RunMetadata_SUSPENDED: true,
+}
+
+// operationResult is the output of a lifecycle command.
+type operationResult struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+func (r *operationResult) String() string {
+	return fmt.Sprintf("Operation %s is %s", r.Name, r.State)
+}
+
+// executiveCmdFunc is the implementation of a command that talks to the
+// executive of a solution.
+type executiveCmdFunc func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error
+
+// wrapExecutiveCmd sets up the connection flags for the given command and
+// dials the cluster before running the given function.
+func wrapExecutiveCmd(cmd *cobra.Command, run executiveCmdFunc) *cobra.Command {
+	vipr := viper.New()
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		projectName := vipr.GetString(orgutil.KeyProject)
+		orgName := vipr.GetString(orgutil.KeyOrganization)
+		ctx, conn, err := connectToCluster(cmd.Context(), projectName,
+			orgName, flagServerAddress,
+			flagSolutionName, flagClusterName)
+		if err != nil {
+			return errors.Wrapf(err, "could not dial connection")
+		}
+		defer conn.Close()
+
+		return run(ctx, cmd, args, conn)
+	}
+	addConnectionFlags(cmd)
+	return platformlevelswitch.WrapCmd(cmd, vipr)
+}
+
+func addOperationFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&flagOperationName, "operation", "", "Name of the executive operation. Can be omitted if the executive has exactly one operation.")
+}
+
+func addWaitFlags(cmd *cobra.Command, waitByDefault bool) {
+	if !waitByDefault {
+		cmd.Flags().BoolVar(&flagWait, "wait", false, "Wait until the operation is finished (succeeded, failed, canceled or suspended).")
+	}
+	cmd.Flags().DurationVar(&flagWaitTimeout, "timeout", 0, "Maximum time to wait for the operation to finish. Waits indefinitely if zero.")
+}
+
+// resolveOperation returns the operation with the given name. If the name is
+// empty, the executive must have exactly one operation, which is returned.
+func resolveOperation(ctx context.Context, exC executiveservicepb.ExecutiveServiceClient, name string) (*longrunningpb.Operation, error) {
+	if name != "" {
+		op, err := exC.GetOperation(ctx, &longrunningpb.GetOperationRequest{Name: name})
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to get operation %q", name)
+		}
+		return op, nil
+	}
+
+	listOpResp, err := exC.ListOperations(ctx, &longrunningpb.ListOperationsRequest{})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list executive operations")
+	}
+
+	switch len(listOpResp.GetOperations()) {
+	case 0:
+		return nil, fmt.Errorf("no operations found. Did you load a behavior tree into the executive?")
+	case 1:
+		return listOpResp.GetOperations()[0], nil
+	default:
+		var names []string
+		for _, op := range listOpResp.GetOperations() {
+			names = append(names, op.GetName())
+		}
+		return nil, fmt.Errorf("found %d concurrent operations, use --operation to select one of: %s", len(names), strings.Join(names, ", "))
+	}
+}
+
+func operationMetadata(op *longrunningpb.Operation) (*runmetadatapb.RunMetadata, error) {
+	metadata := new(runmetadatapb.RunMetadata)
+	if err := op.GetMetadata().UnmarshalTo(metadata); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal RunMetadata proto")
+	}
+	return metadata, nil
+}
+
+// waitForOperation blocks until the given operation is in one of the
+// [finishedStates] or until the timeout expires (if non-zero).
+func waitForOperation(ctx context.Context, exC executiveservicepb.ExecutiveServiceClient, name string, timeout time.Duration) (*longrunningpb.Operation, *runmetadatapb.RunMetadata, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	for {
+		req := &longrunningpb.WaitOperationRequest{Name: name}
+		if deadline, ok := ctx.Deadline(); ok {
+			req.Timeout = durationpb.New(time.Until(deadline))
+		}
+		op, err := exC.WaitOperation(ctx, req)
+		if err != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				return nil, nil, fmt.Errorf("timed out after %v waiting for operation %q", timeout, name)
+			}
+			return nil, nil, errors.Wrapf(err, "unable to wait for operation %q", name)
+		}
+		metadata, err := operationMetadata(op)
+		if err != nil {
+			return nil, nil, err
+		}
+		if finishedStates[metadata.GetOperationState()] {
+			return op, metadata, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, nil, fmt.Errorf("timed out after %v waiting for operation %q (state: %v)", timeout, name, metadata.GetOperationState())
+		case <-time.After(waitPollInterval):
+		}
+	}
+}
+
+// operationResultError converts the final state of an operation into an error
+// with an exit code that tells apart the different outcomes. Returns nil if the
+// operation succeeded.
+func operationResultError(op *longrunningpb.Operation, metadata *runmetadatapb.RunMetadata) error {
+	switch metadata.GetOperationState() {
+	case runmetadatapb.RunMetadata_SUCCEEDED:
+		return nil
+	case runmetadatapb.RunMetadata_FAILED:
+		err := fmt.Errorf("operation %s failed", op.GetName())
+		if opErr := status.ErrorProto(op.GetError()); opErr != nil {
+			err = fmt.Errorf("operation %s failed: %w", op.GetName(), opErr)
+		}
+		return &root.ExitError{Code: exitCodeOperationFailed, Err: err}
+	case runmetadatapb.RunMetadata_CANCELED:
+		return &root.ExitError{Code: exitCodeOperationCanceled, Err: fmt.Errorf("operation %s was canceled", op.GetName())}
+	case runmetadatapb.RunMetadata_SUSPENDED:
+		return &root.ExitError{Code: exitCodeOperationSuspended, Err: fmt.Errorf("operation %s is suspended", op.GetName())}
+	default:
+		return fmt.Errorf("operation %s is in unexpected state %v", op.GetName(), metadata.GetOperationState())
+	}
+}
+
+// printOperationState prints the current state of the given operation using the
+// command's output format.
+func printOperationState(cmd *cobra.Command, name string, state runmetadatapb.RunMetadata_State) error {
+	prtr, err := printer.NewPrinterFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	prtr.Println(&operationResult{Name: name, State: state.String()})
+	return nil
+}
+
+// finishOperationCmd optionally waits for the operation to finish and prints
+// its state.
+func finishOperationCmd(ctx context.Context, cmd *cobra.Command, exC executiveservicepb.ExecutiveServiceClient, name string, wait bool) error {
+	if !wait {
+		op, err := exC.GetOperation(ctx, &longrunningpb.GetOperationRequest{Name: name})
+		if err != nil {
+			return errors.Wrapf(err, "unable to get operation %q", name)
+		}
+		metadata, err := operationMetadata(op)
+		if err != nil {
+			return err
+		}
+		return printOperationState(cmd, name, metadata.GetOperationState())
+	}
+
+	op, metadata, err := waitForOperation(ctx, exC, name, flagWaitTimeout)
+	if err != nil {
+		return err
+	}
+	if err := printOperationState(cmd, name, metadata.GetOperationState()); err != nil {
+		return err
+	}
+	return operationResultError(op, metadata)
+}
+
+func parseEnumFlag[T any](flagName string, value string, values map[string]T) (T, error) {
+	v, ok := values[value]
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("invalid value %q for --%s, must be one of: %s", value, flagName, strings.Join(slices.Sorted(maps.Keys(values)), ", "))
+	}
+	return v, nil
+}
+
+const exitCodesHelp = `
+Exit codes when waiting for the operation:
+  0: The operation succeeded.
+  1: The command failed (e.g., the cluster could not be reached or the timeout expired).
+  2: The operation failed.
+  3: The operation was canceled.
+  4: The operation was suspended.`
+
+var processStartCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "start",
+	Short: "Start an executive operation.",
+	Long: `Start the execution of an operation loaded in the executive (e.g., with 'inctl process set').
+
+$ inctl process start --org my_org --solution my_solution_id [--operation NAME] [--execution_mode normal|step_wise] [--simulation_mode reality|preview|fast_preview] [--wait [--timeout 10m]]
+` + exitCodesHelp,
+	Args: cobra.NoArgs,
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	exC := executiveservicepb.NewExecutiveServiceClient(conn)
+	op, err := resolveOperation(ctx, exC, flagOperationName)
+	if err != nil {
+		return err
+	}
+
+	req := &executiveservicepb.StartOperationRequest{Name: op.GetName()}
+	if flagExecutionMode != "" {
+		if req.ExecutionMode, err = parseEnumFlag("execution_mode", flagExecutionMode, executionModes); err != nil {
+			return err
+		}
+	}
+	if flagSimulationMode != "" {
+		if req.SimulationMode, err = parseEnumFlag("simulation_mode", flagSimulationMode, simulationModes); err != nil {
+			return err
+		}
+	}
+	if flagStartTreeID != "" || cmd.Flags().Changed("start_node_id") {
+		req.StartTreeId = proto.String(flagStartTreeID)
+		req.StartNodeId = proto.Uint32(flagStartNodeID)
+	}
+	if _, err := exC.StartOperation(ctx, req); err != nil {
+		return errors.Wrapf(err, "unable to start operation %q", op.GetName())
+	}
+
+	return finishOperationCmd(ctx, cmd, exC, op.GetName(), flagWait)
+})
+
+var processSuspendCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "suspend",
+	Short: "Suspend a running executive operation.",
+	Long: `Suspend a running operation. Waits for in-flight skills to finish before the operation is suspended.
+
+$ inctl process suspend --org my_org --solution my_solution_id [--operation NAME] [--wait [--timeout 1m]]
+
+With --wait, the command only succeeds if the operation ends up suspended. It fails with exit code 1 if the operation succeeded instead.
+` + exitCodesHelp,
+	Args: cobra.NoArgs,
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	exC := executiveservicepb.NewExecutiveServiceClient(conn)
+	op, err := resolveOperation(ctx, exC, flagOperationName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := exC.SuspendOperation(ctx, &executiveservicepb.SuspendOperationRequest{Name: op.GetName()}); err != nil {
+		return errors.Wrapf(err, "unable to suspend operation %q", op.GetName())
+	}
+
+	if !flagWait {
+		return finishOperationCmd(ctx, cmd, exC, op.GetName(), false)
+	}
+	op, metadata, err := waitForOperation(ctx, exC, op.GetName(), flagWaitTimeout)
+	if err != nil {
+		return err
+	}
+	if err := printOperationState(cmd, op.GetName(), metadata.GetOperationState()); err != nil {
+		return err
+	}
+	// Suspending is the expected outcome here and not an error.
+	if metadata.GetOperationState() == runmetadatapb.RunMetadata_SUSPENDED {
+		return nil
+	}
+	if err := operationResultError(op, metadata); err != nil {
+		return err
+	}
+	return fmt.Errorf("operation %s succeeded before it could be suspended", op.GetName())
+})
+
+var processResumeCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "resume",
+	Short: "Resume a suspended executive operation.",
+	Long: `Resume a suspended operation.
+
+$ inctl process resume --org my_org --solution my_solution_id [--operation NAME] [--mode continue|step|next] [--wait [--timeout 10m]]
+` + exitCodesHelp,
+	Args: cobra.NoArgs,
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	exC := executiveservicepb.NewExecutiveServiceClient(conn)
+	op, err := resolveOperation(ctx, exC, flagOperationName)
+	if err != nil {
+		return err
+	}
+
+	req := &executiveservicepb.ResumeOperationRequest{Name: op.GetName()}
+	if flagResumeMode != "" {
+		mode, err := parseEnumFlag("mode", flagResumeMode, resumeModes)
+		if err != nil {
+			return err
+		}
+		req.Mode = mode.Enum()
+	}
+	if _, err := exC.ResumeOperation(ctx, req); err != nil {
+		return errors.Wrapf(err, "unable to resume operation %q", op.GetName())
+	}
+
+	return finishOperationCmd(ctx, cmd, exC, op.GetName(), flagWait)
+})
+
+var processCancelCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "cancel",
+	Short: "Cancel an executive operation.",
+	Long: `Cancel a running or suspended operation.
+
+$ inctl process cancel --org my_org --solution my_solution_id [--operation NAME] [--wait [--timeout 1m]]
+` + exitCodesHelp,
+	Args: cobra.NoArgs,
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	exC := executiveservicepb.NewExecutiveServiceClient(conn)
+	op, err := resolveOperation(ctx, exC, flagOperationName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := exC.CancelOperation(ctx, &longrunningpb.CancelOperationRequest{Name: op.GetName()}); err != nil {
+		return errors.Wrapf(err, "unable to cancel operation %q", op.GetName())
+	}
+
+	return finishOperationCmd(ctx, cmd, exC, op.GetName(), flagWait)
+})
+
+var processResetCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "reset",
+	Short: "Reset an executive operation.",
+	Long: `Reset a finished operation so that it can be started again.
+
+$ inctl process reset --org my_org --solution my_solution_id [--operation NAME] [--keep_blackboard]`,
+	Args: cobra.NoArgs,
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	exC := executiveservicepb.NewExecutiveServiceClient(conn)
+	op, err := resolveOperation(ctx, exC, flagOperationName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := exC.ResetOperation(ctx, &executiveservicepb.ResetOperationRequest{
+		Name:           op.GetName(),
+		KeepBlackboard: flagKeepBlackboard,
+	}); err != nil {
+		return errors.Wrapf(err, "unable to reset operation %q", op.GetName())
+	}
+
+	return finishOperationCmd(ctx, cmd, exC, op.GetName(), false)
+})
+
+var processWaitCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "wait",
+	Short: "Wait for an executive operation to finish.",
+	Long: `Wait until an operation succeeded, failed, was canceled or was suspended.
+
+$ inctl process wait --org my_org --solution my_solution_id [--operation NAME] [--timeout 10m]
+` + exitCodesHelp,
+	Args: cobra.NoArgs,
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	exC := executiveservicepb.NewExecutiveServiceClient(conn)
+	op, err := resolveOperation(ctx, exC, flagOperationName)
+	if err != nil {
+		return err
+	}
+
+	return finishOperationCmd(ctx, cmd, exC, op.GetName(), true)
+})
+
+var processAbandonCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "abandon",
+	Short: "Force an executive operation to stop.",
+	Long: `Force an operation to stop independent of ongoing skill calls.
+
+This is a last resort if 'inctl process cancel' does not lead to a canceled operation. All ongoing skill calls are abandoned and left in an unknown state. Only available after the operation has been canceled.
+
+$ inctl process abandon --org my_org --solution my_solution_id [--operation NAME]`,
+	Args: cobra.NoArgs,
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	exC := executiveservicepb.NewExecutiveServiceClient(conn)
+	op, err := resolveOperation(ctx, exC, flagOperationName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := exC.ForceAbandonOperation(ctx, &executiveservicepb.ForceAbandonOperationRequest{Name: op.GetName()}); err != nil {
+		return errors.Wrapf(err, "unable to abandon operation %q", op.GetName())
+	}
+
+	return finishOperationCmd(ctx, cmd, exC, op.GetName(), false)
+})
+
+func init() {
+	for _, cmd := range []*cobra.Command{
+		processStartCmd,
+		processSuspendCmd,
+		processResumeCmd,
+		processCancelCmd,
+		processResetCmd,
+		processWaitCmd,
+		processAbandonCmd,
+	} {
+		addOperationFlag(cmd)
+	}
+
+	processStartCmd.Flags().StringVar(&flagExecutionMode, "execution_mode", "", "(optional) Execution mode. One of: (normal, step_wise)")
+	processStartCmd.Flags().StringVar(&flagSimulationMode, "simulation_mode", "", "(optional) Simulation mode. One of: (reality, preview, fast_preview)")
+	processStartCmd.Flags().StringVar(&flagStartTreeID, "start_tree_id", "", "(optional) Id of the tree containing the node to start execution from.")
+	processStartCmd.Flags().Uint32Var(&flagStartNodeID, "start_node_id", 0, "(optional) Id of the node to start execution from.")
+	addWaitFlags(processStartCmd, false)
+
+	addWaitFlags(processSuspendCmd, false)
+
+	processResumeCmd.Flags().StringVar(&flagResumeMode, "mode", "", "(optional) Resume mode. One of: (continue, step, next)")
+	addWaitFlags(processResumeCmd, false)
+
+	addWaitFlags(processCancelCmd, false)
+
+	processResetCmd.Flags().BoolVar(&flagKeepBlackboard, "keep_blackboard", false, "Keep the blackboard values of the previous run.")
+
+	addWaitFlags(processWaitCmd, true)
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"intrinsic/executive/go/behaviortreelint"
+	"intrinsic/executive/go/behaviortreequery"
+	btpb "intrinsic/executive/proto/behavior_tree_go_proto"
+	executiveservicepb "intrinsic/executive/proto/executive_service_go_proto"
+	protoregistrygrpcpb "intrinsic/proto_tools/proto/proto_registry_go_proto"
+	"intrinsic/tools/inctl/util/printer"
+
+	longrunningpb "cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+var flagPatchSet []string
+
+type fieldAssignment struct {
+	path  string
+	value string
+}
+
+func parseFieldAssignments(values []string) ([]fieldAssignment, error) {
+	var result []fieldAssignment
+	for _, v := range values {
+		path, value, ok := strings.Cut(v, "=")
+		if !ok || path == "" {
+			return nil, fmt.Errorf("invalid --set value %q, expected field=value", v)
+		}
+		result = append(result, fieldAssignment{path: strings.TrimSpace(path), value: value})
+	}
+	return result, nil
+}
+
+// replaceOperation deletes the operation with the given name and creates a new
+// operation for bt.
+func replaceOperation(ctx context.Context, exC executiveservicepb.ExecutiveServiceClient, name string, bt *btpb.BehaviorTree) error {
+	if _, err := exC.DeleteOperation(ctx, &longrunningpb.DeleteOperationRequest{Name: name}); err != nil {
+		return errors.Wrapf(err, "unable to delete operation %q", name)
+	}
+	if _, err := exC.CreateOperation(ctx, &executiveservicepb.CreateOperationRequest{
+		RunnableType: &executiveservicepb.CreateOperationRequest_BehaviorTree{BehaviorTree: bt},
+	}); err != nil {
+		return errors.Wrap(err, "unable to create executive operation")
+	}
+	return nil
+}
+
+var processPatchCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "patch",
+	Short: "Modify selected nodes of the active process.",
+	Long: `Modify the elements of the process currently loaded in the executive that match a query and load the modified process.
+
+Elements are selected with the same queries as for "inctl process get --select". Each --set assigns a value to a field of all selected elements. Field paths are resolved as in queries, e.g., "skill_id" for task nodes or "parameters.velocity" for fields of skill parameters. Messages are given in text format.
+
+$ inctl process patch --org my_org --solution my_solution_id [--operation NAME] --select '//task[skill_id="ai.intrinsic.move_robot"]' --set skill_id=ai.intrinsic.move_robot_v2
+$ inctl process patch --org my_org --solution my_solution_id --select 'sequence[name="pick"]/*' --set 'decorators.execution_settings={ mode: DISABLED }'
+
+The selected operation in the executive is replaced, i.e., the process has to be started again.`,
+	Args: cobra.NoArgs,
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	assignments, err := parseFieldAssignments(flagPatchSet)
+	if err != nil {
+		return err
+	}
+	query, err := behaviortreequery.Parse(flagSelect)
+	if err != nil {
+		return err
+	}
+
+	exC := executiveservicepb.NewExecutiveServiceClient(conn)
+	op, err := resolveOperation(ctx, exC, flagOperationName)
+	if err != nil {
+		return err
+	}
+	metadata, err := operationMetadata(op)
+	if err != nil {
+		return err
+	}
+	bt := metadata.GetBehaviorTree()
+	if bt == nil {
+		return fmt.Errorf("operation %q has no behavior tree", op.GetName())
+	}
+	resolver, err := treeResolver(ctx, bt, protoregistrygrpcpb.NewProtoRegistryClient(conn))
+	if err != nil {
+		return err
+	}
+	opts := []behaviortreequery.Option{behaviortreequery.WithResolver(resolver)}
+	elements, err := query.Select(ctx, bt, opts...)
+	if err != nil {
+		return errors.Wrapf(err, "could not evaluate %q", flagSelect)
+	}
+	if len(elements) == 0 {
+		return fmt.Errorf("no elements match %q", flagSelect)
+	}
+	for _, element := range elements {
+		for _, a := range assignments {
+			if err := behaviortreequery.Set(element, a.path, a.value, opts...); err != nil {
+				return errors.Wrapf(err, "could not set %s of %s", a.path, behaviortreelint.Path(element))
+			}
+		}
+	}
+
+	// The tree returned by the executive contains its execution state.
+	if err := clearTree(bt, false, false); err != nil {
+		return errors.Wrap(err, "could not clear output-only fields")
+	}
+	if err := replaceOperation(ctx, exC, op.GetName(), bt); err != nil {
+		return errors.Wrap(err, "could not load patched behavior tree")
+	}
+
+	prtr, err := printer.NewPrinterFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	printer.PrintMsgf(prtr, "Patched %d elements.", len(elements))
+	return nil
+})
+
+func init() {
+	addOperationFlag(processPatchCmd)
+	processPatchCmd.Flags().StringVar(&flagSelect, "select", "", "Query that selects the elements to modify (e.g., '//task[skill_id=\"ai.intrinsic.move_robot\"]').")
+	processPatchCmd.Flags().StringArrayVar(&flagPatchSet, "set", nil, "Assignment field=value applied to all selected elements. Can be given multiple times.")
+	processPatchCmd.MarkFlagRequired("select")
+	processPatchCmd.MarkFlagRequired("set")
+}
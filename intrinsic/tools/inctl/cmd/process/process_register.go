@@ -0,0 +1,199 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"intrinsic/assets/idutils"
+	btpb "intrinsic/executive/proto/behavior_tree_go_proto"
+	protoregistrypb "intrinsic/proto_tools/proto/proto_registry_go_proto"
+	btregistrypb "intrinsic/skills/proto/behavior_tree_registry_go_proto"
+	skillregistryconfigpb "intrinsic/skills/proto/skill_registry_config_go_proto"
+	skillspb "intrinsic/skills/proto/skills_go_proto"
+	"intrinsic/tools/inctl/util/printer"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var flagRegisterAsSkill string
+
+// validateMessageInFileset checks that the message with the given name is
+// described by the given file descriptor set.
+func validateMessageInFileset(fullName string, fds *descriptorpb.FileDescriptorSet) error {
+	if fullName == "" {
+		return fmt.Errorf("message name is empty")
+	}
+	if fds == nil {
+		return fmt.Errorf("no file descriptor set for %s", fullName)
+	}
+	files, err := protodesc.NewFiles(fds)
+	if err != nil {
+		return errors.Wrap(err, "invalid file descriptor set")
+	}
+	d, err := files.FindDescriptorByName(protoreflect.FullName(fullName))
+	if err != nil {
+		return fmt.Errorf("message %s is not in the file descriptor set", fullName)
+	}
+	if _, ok := d.(protoreflect.MessageDescriptor); !ok {
+		return fmt.Errorf("%s is not a message", fullName)
+	}
+	return nil
+}
+
+// validateDefaultValue checks that the given default value (if any) has the
+// parameter type.
+func validateDefaultValue(defaultValue *anypb.Any, fullName string) error {
+	if defaultValue == nil {
+		return nil
+	}
+	if got := string(defaultValue.MessageName()); got != fullName {
+		return fmt.Errorf("default value has type %s, want %s", got, fullName)
+	}
+	return nil
+}
+
+// prepareSkillDescription validates the skill description of the given tree
+// and fills in the fields derived from the skill id. A tree without description
+// becomes a skill without parameters and return value.
+func prepareSkillDescription(bt *btpb.BehaviorTree, id string) error {
+	if err := idutils.ValidateID(id); err != nil {
+		return errors.Wrapf(err, "invalid skill id %q", id)
+	}
+	if bt.GetRoot() == nil {
+		return fmt.Errorf("behavior tree has no root node")
+	}
+	if bt.Description == nil {
+		bt.Description = &skillspb.Skill{}
+	}
+	d := bt.Description
+	if d.GetId() != "" && d.GetId() != id {
+		return fmt.Errorf("behavior tree is described as skill %q, which does not match %q", d.GetId(), id)
+	}
+	d.Id = id
+	// ValidateID guarantees that the id consists of a package and a name.
+	d.PackageName, _ = idutils.PackageFrom(id)
+	d.SkillName, _ = idutils.NameFrom(id)
+	if d.BehaviorTreeDescription == nil {
+		d.BehaviorTreeDescription = &skillspb.BehaviorTreeDescription{}
+	}
+	if d.GetDisplayName() == "" {
+		d.DisplayName = bt.GetName()
+	}
+
+	if p := d.GetParameterDescription(); p != nil {
+		if err := validateMessageInFileset(p.GetParameterMessageFullName(), p.GetParameterDescriptorFileset()); err != nil {
+			return errors.Wrap(err, "invalid parameter description")
+		}
+		if err := validateDefaultValue(p.GetDefaultValue(), p.GetParameterMessageFullName()); err != nil {
+			return errors.Wrap(err, "invalid parameter description")
+		}
+	}
+	if r := d.GetReturnValueDescription(); r != nil {
+		if err := validateMessageInFileset(r.GetReturnValueMessageFullName(), r.GetDescriptorFileset()); err != nil {
+			return errors.Wrap(err, "invalid return value description")
+		}
+	}
+	return nil
+}
+
+var processRegisterCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "register",
+	Short: "Register a process as a skill.",
+	Long: `Register a process as a skill in a currently deployed solution.
+
+The input is a intrinsic_proto.executive.BehaviorTree proto in binary or text format. The parameters and return value of the skill are taken from the "description" of the tree, which is validated before registering. Afterwards, the process is listed by "inctl skill list" and can be used like any other skill. Registering a process with the id of an existing process skill updates it.
+
+$ inctl process register --org my_org --solution my_solution_id --input_file /tmp/my-process.textproto [--process_format textproto|binaryproto] --as-skill com.example.my_process`,
+	Args: cobra.NoArgs,
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	content, err := os.ReadFile(flagInputFile)
+	if err != nil {
+		return errors.Wrapf(err, "could not read input file")
+	}
+	bt, err := deserializeBT(ctx, flagProcessFormat, content, protoregistrypb.NewProtoRegistryClient(conn))
+	if err != nil {
+		return errors.Wrapf(err, "could not deserialize BT")
+	}
+	if err := clearTree(bt, flagClearTreeID, flagClearNodeIDs); err != nil {
+		return errors.Wrap(err, "could not clear tree")
+	}
+
+	if err := prepareSkillDescription(bt, flagRegisterAsSkill); err != nil {
+		return err
+	}
+
+	client := btregistrypb.NewBehaviorTreeRegistryClient(conn)
+	if _, err := client.RegisterOrUpdateBehaviorTree(ctx, &btregistrypb.RegisterOrUpdateBehaviorTreeRequest{
+		Registration: &skillregistryconfigpb.BehaviorTreeRegistration{BehaviorTree: bt},
+	}); err != nil {
+		return errors.Wrapf(err, "could not register behavior tree as skill %s", flagRegisterAsSkill)
+	}
+
+	prtr, err := printer.NewPrinterFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	printer.PrintMsgf(prtr, "Registered process as skill %s.", flagRegisterAsSkill)
+	return nil
+})
+
+var processUnregisterCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "unregister skill_id",
+	Short: "Unregister a process skill.",
+	Long: `Remove a skill that was registered from a process from a currently deployed solution.
+
+$ inctl process unregister --org my_org --solution my_solution_id com.example.my_process`,
+	Args: cobra.ExactArgs(1),
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	id := strings.TrimSpace(args[0])
+	if err := idutils.ValidateID(id); err != nil {
+		return errors.Wrapf(err, "invalid skill id %q", id)
+	}
+
+	client := btregistrypb.NewBehaviorTreeRegistryClient(conn)
+	if _, err := client.UnregisterBehaviorTree(ctx, &btregistrypb.UnregisterBehaviorTreeRequest{Id: id}); err != nil {
+		return errors.Wrapf(err, "could not unregister skill %s", id)
+	}
+
+	prtr, err := printer.NewPrinterFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	printer.PrintMsgf(prtr, "Unregistered skill %s.", id)
+	return nil
+})
+
+func init() {
+	allowedFormats := []string{TextProtoFormat, BinaryProtoFormat}
+	processRegisterCmd.Flags().StringVar(
+		&flagProcessFormat, "process_format", TextProtoFormat,
+		fmt.Sprintf("(optional) input format. One of: (%s)", strings.Join(allowedFormats, ", ")))
+	processRegisterCmd.Flags().BoolVar(&flagClearTreeID, "clear_tree_id", true, "Clear the tree_id field from the BT proto.")
+	processRegisterCmd.Flags().BoolVar(&flagClearNodeIDs, "clear_node_ids", true, "Clear the nodes' id fields from the BT proto.")
+	processRegisterCmd.Flags().StringVar(&flagInputFile, "input_file", "", "File from which to read the process.")
+	processRegisterCmd.Flags().StringVar(&flagRegisterAsSkill, "as-skill", "", "Id of the skill to register the process as, e.g., com.example.my_process.")
+	processRegisterCmd.MarkFlagRequired("input_file")
+	processRegisterCmd.MarkFlagRequired("as-skill")
+}
@@ -0,0 +1,387 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"intrinsic/executive/go/behaviortree"
+	behaviortreepb "intrinsic/executive/proto/behavior_tree_go_proto"
+	executiveservicepb "intrinsic/executive/proto/executive_service_go_proto"
+	runmetadatapb "intrinsic/executive/proto/run_metadata_go_proto"
+	"intrinsic/tools/inctl/util/color"
+	"intrinsic/tools/inctl/util/printer"
+	espb "intrinsic/util/status/extended_status_go_proto"
+	"intrinsic/util/status/extstatus"
+
+	longrunningpb "cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+// clearScreen moves the cursor to the top left corner and clears the terminal.
+const clearScreen = "\x1b[H\x1b[2J"
+
+var flagWatchInterval time.Duration
+
+// nodeStatus is the state of a single node of a watched behavior tree.
+type nodeStatus struct {
+	key       string
+	treeID    string
+	nodeID    uint32
+	hasNodeID bool
+	name      string
+	nodeType  string
+	depth     int
+	state     behaviortreepb.BehaviorTree_Node_State
+	// failureReason is set by the executive for failed nodes.
+	failureReason *behaviortreepb.BehaviorTree_Node_FailureReason
+}
+
+// nodeStatusCollector is a behavior tree visitor that collects the states of
+// all nodes in the order in which they appear in the tree.
+type nodeStatusCollector struct {
+	nodes []*nodeStatus
+	// Number of nodes without an id seen per tree, used to derive stable keys.
+	anonymousNodes map[string]int
+}
+
+func (c *nodeStatusCollector) Visit(ctx context.Context, element behaviortree.VisitElement) error {
+	node := element.Node()
+	if node == nil {
+		return nil
+	}
+
+	treeID := behaviortree.EnclosingTree(element).GetTreeId()
+	depth := 0
+	for ancestor := range element.Ancestors() {
+		if ancestor.Node() != nil {
+			depth++
+		}
+	}
+
+	status := &nodeStatus{
+		treeID:        treeID,
+		nodeID:        node.GetId(),
+		hasNodeID:     node.Id != nil,
+		name:          node.GetName(),
+		nodeType:      behaviortree.NodeType(node),
+		depth:         depth,
+		state:         node.GetState(),
+		failureReason: node.FailureReason,
+	}
+	if status.hasNodeID {
+		status.key = fmt.Sprintf("%s:%d", treeID, status.nodeID)
+	} else {
+		status.key = fmt.Sprintf("%s#%d", treeID, c.anonymousNodes[treeID])
+		c.anonymousNodes[treeID]++
+	}
+	c.nodes = append(c.nodes, status)
+	return nil
+}
+
+func collectNodeStatuses(ctx context.Context, bt *behaviortreepb.BehaviorTree) ([]*nodeStatus, error) {
+	collector := &nodeStatusCollector{anonymousNodes: map[string]int{}}
+	if bt == nil {
+		return nil, nil
+	}
+	if err := behaviortree.Walk(ctx, bt, collector, behaviortree.VisitCalledTreeState()); err != nil {
+		return nil, errors.Wrap(err, "failed walking behavior tree")
+	}
+	return collector.nodes, nil
+}
+
+// activeNode returns the innermost running node, which is the node that is
+// currently being executed. Returns nil if no node is running.
+func activeNode(nodes []*nodeStatus) *nodeStatus {
+	var active *nodeStatus
+	for _, n := range nodes {
+		if n.state == behaviortreepb.BehaviorTree_Node_RUNNING && (active == nil || n.depth >= active.depth) {
+			active = n
+		}
+	}
+	return active
+}
+
+// watchEvent is emitted for every state transition of the operation or of one
+// of its nodes when watching with JSON or NDJSON output.
+type watchEvent struct {
+	Time           time.Time `json:"time"`
+	Operation      string    `json:"operation"`
+	OperationState string    `json:"operation_state"`
+	TreeID         string    `json:"tree_id,omitempty"`
+	NodeID         *uint32   `json:"node_id,omitempty"`
+	NodeName       string    `json:"node_name,omitempty"`
+	NodeType       string    `json:"node_type,omitempty"`
+	PreviousState  string    `json:"previous_state,omitempty"`
+	State          string    `json:"state,omitempty"`
+	FailureReason  string    `json:"failure_reason,omitempty"`
+	ExtendedStatus string    `json:"extended_status,omitempty"`
+}
+
+func (e *watchEvent) String() string {
+	if e.State == "" {
+		return fmt.Sprintf("%s operation %s: %s", e.Time.Format(time.RFC3339), e.Operation, e.OperationState)
+	}
+	return fmt.Sprintf("%s node %q (%s): %s -> %s", e.Time.Format(time.RFC3339), e.NodeName, e.NodeType, e.PreviousState, e.State)
+}
+
+// operationExtendedStatus returns the extended status of the operation error,
+// or the diagnostics of the operation if its error carries none. Returns nil if
+// neither is set.
+func operationExtendedStatus(op *longrunningpb.Operation, metadata *runmetadatapb.RunMetadata) *espb.ExtendedStatus {
+	if es, ok := extstatus.FromGRPCStatusProto(op.GetError()); ok {
+		return es.Proto()
+	}
+	return metadata.GetDiagnostics()
+}
+
+// nodeStateEvents returns an event for every node whose state differs from the
+// state in the previous snapshot. Events of failed nodes carry the failure
+// reason of the node and the extended status of the operation, es, if any.
+func nodeStateEvents(now time.Time, operation string, opState runmetadatapb.RunMetadata_State, es *espb.ExtendedStatus, previous map[string]*nodeStatus, nodes []*nodeStatus) []*watchEvent {
+	var events []*watchEvent
+	for _, n := range nodes {
+		prev, ok := previous[n.key]
+		if ok && prev.state == n.state {
+			continue
+		}
+		if !ok && n.state == behaviortreepb.BehaviorTree_Node_UNSPECIFIED {
+			continue
+		}
+		event := &watchEvent{
+			Time:           now,
+			Operation:      operation,
+			OperationState: opState.String(),
+			TreeID:         n.treeID,
+			NodeName:       n.name,
+			NodeType:       n.nodeType,
+			State:          n.state.String(),
+		}
+		if n.hasNodeID {
+			event.NodeID = &n.nodeID
+		}
+		if ok {
+			event.PreviousState = prev.state.String()
+		}
+		if n.state == behaviortreepb.BehaviorTree_Node_FAILED {
+			if n.failureReason != nil {
+				event.FailureReason = n.failureReason.String()
+			}
+			if es != nil {
+				event.ExtendedStatus = extstatus.FromProto(es).String()
+			}
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func nodeStateColor(state behaviortreepb.BehaviorTree_Node_State) color.Color {
+	switch state {
+	case behaviortreepb.BehaviorTree_Node_RUNNING:
+		return color.C.LightCyan()
+	case behaviortreepb.BehaviorTree_Node_SUCCEEDED:
+		return color.C.Green()
+	case behaviortreepb.BehaviorTree_Node_FAILED:
+		return color.C.Red()
+	case behaviortreepb.BehaviorTree_Node_SUSPENDED:
+		return color.C.Yellow()
+	case behaviortreepb.BehaviorTree_Node_CANCELING, behaviortreepb.BehaviorTree_Node_CANCELED:
+		return color.C.Magenta()
+	default:
+		return color.C.LightGray()
+	}
+}
+
+func indentExtendedStatus(es *espb.ExtendedStatus, indent string) string {
+	lines := strings.Split(strings.TrimRight(extstatus.FromProto(es).String(), "\n"), "\n")
+	for i, l := range lines {
+		lines[i] = indent + l
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderTree writes a human-readable representation of the operation and the
+// states of all of its nodes to w.
+func renderTree(w io.Writer, op *longrunningpb.Operation, metadata *runmetadatapb.RunMetadata, nodes []*nodeStatus) {
+	fmt.Fprintf(w, "Operation %s: %s\n", op.GetName(), metadata.GetOperationState())
+	if bt := metadata.GetBehaviorTree(); bt.GetName() != "" {
+		fmt.Fprintf(w, "Process:   %s\n", bt.GetName())
+	}
+	if d := metadata.GetExecutionTime(); d != nil {
+		fmt.Fprintf(w, "Duration:  %v\n", d.AsDuration().Round(time.Millisecond))
+	}
+	fmt.Fprintln(w)
+
+	active := activeNode(nodes)
+	for _, n := range nodes {
+		marker := "  "
+		if n == active {
+			marker = color.C.LightCyan().Sprintf("▶ ")
+		}
+		label := n.name
+		if label == "" {
+			label = "<unnamed>"
+		}
+		state := nodeStateColor(n.state).Sprintf("%-10s", n.state)
+		reason := ""
+		if n.state == behaviortreepb.BehaviorTree_Node_FAILED && n.failureReason != nil {
+			reason = " " + color.C.Red().Sprintf("[%s]", n.failureReason)
+		}
+		fmt.Fprintf(w, "%s%s%s %s (%s)%s\n", marker, strings.Repeat("  ", n.depth), state, label, n.nodeType, reason)
+	}
+
+	if metadata.GetOperationState() == runmetadatapb.RunMetadata_FAILED {
+		if es := operationExtendedStatus(op, metadata); es != nil {
+			fmt.Fprintf(w, "\nOperation failed:\n%s\n", indentExtendedStatus(es, "  "))
+		}
+	}
+}
+
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+type watchParams struct {
+	executive executiveservicepb.ExecutiveServiceClient
+	name      string
+	interval  time.Duration
+	// If true, events are printed instead of rendering the whole tree.
+	events bool
+	out    io.Writer
+	prtr   printer.CommandPrinter
+	clear  bool
+}
+
+func watchOperation(ctx context.Context, params *watchParams) error {
+	previous := map[string]*nodeStatus{}
+	previousOpState := runmetadatapb.RunMetadata_UNSPECIFIED
+	for {
+		op, err := params.executive.GetOperationView(ctx, &executiveservicepb.GetOperationViewRequest{
+			Name: params.name,
+			ViewType: &executiveservicepb.GetOperationViewRequest_View_{
+				View: executiveservicepb.GetOperationViewRequest_VIEW_DEFAULT,
+			},
+		})
+		if err != nil {
+			return errors.Wrapf(err, "unable to get operation %q", params.name)
+		}
+		metadata, err := operationMetadata(op)
+		if err != nil {
+			return err
+		}
+		nodes, err := collectNodeStatuses(ctx, metadata.GetBehaviorTree())
+		if err != nil {
+			return err
+		}
+
+		opState := metadata.GetOperationState()
+		events := nodeStateEvents(time.Now(), op.GetName(), opState, operationExtendedStatus(op, metadata), previous, nodes)
+		opStateChanged := opState != previousOpState
+
+		if params.events {
+			if opStateChanged {
+				params.prtr.Println(&watchEvent{
+					Time:           time.Now(),
+					Operation:      op.GetName(),
+					OperationState: opState.String(),
+				})
+			}
+			for _, e := range events {
+				params.prtr.Println(e)
+			}
+		} else if opStateChanged || len(events) > 0 {
+			if params.clear {
+				fmt.Fprint(params.out, clearScreen)
+			}
+			renderTree(params.out, op, metadata, nodes)
+		}
+
+		previous = map[string]*nodeStatus{}
+		for _, n := range nodes {
+			previous[n.key] = n
+		}
+		previousOpState = opState
+
+		switch opState {
+		case runmetadatapb.RunMetadata_SUCCEEDED, runmetadatapb.RunMetadata_FAILED, runmetadatapb.RunMetadata_CANCELED:
+			return operationResultError(op, metadata)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(params.interval):
+		}
+	}
+}
+
+var processWatchCmd = wrapExecutiveCmd(&cobra.Command{
+	Use:   "watch",
+	Short: "Watch the execution of an executive operation.",
+	Long: `Watch the execution of an operation and show the state of every node of its behavior tree.
+
+The tree is redrawn whenever the state of the operation or of one of its nodes changes. The currently active node is marked with '▶'. Watching ends when the operation succeeded, failed or was canceled.
+
+$ inctl process watch --org my_org --solution my_solution_id [--operation NAME] [--interval 500ms]
+
+With --output ndjson (or json), one event is printed per line for every state transition instead:
+$ inctl process watch --org my_org --solution my_solution_id --output ndjson
+
+Exit codes:
+  0: The operation succeeded.
+  1: The command failed (e.g., the cluster could not be reached).
+  2: The operation failed.
+  3: The operation was canceled.`,
+	Args: cobra.NoArgs,
+}, func(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	exC := executiveservicepb.NewExecutiveServiceClient(conn)
+	op, err := resolveOperation(ctx, exC, flagOperationName)
+	if err != nil {
+		return err
+	}
+
+	prtr, err := printer.NewPrinterFromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	outputType := printer.GetFlagOutputType(cmd)
+
+	return watchOperation(ctx, &watchParams{
+		executive: exC,
+		name:      op.GetName(),
+		interval:  flagWatchInterval,
+		events:    outputType == printer.OutputTypeJSON || outputType == printer.OutputTypeNDJSON,
+		out:       cmd.OutOrStdout(),
+		prtr:      prtr,
+		clear:     isTerminal(os.Stdout),
+	})
+})
+
+func init() {
+	addOperationFlag(processWatchCmd)
+	processWatchCmd.Flags().DurationVar(&flagWatchInterval, "interval", time.Second, "Interval at which the operation is polled.")
+}
@@ -34,7 +34,7 @@ var operationPollInterval = 2 * time.Second
 var PubsubCmd = &cobra.Command{
 	Use:        "pubsub",
 	Short:      "Manages Intrinsic PubSub connected services.",
-	Long:       "Manages Intrinsic PubSub connected services including Hub creation, deletion and traffic configuration.",
+	Long:       "Manages Intrinsic PubSub connected services including Hub creation, deletion and traffic configuration, and inspects PubSub topics.",
 	SuggestFor: []string{"pub-sub", "mq", "bus"},
 }
 
@@ -0,0 +1,166 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topic
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"intrinsic/assets/cmdutils"
+	anyresolver "intrinsic/httpjson/any"
+	"intrinsic/platform/pubsub/golang/pubsubinterface"
+	"intrinsic/tools/inctl/cmd/pubsub/pubsub"
+	"intrinsic/tools/inctl/cmd/root"
+
+	"github.com/spf13/cobra"
+
+	pubsubpb "intrinsic/platform/pubsub/adapters/pubsub_go_proto"
+)
+
+// echoQueueSize is the number of received messages that echo buffers while it
+// is printing. Further messages are dropped.
+const echoQueueSize = 64
+
+// EchoCmdRunner handles execution of the echo command.
+// That command prints the messages published on a topic expression.
+type EchoCmdRunner struct {
+	ps           pubsubinterface.PubSub
+	resolver     anyresolver.Resolver
+	outputWriter io.Writer
+	format       string
+
+	// count is the number of messages to print before returning. Zero means no
+	// limit.
+	count int
+	// limiter drops messages that exceed the maximum printing rate.
+	limiter *rateLimiter
+}
+
+func (r *EchoCmdRunner) run(ctx context.Context, topic string) error {
+	packets := make(chan *pubsubpb.PubSubPacket, echoQueueSize)
+	sub, err := r.ps.NewRawSubscription(topic, pubsubinterface.TopicConfig{Qos: pubsubinterface.Sensor},
+		func(packet *pubsubpb.PubSubPacket) {
+			select {
+			case packets <- packet:
+			default:
+			}
+		})
+	if err != nil {
+		return fmt.Errorf("could not subscribe to %q: %w", topic, err)
+	}
+	defer sub.Close()
+
+	printed := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case packet := <-packets:
+			if !r.limiter.allow(time.Now()) {
+				continue
+			}
+			if err := printValue(r.outputWriter, r.format, newTopicMessage(packet, r.resolver, r.format)); err != nil {
+				return fmt.Errorf("could not print message: %w", err)
+			}
+			printed++
+			if r.count > 0 && printed >= r.count {
+				return nil
+			}
+		}
+	}
+}
+
+// EchoCmdEnvironment is the execution environment for the echo command.
+// That environment contains command line flags.
+type EchoCmdEnvironment struct {
+	cmdFlags *cmdutils.CmdFlags
+}
+
+// RunE sets up the execution environment and invokes EchoCmdRunner.run.
+func (e *EchoCmdEnvironment) RunE(cmd *cobra.Command, args []string) error {
+	if err := checkOutputFormat(root.FlagOutput); err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	ps, err := newPubSub()
+	if err != nil {
+		return err
+	}
+	defer ps.Close()
+	resolver, closeResolver, err := newTypeResolver(e.cmdFlags.GetString(keyResolverAddress))
+	if err != nil {
+		return err
+	}
+	defer closeResolver()
+
+	runner := &EchoCmdRunner{
+		ps:           ps,
+		resolver:     resolver,
+		outputWriter: cmd.OutOrStdout(),
+		format:       root.FlagOutput,
+		count:        e.cmdFlags.GetInt(keyCount),
+		limiter:      newRateLimiter(e.cmdFlags.GetInt(keyMaxRate)),
+	}
+	return runner.run(ctx, args[0])
+}
+
+// NewEchoCmd returns the initialized cobra command for echo.
+func NewEchoCmd() *cobra.Command {
+	flags := cmdutils.NewCmdFlags()
+	commandWrapper := &EchoCmdEnvironment{cmdFlags: flags}
+
+	cmd := &cobra.Command{
+		Use:   "echo <topic-expr>",
+		Short: "Prints the messages published on PubSub topics.",
+		Long: `Prints the messages published on the topics that match the given key expression.
+
+Messages are printed as text protos by default, or as JSON with --output=json or
+--output=ndjson. Message types are resolved through the ProtoRegistry and
+InstalledAssets services of the workcell. Must be run on the workcell or a
+machine in its network.
+
+Example:
+  inctl pubsub echo "robot/status" --count 10`,
+		Args: cobra.ExactArgs(1),
+		RunE: commandWrapper.RunE,
+	}
+
+	flags.SetCommand(cmd)
+
+	flags.OptionalString(
+		keyResolverAddress,
+		defaultResolverAddress,
+		"Address of the services used to resolve message types.")
+	flags.Int(
+		keyCount,
+		0,
+		"(optional) Number of messages to print before exiting. 0 prints messages until interrupted.")
+	flags.Int(
+		keyMaxRate,
+		0,
+		"(optional) Maximum number of messages to print per second. Further messages are dropped. 0 prints all messages.")
+
+	return cmd
+}
+
+func init() {
+	pubsub.PubsubCmd.AddCommand(NewEchoCmd())
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"intrinsic/platform/pubsub/golang/pubsubfake"
+	"intrinsic/platform/pubsub/golang/pubsubinterface"
+	"intrinsic/tools/inctl/util/printer"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// publishWhenSubscribed publishes msgs to topic once the topic has a
+// subscriber. Any messages are published as is.
+func publishWhenSubscribed(t *testing.T, ps *pubsubfake.PubSub, topic string, msgs ...proto.Message) {
+	t.Helper()
+	pub, err := ps.NewPublisher(topic, pubsubinterface.TopicConfig{Qos: pubsubinterface.HighReliability})
+	if err != nil {
+		t.Fatalf("NewPublisher() failed: %v", err)
+	}
+	defer pub.Close()
+	for {
+		ok, err := pub.HasMatchingSubscribers()
+		if err != nil {
+			t.Fatalf("HasMatchingSubscribers() failed: %v", err)
+		}
+		if ok {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	for _, msg := range msgs {
+		var err error
+		if a, ok := msg.(*anypb.Any); ok {
+			err = pub.PublishAny(a)
+		} else {
+			err = pub.Publish(msg)
+		}
+		if err != nil {
+			t.Fatalf("Publish() failed: %v", err)
+		}
+	}
+}
+
+func TestEcho(t *testing.T) {
+	unknown := &anypb.Any{TypeUrl: "type.googleapis.com/unknown.Message"}
+	tests := []struct {
+		name   string
+		format string
+		check  func(t *testing.T, out string)
+	}{
+		{
+			name:   "textproto",
+			format: printer.TextOutputFormat,
+			check: func(t *testing.T, out string) {
+				if !strings.Contains(out, "value: 1") || !strings.Contains(out, "value: 2") {
+					t.Errorf("echo printed %q, want both messages as text protos", out)
+				}
+				if got := strings.Count(out, "---"); got != 3 {
+					t.Errorf("echo printed %d messages, want 3:\n%s", got, out)
+				}
+				if !strings.Contains(out, "could not decode message") {
+					t.Errorf("echo printed %q, want an error for the message of unknown type", out)
+				}
+			},
+		},
+		{
+			name:   "ndjson",
+			format: printer.NDJSONOutputFormat,
+			check: func(t *testing.T, out string) {
+				lines := strings.Split(strings.TrimSpace(out), "\n")
+				if len(lines) != 3 {
+					t.Fatalf("echo printed %d lines, want 3:\n%s", len(lines), out)
+				}
+				var m topicMessage
+				if err := json.Unmarshal([]byte(lines[0]), &m); err != nil {
+					t.Fatalf("json.Unmarshal(%q) failed: %v", lines[0], err)
+				}
+				if m.TypeURL != "type.googleapis.com/google.protobuf.Int64Value" || string(m.Message) != `"1"` || m.PublishTime == "" {
+					t.Errorf("echo printed %+v, want the first message with its publish time", m)
+				}
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := pubsubfake.New()
+			defer ps.Close()
+			out := &bytes.Buffer{}
+			runner := &EchoCmdRunner{
+				ps:           ps,
+				resolver:     protoregistry.GlobalTypes,
+				outputWriter: out,
+				format:       tc.format,
+				count:        3,
+				limiter:      newRateLimiter(0),
+			}
+
+			done := make(chan error)
+			go func() { done <- runner.run(context.Background(), "values") }()
+			publishWhenSubscribed(t, ps, "values", wrapperspb.Int64(1), wrapperspb.Int64(2), unknown)
+			if err := <-done; err != nil {
+				t.Fatalf("run() failed: %v", err)
+			}
+			tc.check(t, out.String())
+		})
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	l := newRateLimiter(10)
+	start := time.Now()
+	for _, tc := range []struct {
+		offset time.Duration
+		want   bool
+	}{
+		{offset: 0, want: true},
+		{offset: 50 * time.Millisecond, want: false},
+		{offset: 100 * time.Millisecond, want: true},
+		{offset: 150 * time.Millisecond, want: false},
+		{offset: 250 * time.Millisecond, want: true},
+	} {
+		if got := l.allow(start.Add(tc.offset)); got != tc.want {
+			t.Errorf("allow(+%v) = %v, want %v", tc.offset, got, tc.want)
+		}
+	}
+	if unlimited := newRateLimiter(0); !unlimited.allow(start) || !unlimited.allow(start) {
+		t.Errorf("allow() of an unlimited rate limiter returned false")
+	}
+}
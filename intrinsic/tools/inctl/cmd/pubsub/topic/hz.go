@@ -0,0 +1,285 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topic
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"intrinsic/assets/cmdutils"
+	"intrinsic/platform/pubsub/golang/pubsubinterface"
+	"intrinsic/tools/inctl/cmd/pubsub/pubsub"
+	"intrinsic/tools/inctl/cmd/root"
+	"intrinsic/tools/inctl/util/printer"
+
+	"github.com/spf13/cobra"
+
+	pubsubpb "intrinsic/platform/pubsub/adapters/pubsub_go_proto"
+)
+
+const (
+	keyWindow = "window"
+)
+
+// hzReportInterval is the interval at which hz prints statistics.
+var hzReportInterval = time.Second
+
+// latencyStats are statistics of the time between publishing and receiving
+// messages. They are only meaningful if the clocks of publisher and
+// subscriber are synchronized.
+type latencyStats struct {
+	MeanS float64 `json:"mean_s"`
+	MinS  float64 `json:"min_s"`
+	MaxS  float64 `json:"max_s"`
+}
+
+// hzStats are statistics of the messages received in a window, as printed by
+// hz.
+type hzStats struct {
+	Messages      int           `json:"messages"`
+	RateHz        float64       `json:"rate_hz"`
+	MinPeriodS    float64       `json:"min_period_s"`
+	MaxPeriodS    float64       `json:"max_period_s"`
+	StdDevPeriodS float64       `json:"std_dev_period_s"`
+	Latency       *latencyStats `json:"latency,omitempty"`
+}
+
+func (s *hzStats) String() string {
+	str := fmt.Sprintf("average rate: %.3f Hz\n\tmin: %.5fs max: %.5fs std dev: %.5fs window: %d",
+		s.RateHz, s.MinPeriodS, s.MaxPeriodS, s.StdDevPeriodS, s.Messages)
+	if s.Latency != nil {
+		str += fmt.Sprintf("\n\tlatency mean: %.5fs min: %.5fs max: %.5fs", s.Latency.MeanS, s.Latency.MinS, s.Latency.MaxS)
+	}
+	return str
+}
+
+// computeHzStats computes statistics from the arrival times of messages, in
+// order, and the latencies of the messages that have a publish time. It
+// returns false if there are fewer than two arrivals.
+func computeHzStats(arrivals []time.Time, latencies []time.Duration) (*hzStats, bool) {
+	if len(arrivals) < 2 {
+		return nil, false
+	}
+	periods := make([]float64, 0, len(arrivals)-1)
+	for i := 1; i < len(arrivals); i++ {
+		periods = append(periods, arrivals[i].Sub(arrivals[i-1]).Seconds())
+	}
+	mean, minPeriod, maxPeriod := summarize(periods)
+	variance := 0.0
+	for _, p := range periods {
+		variance += (p - mean) * (p - mean)
+	}
+	variance /= float64(len(periods))
+
+	stats := &hzStats{
+		Messages:      len(arrivals),
+		MinPeriodS:    minPeriod,
+		MaxPeriodS:    maxPeriod,
+		StdDevPeriodS: math.Sqrt(variance),
+	}
+	if mean > 0 {
+		stats.RateHz = 1 / mean
+	}
+	if len(latencies) > 0 {
+		seconds := make([]float64, 0, len(latencies))
+		for _, l := range latencies {
+			seconds = append(seconds, l.Seconds())
+		}
+		stats.Latency = &latencyStats{}
+		stats.Latency.MeanS, stats.Latency.MinS, stats.Latency.MaxS = summarize(seconds)
+	}
+	return stats, true
+}
+
+// summarize returns the mean, minimum and maximum of a non-empty slice.
+func summarize(values []float64) (mean, lower, upper float64) {
+	lower, upper = values[0], values[0]
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+		lower = min(lower, v)
+		upper = max(upper, v)
+	}
+	return sum / float64(len(values)), lower, upper
+}
+
+// hzWindow holds the arrival times and latencies of the most recent messages.
+type hzWindow struct {
+	mu        sync.Mutex
+	size      int
+	arrivals  []time.Time
+	latencies []time.Duration
+	// received is the total number of received messages.
+	received int
+}
+
+func (w *hzWindow) add(packet *pubsubpb.PubSubPacket, now time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.received++
+	w.arrivals = append(w.arrivals, now)
+	if len(w.arrivals) > w.size {
+		w.arrivals = w.arrivals[len(w.arrivals)-w.size:]
+	}
+	if packet.GetPublishTime() != nil {
+		w.latencies = append(w.latencies, now.Sub(packet.GetPublishTime().AsTime()))
+		if len(w.latencies) > w.size {
+			w.latencies = w.latencies[len(w.latencies)-w.size:]
+		}
+	}
+}
+
+// stats returns the statistics of the window and the total number of
+// received messages.
+func (w *hzWindow) stats() (*hzStats, bool, int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	stats, ok := computeHzStats(w.arrivals, w.latencies)
+	return stats, ok, w.received
+}
+
+// HzCmdRunner handles execution of the hz command.
+// That command prints the rate and latency of messages published on a topic
+// expression.
+type HzCmdRunner struct {
+	ps           pubsubinterface.PubSub
+	outputWriter io.Writer
+	format       string
+
+	// window is the number of most recent messages used for the statistics.
+	window int
+	// count is the number of reports to print before returning. Zero means no
+	// limit.
+	count int
+}
+
+func (r *HzCmdRunner) run(ctx context.Context, topic string) error {
+	if r.window < 2 {
+		return fmt.Errorf("--%s must be at least 2, got %d", keyWindow, r.window)
+	}
+	w := &hzWindow{size: r.window}
+	sub, err := r.ps.NewRawSubscription(topic, pubsubinterface.TopicConfig{Qos: pubsubinterface.Sensor},
+		func(packet *pubsubpb.PubSubPacket) {
+			w.add(packet, time.Now())
+		})
+	if err != nil {
+		return fmt.Errorf("could not subscribe to %q: %w", topic, err)
+	}
+	defer sub.Close()
+
+	ticker := time.NewTicker(hzReportInterval)
+	defer ticker.Stop()
+	reported, lastReceived := 0, 0
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		stats, ok, received := w.stats()
+		if received == lastReceived {
+			if r.format == printer.TextOutputFormat {
+				fmt.Fprintln(r.outputWriter, "no new messages")
+			}
+			continue
+		}
+		lastReceived = received
+		if !ok {
+			continue
+		}
+		if err := printValue(r.outputWriter, r.format, stats); err != nil {
+			return fmt.Errorf("could not print statistics: %w", err)
+		}
+		reported++
+		if r.count > 0 && reported >= r.count {
+			return nil
+		}
+	}
+}
+
+// HzCmdEnvironment is the execution environment for the hz command.
+// That environment contains command line flags.
+type HzCmdEnvironment struct {
+	cmdFlags *cmdutils.CmdFlags
+}
+
+// RunE sets up the execution environment and invokes HzCmdRunner.run.
+func (e *HzCmdEnvironment) RunE(cmd *cobra.Command, args []string) error {
+	if err := checkOutputFormat(root.FlagOutput); err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	ps, err := newPubSub()
+	if err != nil {
+		return err
+	}
+	defer ps.Close()
+
+	runner := &HzCmdRunner{
+		ps:           ps,
+		outputWriter: cmd.OutOrStdout(),
+		format:       root.FlagOutput,
+		window:       e.cmdFlags.GetInt(keyWindow),
+		count:        e.cmdFlags.GetInt(keyCount),
+	}
+	return runner.run(ctx, args[0])
+}
+
+// NewHzCmd returns the initialized cobra command for hz.
+func NewHzCmd() *cobra.Command {
+	flags := cmdutils.NewCmdFlags()
+	commandWrapper := &HzCmdEnvironment{cmdFlags: flags}
+
+	cmd := &cobra.Command{
+		Use:   "hz <topic-expr>",
+		Short: "Prints the rate and latency of messages on PubSub topics.",
+		Long: `Prints the rate of the messages published on the topics that match the given
+key expression, once per second.
+
+The latency is the time between publishing and receiving a message and is only
+meaningful if the clocks of publisher and subscriber are synchronized. Must be
+run on the workcell or a machine in its network.
+
+Example:
+  inctl pubsub hz "robot/status" --window 50`,
+		Args: cobra.ExactArgs(1),
+		RunE: commandWrapper.RunE,
+	}
+
+	flags.SetCommand(cmd)
+
+	flags.Int(
+		keyWindow,
+		100,
+		"(optional) Number of most recent messages used to compute the statistics.")
+	flags.Int(
+		keyCount,
+		0,
+		"(optional) Number of reports to print before exiting. 0 prints reports until interrupted.")
+
+	return cmd
+}
+
+func init() {
+	pubsub.PubsubCmd.AddCommand(NewHzCmd())
+}
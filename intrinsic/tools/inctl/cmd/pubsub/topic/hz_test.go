@@ -0,0 +1,56 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topic
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestComputeHzStats(t *testing.T) {
+	start := time.Now()
+	arrivals := []time.Time{
+		start,
+		start.Add(100 * time.Millisecond),
+		start.Add(300 * time.Millisecond),
+		start.Add(400 * time.Millisecond),
+	}
+	latencies := []time.Duration{time.Millisecond, 3 * time.Millisecond}
+
+	got, ok := computeHzStats(arrivals, latencies)
+	if !ok {
+		t.Fatalf("computeHzStats() returned false, want statistics")
+	}
+	// The periods are 0.1s, 0.2s and 0.1s.
+	want := &hzStats{
+		Messages:      4,
+		RateHz:        7.5,
+		MinPeriodS:    0.1,
+		MaxPeriodS:    0.2,
+		StdDevPeriodS: math.Sqrt(2.0 / 900),
+		Latency:       &latencyStats{MeanS: 0.002, MinS: 0.001, MaxS: 0.003},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+		t.Errorf("computeHzStats() returned unexpected statistics (-want +got):\n%s", diff)
+	}
+
+	if _, ok := computeHzStats(arrivals[:1], nil); ok {
+		t.Errorf("computeHzStats() with a single arrival returned true, want false")
+	}
+}
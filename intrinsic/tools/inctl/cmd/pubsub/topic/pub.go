@@ -0,0 +1,224 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topic
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"intrinsic/assets/cmdutils"
+	anyresolver "intrinsic/httpjson/any"
+	"intrinsic/platform/pubsub/golang/pubsubinterface"
+	"intrinsic/tools/inctl/cmd/pubsub/pubsub"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	keyMessageType = "type"
+	keyMessageJSON = "json"
+	keyRate        = "rate"
+	keyWait        = "wait-for-subscribers"
+)
+
+// subscriberPollInterval is the interval at which pub checks for subscribers
+// before publishing.
+var subscriberPollInterval = 100 * time.Millisecond
+
+// PubCmdRunner handles execution of the pub command.
+// That command publishes a message given as JSON to a topic.
+type PubCmdRunner struct {
+	ps           pubsubinterface.PubSub
+	resolver     anyresolver.Resolver
+	outputWriter io.Writer
+
+	// count is the number of times the message is published.
+	count int
+	// interval is the time between two messages if count is greater than one.
+	interval time.Duration
+	// wait is the time to wait for subscribers before publishing.
+	wait time.Duration
+}
+
+// parseMessage parses content as JSON into a message of the given type, which
+// is a full message name or a type URL.
+func (r *PubCmdRunner) parseMessage(messageType string, content string) (proto.Message, error) {
+	msgType, err := findMessageType(r.resolver, messageType)
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve type %q: %w", messageType, err)
+	}
+	msg := msgType.New().Interface()
+	if err := (protojson.UnmarshalOptions{Resolver: r.resolver}).Unmarshal([]byte(content), msg); err != nil {
+		return nil, fmt.Errorf("could not parse message as %s: %w", msgType.Descriptor().FullName(), err)
+	}
+	return msg, nil
+}
+
+// waitForSubscribers waits until the publisher has matching subscribers or
+// the wait time has passed. Messages published before subscribers are
+// discovered would be lost.
+func (r *PubCmdRunner) waitForSubscribers(ctx context.Context, pub pubsubinterface.Publisher) error {
+	deadline := time.Now().Add(r.wait)
+	for {
+		ok, err := pub.HasMatchingSubscribers()
+		if err != nil {
+			return fmt.Errorf("could not check for subscribers: %w", err)
+		}
+		if ok {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			fmt.Fprintf(r.outputWriter, "No subscribers for %q after %v, publishing anyway.\n", pub.TopicName(), r.wait)
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(subscriberPollInterval):
+		}
+	}
+}
+
+func (r *PubCmdRunner) run(ctx context.Context, topic string, messageType string, content string) error {
+	if r.count < 1 {
+		return fmt.Errorf("--%s must be at least 1, got %d", keyCount, r.count)
+	}
+	msg, err := r.parseMessage(messageType, content)
+	if err != nil {
+		return err
+	}
+	pub, err := r.ps.NewPublisher(topic, pubsubinterface.TopicConfig{Qos: pubsubinterface.HighReliability})
+	if err != nil {
+		return fmt.Errorf("could not create publisher for %q: %w", topic, err)
+	}
+	defer pub.Close()
+	if err := r.waitForSubscribers(ctx, pub); err != nil {
+		return err
+	}
+
+	for i := range r.count {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(r.interval):
+			}
+		}
+		if err := pub.Publish(msg); err != nil {
+			return fmt.Errorf("could not publish to %q: %w", topic, err)
+		}
+	}
+	fmt.Fprintf(r.outputWriter, "Published %d message(s) to %q.\n", r.count, pub.TopicName())
+	return nil
+}
+
+// PubCmdEnvironment is the execution environment for the pub command.
+// That environment contains command line flags.
+type PubCmdEnvironment struct {
+	cmdFlags *cmdutils.CmdFlags
+}
+
+// RunE sets up the execution environment and invokes PubCmdRunner.run.
+func (e *PubCmdEnvironment) RunE(cmd *cobra.Command, args []string) error {
+	wait, err := time.ParseDuration(e.cmdFlags.GetString(keyWait))
+	if err != nil {
+		return fmt.Errorf("invalid value for --%s: %w", keyWait, err)
+	}
+	rate := e.cmdFlags.GetInt(keyRate)
+	if rate < 1 {
+		return fmt.Errorf("--%s must be at least 1, got %d", keyRate, rate)
+	}
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	ps, err := newPubSub()
+	if err != nil {
+		return err
+	}
+	defer ps.Close()
+	resolver, closeResolver, err := newTypeResolver(e.cmdFlags.GetString(keyResolverAddress))
+	if err != nil {
+		return err
+	}
+	defer closeResolver()
+
+	runner := &PubCmdRunner{
+		ps:           ps,
+		resolver:     resolver,
+		outputWriter: cmd.OutOrStdout(),
+		count:        e.cmdFlags.GetInt(keyCount),
+		interval:     time.Second / time.Duration(rate),
+		wait:         wait,
+	}
+	return runner.run(ctx, args[0], e.cmdFlags.GetString(keyMessageType), e.cmdFlags.GetString(keyMessageJSON))
+}
+
+// NewPubCmd returns the initialized cobra command for pub.
+func NewPubCmd() *cobra.Command {
+	flags := cmdutils.NewCmdFlags()
+	commandWrapper := &PubCmdEnvironment{cmdFlags: flags}
+
+	cmd := &cobra.Command{
+		Use:   "pub <topic>",
+		Short: "Publishes a message to a PubSub topic.",
+		Long: `Publishes a message, given as JSON, to a PubSub topic.
+
+The message type is resolved through the ProtoRegistry and InstalledAssets
+services of the workcell. Must be run on the workcell or a machine in its
+network.
+
+Example:
+  inctl pubsub pub "robot/command" --type intrinsic_proto.Example --json '{"value": 1}'`,
+		Args: cobra.ExactArgs(1),
+		RunE: commandWrapper.RunE,
+	}
+
+	flags.SetCommand(cmd)
+
+	flags.RequiredString(
+		keyMessageType,
+		"Full name or type URL of the message type, e.g., google.protobuf.StringValue.")
+	flags.RequiredString(
+		keyMessageJSON,
+		"The message in JSON format.")
+	flags.OptionalString(
+		keyResolverAddress,
+		defaultResolverAddress,
+		"Address of the services used to resolve message types.")
+	flags.Int(
+		keyCount,
+		1,
+		"(optional) Number of times to publish the message.")
+	flags.Int(
+		keyRate,
+		1,
+		"(optional) Number of messages to publish per second if --count is greater than 1.")
+	flags.OptionalString(
+		keyWait,
+		"2s",
+		"Time to wait for subscribers before publishing.")
+
+	return cmd
+}
+
+func init() {
+	pubsub.PubsubCmd.AddCommand(NewPubCmd())
+}
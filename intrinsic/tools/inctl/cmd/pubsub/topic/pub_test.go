@@ -0,0 +1,94 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topic
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"intrinsic/platform/pubsub/golang/pubsubfake"
+	"intrinsic/platform/pubsub/golang/pubsubinterface"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestPub(t *testing.T) {
+	ps := pubsubfake.New()
+	defer ps.Close()
+
+	var mu sync.Mutex
+	var got []string
+	if _, err := ps.NewSubscription("greetings", pubsubinterface.TopicConfig{Qos: pubsubinterface.HighReliability}, &wrapperspb.StringValue{},
+		func(msg proto.Message) {
+			mu.Lock()
+			defer mu.Unlock()
+			got = append(got, msg.(*wrapperspb.StringValue).GetValue())
+		}, nil); err != nil {
+		t.Fatalf("NewSubscription() failed: %v", err)
+	}
+
+	out := &bytes.Buffer{}
+	runner := &PubCmdRunner{
+		ps:           ps,
+		resolver:     protoregistry.GlobalTypes,
+		outputWriter: out,
+		count:        2,
+		interval:     time.Millisecond,
+	}
+	if err := runner.run(context.Background(), "greetings", "google.protobuf.StringValue", `"hello"`); err != nil {
+		t.Fatalf("run() failed: %v", err)
+	}
+	ps.Flush()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if diff := cmp.Diff([]string{"hello", "hello"}, got); diff != "" {
+		t.Errorf("pub published unexpected messages (-want +got):\n%s", diff)
+	}
+}
+
+func TestPubInvalidMessage(t *testing.T) {
+	ps := pubsubfake.New()
+	defer ps.Close()
+	runner := &PubCmdRunner{
+		ps:           ps,
+		resolver:     protoregistry.GlobalTypes,
+		outputWriter: &bytes.Buffer{},
+		count:        1,
+	}
+
+	tests := []struct {
+		name        string
+		messageType string
+		content     string
+	}{
+		{name: "unknown type", messageType: "unknown.Message", content: `{}`},
+		{name: "invalid JSON", messageType: "google.protobuf.Int64Value", content: `"not a number"`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := runner.run(context.Background(), "values", tc.messageType, tc.content); err == nil {
+				t.Errorf("run(%q, %q) succeeded, want error", tc.messageType, tc.content)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Intrinsic Innovation LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package topic implements the inctl pubsub commands that inspect PubSub
+// topics. They connect to the PubSub network of the workcell and therefore
+// depend on the native PubSub library, unlike the other pubsub commands.
+package topic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	anyresolver "intrinsic/httpjson/any"
+	pubsublib "intrinsic/platform/pubsub/golang/pubsub"
+	"intrinsic/platform/pubsub/golang/pubsubinterface"
+	"intrinsic/tools/inctl/util/printer"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pubsubpb "intrinsic/platform/pubsub/adapters/pubsub_go_proto"
+
+	anypb "google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	keyResolverAddress = "resolver-address"
+	keyCount           = "count"
+	keyMaxRate         = "max-rate"
+
+	// defaultResolverAddress is the ingress of the cluster when running inctl
+	// on the workcell. It serves the ProtoRegistry and InstalledAssets
+	// services used to resolve message types.
+	defaultResolverAddress = "localhost:17080"
+)
+
+// newPubSub connects to the PubSub network of the workcell. The topic
+// commands must therefore run on the workcell or a machine in its network.
+// Replaced in tests.
+var newPubSub = func() (pubsubinterface.PubSub, error) {
+	ps, err := pubsublib.NewPubSub()
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to PubSub: %w", err)
+	}
+	return ps, nil
+}
+
+// newTypeResolver returns a resolver for message types published on the
+// workcell and a function that releases it. Replaced in tests.
+var newTypeResolver = func(address string) (anyresolver.Resolver, func(), error) {
+	r, err := anyresolver.NewAnyResolver(address, address)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create type resolver for %q: %w", address, err)
+	}
+	return r, r.Close, nil
+}
+
+// findMessageType resolves a full message name or a type URL.
+func findMessageType(resolver anyresolver.Resolver, name string) (protoreflect.MessageType, error) {
+	if strings.Contains(name, "/") {
+		return resolver.FindMessageByURL(name)
+	}
+	return resolver.FindMessageByName(protoreflect.FullName(name))
+}
+
+// topicMessage is a message received on a topic, as printed by echo.
+type topicMessage struct {
+	PublishTime string          `json:"publish_time,omitempty"`
+	TypeURL     string          `json:"type_url"`
+	Message     json.RawMessage `json:"message,omitempty"`
+	Error       string          `json:"error,omitempty"`
+
+	// text is the text representation of the message.
+	text string
+}
+
+func (m *topicMessage) String() string {
+	s := fmt.Sprintf("# %s", m.TypeURL)
+	if m.PublishTime != "" {
+		s += " published at " + m.PublishTime
+	}
+	if m.Error != "" {
+		s += "\n# " + m.Error
+	}
+	if m.text != "" {
+		s += "\n" + strings.TrimSuffix(m.text, "\n")
+	}
+	return s + "\n---"
+}
+
+// newTopicMessage decodes the payload of a packet for printing. A payload
+// whose type cannot be resolved is reported in the Error field instead of
+// failing, so that echo keeps printing the other messages of a topic.
+func newTopicMessage(packet *pubsubpb.PubSubPacket, resolver anyresolver.Resolver, format string) *topicMessage {
+	m := &topicMessage{TypeURL: packet.GetPayload().GetTypeUrl()}
+	if packet.GetPublishTime() != nil {
+		m.PublishTime = packet.GetPublishTime().AsTime().Format(time.RFC3339Nano)
+	}
+	msg, err := anypb.UnmarshalNew(packet.GetPayload(), proto.UnmarshalOptions{Resolver: resolver})
+	if err != nil {
+		m.Error = fmt.Sprintf("could not decode message: %v", err)
+		return m
+	}
+	if format == printer.TextOutputFormat {
+		m.text = prototext.MarshalOptions{Resolver: resolver, Multiline: true, Indent: "  "}.Format(msg)
+		return m
+	}
+	m.Message, err = protojson.MarshalOptions{Resolver: resolver}.Marshal(msg)
+	if err != nil {
+		m.Error = fmt.Sprintf("could not marshal message to JSON: %v", err)
+	}
+	return m
+}
+
+// checkOutputFormat returns an error if the topic commands do not support the
+// given value of --output.
+func checkOutputFormat(format string) error {
+	switch format {
+	case printer.TextOutputFormat, printer.JSONOutputFormat, printer.NDJSONOutputFormat:
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+}
+
+// printValue prints v in the given format: as text using its String method,
+// as indented JSON or as a single line of JSON.
+func printValue(out io.Writer, format string, v fmt.Stringer) error {
+	switch format {
+	case printer.JSONOutputFormat:
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(b))
+		return err
+	case printer.NDJSONOutputFormat:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(b))
+		return err
+	default:
+		_, err := fmt.Fprintln(out, v.String())
+		return err
+	}
+}
+
+// rateLimiter limits the rate of events to a maximum number per second.
+type rateLimiter struct {
+	interval time.Duration
+	last     time.Time
+}
+
+// newRateLimiter returns a limiter for maxRate events per second. A maxRate of
+// zero or less does not limit the rate.
+func newRateLimiter(maxRate int) *rateLimiter {
+	if maxRate <= 0 {
+		return &rateLimiter{}
+	}
+	return &rateLimiter{interval: time.Second / time.Duration(maxRate)}
+}
+
+// allow reports whether an event at time now is within the rate limit, and
+// records it if so.
+func (l *rateLimiter) allow(now time.Time) bool {
+	if l.interval > 0 && !l.last.IsZero() && now.Sub(l.last) < l.interval {
+		return false
+	}
+	l.last = now
+	return true
+}
@@ -39,6 +39,8 @@ import (
 const (
 	// AssetCmdName is the name of the `inctl assets` command.
 	AssetCmdName = "asset"
+	// BlackboardCmdName is the name of the `inctl blackboard` command.
+	BlackboardCmdName = "blackboard"
 	// ClusterCmdName is the name of the `inctl cluster` command.
 	ClusterCmdName = "cluster"
 
@@ -103,7 +105,7 @@ func (e *executionContext) RewriteError(err error, cmdNames []string) string {
 		// (see b/292218614).
 		if grpcStatus.Code() == grpccodes.Unavailable && len(cmdNames) > 0 &&
 			slices.Contains([]string{
-				ClusterCmdName, ProcessCmdName, SolutionCmdName, SolutionsCmdName, SkillCmdName,
+				BlackboardCmdName, ClusterCmdName, ProcessCmdName, SolutionCmdName, SolutionsCmdName, SkillCmdName,
 			}, cmdNames[0]) {
 
 			return fmt.Sprintf("%v\nThe GCP project given by --project is not reachable at the "+
@@ -168,36 +170,56 @@ func getCommandNames() ([]string, error) {
 	return names, nil
 }
 
+// ExitError is an error which makes inctl exit with the given exit code
+// instead of the default exit code 1. Commands can return it (or wrap it) to
+// let scripts tell apart different failure modes.
+type ExitError struct {
+	// Code is the exit code of the inctl process.
+	Code int
+	// Err is the underlying error which is printed to the user.
+	Err error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
 // Execute is the top level function that runs the app and prints any errors.
-// It returns true if the command was successful.
+// It returns the exit code for the inctl process, which is 0 if the command was
+// successful.
 // rewriteError rewrites an error into a helpful string.
-func Execute(ec executionContext) bool {
+func Execute(ec executionContext) int {
 	ctx := context.Background()
 	RootCmd.SetArgs(flag.Args())
 
 	ctx, span := trace.StartSpan(ctx, "inctl")
 	defer span.End()
 
-	success := true
 	if err := RootCmd.ExecuteContext(ctx); err != nil {
 		cmdNames, _ := getCommandNames() // ignore error, cmdNames will simply be nil
 		RootCmd.PrintErrln("Error:", ec.RewriteError(err, cmdNames), "TraceID:", span.SpanContext().TraceID)
-		success = false
 
+		var exitErr *ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.Code
+		}
+		return 1
 	}
 
-	return success
+	return 0
 }
 
 // Inctl launches inctl with the currently configured commands.
 func Inctl() {
 	intrinsic.Init()
 
-	success := Execute(executionContext{})
-
-	if !success {
+	if code := Execute(executionContext{}); code != 0 {
 		log.Warning("Command failed")
-		os.Exit(1)
+		os.Exit(code)
 	}
 }
 